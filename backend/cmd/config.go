@@ -0,0 +1,27 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"nails_game/internal/config"
+)
+
+const configUsage = "usage: nails_game [flags] config print"
+
+func runConfig(args []string, cfg *config.Config, problems config.ValidationErrors) error {
+	if len(args) == 0 || args[0] != "print" {
+		return errors.New(configUsage)
+	}
+
+	if err := config.Print(os.Stdout, cfg); err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		fmt.Fprintln(os.Stderr, problems.Error())
+		return errors.New("configuration is invalid")
+	}
+	return nil
+}
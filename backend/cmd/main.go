@@ -1,17 +1,30 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"golang.org/x/time/rate"
 	_ "nails_game/docs"
 
+	"nails_game/internal/config"
 	"nails_game/internal/controllers"
 	"nails_game/internal/helpers"
+	"nails_game/internal/models/enums"
 	database "nails_game/internal/repositories"
 	repositories "nails_game/internal/repositories/implementation"
 	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
 )
 
 // @title Nails Game API
@@ -29,7 +42,39 @@ func main() {
 		logger.Warnf("Could not load .env file: %v", err)
 	}
 
-	db, cfg, err := database.InitDB(logger)
+	cfg, command, err := config.Load(os.Args[1:])
+	var invalid config.ValidationErrors
+	if err != nil && !errors.As(err, &invalid) {
+		logger.WithError(err).Fatal("Failed to load configuration")
+	}
+
+	if len(command) > 0 && command[0] == "config" {
+		if err := runConfig(command[1:], cfg, invalid); err != nil {
+			logger.WithError(err).Fatal("Config command failed")
+		}
+		return
+	}
+
+	if invalid != nil {
+		logger.WithField("problems", []string(invalid)).Fatal("Invalid configuration")
+	}
+
+	if len(command) > 0 && command[0] == "migrate" {
+		if err := runMigrate(command[1:], cfg); err != nil {
+			logger.WithError(err).Fatal("Migration command failed")
+		}
+		return
+	}
+
+	if len(command) > 0 && command[0] != "serve" {
+		logger.Fatalf("Unknown command %q, expected serve, migrate or config", command[0])
+	}
+
+	runServer(logger, cfg)
+}
+
+func runServer(logger *logrus.Logger, cfg *config.Config) {
+	db, err := database.InitDB(logger, cfg.Database)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to initialize database")
 	}
@@ -38,27 +83,198 @@ func main() {
 
 	gameRepo := repositories.NewGameRepository(db)
 	playerRepo := repositories.NewPlayerRepository(db)
+	boardPresetRepo := repositories.NewBoardPresetRepository(db)
+	tournamentRepo := repositories.NewTournamentRepository(db)
+	chatRepo := repositories.NewChatRepository(db)
+	seriesRepo := repositories.NewSeriesRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+
+	policy := gameSettingsPolicy(cfg.Game)
+	policy.AllowPrivateGames = cfg.Auth.TokenSecret != ""
+	webhookService := services.NewWebhookService(webhookRepo, tournamentRepo, webhookPolicy(cfg.Webhooks), time.Now)
+	gameFeed := services.NewGameFeed(policy.FogSpectatorDelay)
+	gameService := services.NewGameService(gameRepo, playerRepo, boardPresetRepo, policy,
+		services.WithEventOutbox(webhookService), services.WithEventPublisher(gameFeed))
+	boardService := services.NewBoardService(boardPresetRepo, policy)
+	tournamentService := services.NewTournamentService(tournamentRepo, playerRepo, gameService, time.Now)
+	seriesService := services.NewSeriesService(seriesRepo, playerRepo, gameService, time.Now)
+	var notifier serviceInterfaces.Notifier
+	if cfg.Notifications.SMTPHost != "" {
+		notifier = services.NewSMTPNotifier(cfg.Notifications.SMTPHost, cfg.Notifications.SMTPPort,
+			cfg.Notifications.SMTPUsername, cfg.Notifications.SMTPPassword, cfg.Notifications.From, time.Now)
+	}
+	notificationService := services.NewNotificationService(notificationRepo, gameRepo, playerRepo, notifier,
+		notificationPolicy(cfg.Notifications), time.Now)
+	chatService := services.NewChatService(chatRepo, playerRepo, gameService, gameFeed,
+		services.NewWordListFilter(cfg.Chat.BannedWords), chatPolicy(cfg.Chat), time.Now)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	gameService := services.NewGameService(gameRepo, playerRepo, cfg.LineSize)
+	clockScheduler := services.NewClockScheduler(gameService, cfg.Game.ClockCheckInterval, logger)
+	go clockScheduler.Run(ctx)
+	tournamentScheduler := services.NewTournamentScheduler(tournamentService, cfg.Game.TournamentCheckInterval, logger)
+	go tournamentScheduler.Run(ctx)
+	arenaScheduler := services.NewArenaScheduler(tournamentService, cfg.Game.ArenaPairingInterval, logger)
+	go arenaScheduler.Run(ctx)
+	seriesScheduler := services.NewSeriesScheduler(seriesService, cfg.Game.SeriesCheckInterval, logger)
+	go seriesScheduler.Run(ctx)
+	chatScheduler := services.NewChatScheduler(chatService, cfg.Chat.RateWindow, logger)
+	go chatScheduler.Run(ctx)
+	webhookScheduler := services.NewWebhookScheduler(webhookService, cfg.Webhooks.DispatchInterval, logger)
+	go webhookScheduler.Run(ctx)
+	if notifier != nil {
+		notificationScheduler := services.NewNotificationScheduler(notificationService, cfg.Notifications.CheckInterval, logger)
+		go notificationScheduler.Run(ctx)
+	}
 
-	gameController := controllers.NewGameController(gameService)
+	var tokens serviceInterfaces.TokenService
+	if cfg.Auth.TokenSecret != "" {
+		tokens = services.NewTokenService(cfg.Auth.TokenSecret, cfg.Auth.TokenTTL, time.Now)
+	}
+	authController := controllers.NewAuthController(services.NewAuthService(playerRepo, tokens))
+
+	gameController := controllers.NewGameController(gameService, chatService, tokens, services.NewSpectatorRegistry(), gameFeed)
+	chatController := controllers.NewChatController(chatService, tokens)
+	boardController := controllers.NewBoardController(boardService)
+	tournamentController := controllers.NewTournamentController(tournamentService, tokens)
+	seriesController := controllers.NewSeriesController(seriesService, tokens)
+	notificationController := controllers.NewNotificationController(notificationService, tokens)
+	webhookController := controllers.NewWebhookController(webhookService, tokens, cfg.Webhooks.AdminKey)
 	healthController := controllers.NewHealthController()
 
 	e := echo.New()
+	e.HideBanner = true
+	e.Server.ReadTimeout = cfg.Server.ReadTimeout
+	e.Server.WriteTimeout = cfg.Server.WriteTimeout
+	e.HTTPErrorHandler = controllers.NewErrorHandler(logger)
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	e.Use(middleware.RequestID())
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	e.Use(middleware.BodyLimit(cfg.Limits.MaxBodySize))
+	e.Use(controllers.NewAuthMiddleware(tokens))
+	if cfg.Limits.RequestsPerSecond > 0 {
+		e.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStoreWithConfig(
+			middleware.RateLimiterMemoryStoreConfig{
+				Rate:  rate.Limit(cfg.Limits.RequestsPerSecond),
+				Burst: cfg.Limits.Burst,
+			},
+		)))
+	}
+
+	if tokens != nil {
+		e.POST("/api/auth/login", authController.Login)
+	}
 
 	e.POST("/api/game", gameController.CreateGame)
 	e.POST("/api/game/:gameId/move", gameController.MakeMove)
+	e.POST("/api/game/:gameId/resign", gameController.Resign)
+	e.POST("/api/game/:gameId/abort", gameController.Abort)
+	e.POST("/api/game/:gameId/draw/offer", gameController.OfferDraw)
+	e.POST("/api/game/:gameId/draw/accept", gameController.AcceptDraw)
+	e.POST("/api/game/:gameId/draw/decline", gameController.DeclineDraw)
+	e.POST("/api/game/:gameId/takeback/request", gameController.RequestTakeback)
+	e.POST("/api/game/:gameId/takeback/accept", gameController.AcceptTakeback)
+	e.POST("/api/game/:gameId/takeback/decline", gameController.DeclineTakeback)
+	e.POST("/api/game/:gameId/rematch/offer", gameController.OfferRematch)
+	e.POST("/api/game/:gameId/rematch/accept", gameController.AcceptRematch)
+	e.POST("/api/game/:gameId/rematch/decline", gameController.DeclineRematch)
+
+	e.GET("/api/boards/presets", boardController.ListPresets)
+	e.POST("/api/boards/presets", boardController.CreatePreset)
+	e.GET("/api/boards/presets/:name", boardController.GetPreset)
+	e.GET("/api/boards/generate", boardController.GenerateBoard)
 	e.GET("/api/game/:gameId", gameController.GetGame)
+	e.GET("/api/variants", gameController.ListVariants)
+	e.GET("/api/game/:gameId/events", gameController.GetGameEvents)
+	e.GET("/api/game/:gameId/stream", gameController.StreamGame)
+	e.GET("/api/games/live", gameController.ListLiveGames)
+	e.GET("/api/game/:gameId/chat", chatController.ListMessages)
+	e.POST("/api/game/:gameId/chat", chatController.SendMessage)
+	e.POST("/api/game/:gameId/chat/mute", chatController.Mute)
+	e.POST("/api/game/:gameId/chat/unmute", chatController.Unmute)
+
+	e.GET("/api/tournaments", tournamentController.ListTournaments)
+	e.POST("/api/tournaments", tournamentController.CreateTournament)
+	e.GET("/api/tournaments/:tournamentId", tournamentController.GetTournament)
+	e.POST("/api/tournaments/:tournamentId/register", tournamentController.Register)
+	e.POST("/api/tournaments/:tournamentId/leave", tournamentController.Leave)
+	e.POST("/api/tournaments/:tournamentId/start", tournamentController.Start)
+	e.GET("/api/tournaments/:tournamentId/standings", tournamentController.GetStandings)
+
+	e.GET("/api/series", seriesController.ListSeries)
+	e.POST("/api/series", seriesController.CreateSeries)
+	e.GET("/api/series/:seriesId", seriesController.GetSeries)
+
+	e.GET("/api/players/:playerId/notifications", notificationController.GetPreferences)
+	e.PUT("/api/players/:playerId/notifications", notificationController.UpdatePreferences)
+
+	e.POST("/api/webhooks", webhookController.Register)
+	e.DELETE("/api/webhooks/:webhookId", webhookController.Delete)
+	e.GET("/api/webhooks/:webhookId/deliveries", webhookController.ListDeliveries)
+	e.POST("/api/webhooks/:webhookId/deliveries/:deliveryId/retry", webhookController.RetryDelivery)
 
 	e.GET("/health", healthController.CheckHealth)
 
-	logger.WithField("port", cfg.Port).Info("Starting server")
-	if err := e.Start(":" + cfg.Port); err != nil {
-		logger.WithError(err).Fatal("Failed to start server")
+	go func() {
+		logger.WithField("port", cfg.Server.Port).Info("Starting server")
+		if err := e.Start(":" + cfg.Server.Port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.WithError(err).Fatal("Failed to start server")
+		}
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		logger.WithError(err).Error("Failed to shut down server gracefully")
+	}
+}
+
+func webhookPolicy(cfg config.WebhooksConfig) serviceInterfaces.WebhookPolicy {
+	return serviceInterfaces.WebhookPolicy{
+		MaxAttempts:         cfg.MaxAttempts,
+		RetryBase:           cfg.RetryBase,
+		RetryMax:            cfg.RetryMax,
+		Timeout:             cfg.Timeout,
+		AllowPrivateTargets: cfg.AllowPrivateTargets,
+	}
+}
+
+func notificationPolicy(cfg config.NotificationsConfig) serviceInterfaces.NotificationPolicy {
+	return serviceInterfaces.NotificationPolicy{
+		ReminderBefore: cfg.ReminderBefore,
+		DigestInterval: cfg.DigestInterval,
+	}
+}
+
+func chatPolicy(cfg config.ChatConfig) serviceInterfaces.ChatPolicy {
+	return serviceInterfaces.ChatPolicy{
+		MaxMessageLength: cfg.MaxMessageLength,
+		MaxMessages:      cfg.MaxMessages,
+		RateWindow:       cfg.RateWindow,
+	}
+}
+
+func gameSettingsPolicy(cfg config.GameConfig) serviceInterfaces.GameSettingsPolicy {
+	policy := serviceInterfaces.GameSettingsPolicy{
+		MinLineSize:           cfg.MinLineSize,
+		MaxLineSize:           cfg.MaxLineSize,
+		DefaultLineSize:       cfg.DefaultLineSize,
+		AllowTakebacksInRated: cfg.AllowTakebacksInRated,
+		MaxCoordinateGap:      cfg.MaxCoordinateGap,
+		FogSpectatorDelay:     cfg.FogSpectatorDelay,
+	}
+	for _, v := range cfg.AllowedVariants {
+		policy.AllowedVariants = append(policy.AllowedVariants, enums.Variant(v))
+	}
+	for _, tc := range cfg.AllowedTimeControls {
+		policy.AllowedTimeControls = append(policy.AllowedTimeControls, enums.TimeControlType(tc))
 	}
+	return policy
 }
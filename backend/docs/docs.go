@@ -15,9 +15,240 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/auth/login": {
+            "post": {
+                "description": "Проверяет почту и пароль игрока и выдаёт токен, действующий во всех его партиях, турнирах и сериях. Токены из ответа на создание партии действуют только в ней",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Войти",
+                "parameters": [
+                    {
+                        "description": "Почта и пароль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "INVALID_CREDENTIALS",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/boards/generate": {
+            "get": {
+                "description": "Строит координаты поля по зерну; одинаковые зерно и размер всегда дают одинаковое поле",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Сгенерировать поле",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Зерно генератора",
+                        "name": "seed",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество позиций",
+                        "name": "size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GeneratedBoardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/boards/presets": {
+            "get": {
+                "description": "Возвращает все сохранённые пресеты полей по имени",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Список пресетов полей",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.BoardPresetResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Сохраняет именованную раскладку поля из явных координат или из зерна и размера",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Сохранить пресет поля",
+                "parameters": [
+                    {
+                        "description": "Данные пресета",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateBoardPresetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BoardPresetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "BOARD_PRESET_EXISTS",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/boards/presets/{name}": {
+            "get": {
+                "description": "Возвращает координаты сохранённого пресета",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Получить пресет поля",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пресета",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BoardPresetResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/game": {
             "post": {
-                "description": "Создает новую игру между двумя игроками",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новую игру между двумя игроками или от трёх до шести игроками из playerIds; поле может быть нерегулярным - из пресета, явных координат или по зерну. Создать партию может только один из её игроков по токену входа",
                 "consumes": [
                     "application/json"
                 ],
@@ -46,22 +277,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.CreateGameResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED, INVALID_TOKEN",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
@@ -69,7 +312,12 @@ const docTemplate = `{
         },
         "/api/game/{gameId}": {
             "get": {
-                "description": "Возвращает текущее состояние указанной игры",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает текущее состояние указанной игры. В варианте fog игрок с токеном видит только свои и открытые им гвозди, а зрители - поле с задержкой. Закрытая партия доступна только её игрокам",
                 "produces": [
                     "application/json"
                 ],
@@ -93,30 +341,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.GameStateResponse"
                         }
                     },
+                    "401": {
+                        "description": "INVALID_TOKEN, AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_NOT_IN_GAME",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/game/{gameId}/move": {
+        "/api/game/{gameId}/abort": {
             "post": {
-                "description": "Выполняет ход в указанной игре",
+                "description": "Прерывает партию без результата; доступно только до первого хода каждого игрока",
                 "consumes": [
                     "application/json"
                 ],
@@ -126,7 +380,7 @@ const docTemplate = `{
                 "tags": [
                     "games"
                 ],
-                "summary": "Сделать ход",
+                "summary": "Прервать игру",
                 "parameters": [
                     {
                         "type": "string",
@@ -136,12 +390,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные хода",
+                        "description": "Игрок, выполняющий действие",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.MoveRequest"
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
                         }
                     }
                 ],
@@ -155,136 +409,3620 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, ABORT_NOT_ALLOWED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/health": {
+        "/api/game/{gameId}/chat": {
             "get": {
-                "description": "Проверяет работоспособность сервера и базы данных",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Игрокам возвращается чат игроков, зрителям - чат зрителей, после окончания партии - оба канала. Игрок, отключивший чат, не видит сообщений соперников",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "utils"
+                    "chat"
+                ],
+                "summary": "Получить чат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
-                        "description": "Статус сервера",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ChatMessageResponse"
                             }
                         }
-                    }
-                }
-            }
-        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "INVALID_TOKEN, AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Игроки пишут в канал players, зрители - в канал spectators; игроки могут писать зрителям после окончания партии. Текст проходит фильтр слов, длина и частота сообщений ограничены",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Написать в чат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Сообщение",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ChatMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ChatMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH, SPECTATORS_ONLY",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "CHAT_RATE_LIMITED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/chat/mute": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Игрок перестаёт видеть сообщения соперников в чате игроков, в том числе отправленные раньше",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Отключить чат соперника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/chat/unmute": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает игроку сообщения соперников в чате игроков",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Включить чат соперника",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/draw/accept": {
+            "post": {
+                "description": "Принимает предложение ничьей соперника, партия завершается вничью",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Принять ничью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, TWO_PLAYER_ONLY, NO_DRAW_OFFER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/draw/decline": {
+            "post": {
+                "description": "Отклоняет предложение ничьей соперника",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Отклонить ничью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, TWO_PLAYER_ONLY, NO_DRAW_OFFER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/draw/offer": {
+            "post": {
+                "description": "Предлагает сопернику ничью; встречное предложение засчитывается как согласие",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Предложить ничью",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, TWO_PLAYER_ONLY, DRAW_ALREADY_OFFERED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает поток событий указанной игры в порядке их применения; закрытая партия доступна только её игрокам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Получить историю игры",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.GameEventResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "INVALID_TOKEN, AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_NOT_IN_GAME",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/move": {
+            "post": {
+                "description": "Выполняет ход в указанной игре; на двумерном поле клетка задаётся row и col. В варианте pie второй игрок может первым ходом поменяться сторонами, в варианте simultaneous ход тайно выбирает позицию на текущий раунд",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Сделать ход",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные хода",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "NOT_YOUR_TURN, POSITION_TAKEN, POSITION_BLOCKED, ALREADY_COMMITTED, SWAP_NOT_ALLOWED, GAME_FINISHED, TIME_EXPIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/rematch/accept": {
+            "post": {
+                "description": "Создаёт партию-реванш; её ID возвращается в rematchGameId",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Принять реванш",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_NOT_FINISHED, TWO_PLAYER_ONLY, REMATCH_EXISTS, NO_REMATCH_OFFER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/rematch/decline": {
+            "post": {
+                "description": "Отклоняет предложение соперника сыграть ещё раз",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Отклонить реванш",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_NOT_FINISHED, TWO_PLAYER_ONLY, REMATCH_EXISTS, NO_REMATCH_OFFER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/rematch/offer": {
+            "post": {
+                "description": "Предлагает сопернику после окончания партии сыграть ещё раз с теми же правилами и сменой цвета; встречное предложение засчитывается как согласие",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Предложить реванш",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_NOT_FINISHED, TWO_PLAYER_ONLY, REMATCH_EXISTS, REMATCH_ALREADY_OFFERED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/resign": {
+            "post": {
+                "description": "Игрок сдаётся, победа присуждается сопернику",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Сдаться",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, TWO_PLAYER_ONLY",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Поток Server-Sent Events: сначала вся история партии, затем новые события; каждое событие - dtos.GameEventResponse с id, равным версии. Видимые запросившему сообщения чата приходят событиями chat с dtos.ChatMessageResponse. Поток закрывается после окончания партии. Запрос без токена или с токеном не участника партии считается зрителем и учитывается в spectators; закрытая партия доступна только её игрокам",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Следить за партией",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.GameEventResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "INVALID_TOKEN, AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_NOT_IN_GAME",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/takeback/accept": {
+            "post": {
+                "description": "Принимает просьбу соперника и отменяет его последний ход вместе с ответными ходами",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Вернуть ход",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, TWO_PLAYER_ONLY, NO_TAKEBACK_REQUEST, NO_MOVE_TO_TAKE_BACK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/takeback/decline": {
+            "post": {
+                "description": "Отклоняет просьбу соперника вернуть ход",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Отказать в возврате хода",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, TWO_PLAYER_ONLY, NO_TAKEBACK_REQUEST",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/game/{gameId}/takeback/request": {
+            "post": {
+                "description": "Просит соперника вернуть последний ход игрока вместе с ответными ходами; в партиях с контролем времени недоступно",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Попросить вернуть ход",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игры",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GameStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "GAME_FINISHED, TWO_PLAYER_ONLY, TAKEBACKS_DISABLED, TAKEBACK_ALREADY_REQUESTED, NO_MOVE_TO_TAKE_BACK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/games/live": {
+            "get": {
+                "description": "Возвращает до 100 идущих партий, начиная с последних созданных, с числом зрителей; закрытые партии в список не попадают. Фильтра по диапазону рейтинга нет: рейтинга у игроков нет, есть только флаг rated",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Идущие партии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Вариант",
+                        "name": "variant",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только рейтинговые или только товарищеские",
+                        "name": "rated",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID турнира",
+                        "name": "tournamentId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.LiveGameResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/players/{playerId}/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает, как игрок получает письма о своём ходе в заочных партиях",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Настройки уведомлений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игрока",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NotificationPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Письма о ходе в заочных партиях приходят сразу, сводкой не чаще раза за период или не приходят; отдельно включаются напоминания об истекающем времени",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Изменить настройки уведомлений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID игрока",
+                        "name": "playerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Настройки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NotificationPreferences"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.NotificationPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series": {
+            "get": {
+                "description": "Возвращает все серии, начиная с последних созданных, без партий",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Список серий",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.SeriesResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создаёт серию до победы в большинстве из bestOf партий; первая партия создаётся с параметрами из game сразу или в startsAt, каждая следующая - реванш предыдущей со сменой цвета. Создать серию может только один из её игроков",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Создать серию",
+                "parameters": [
+                    {
+                        "description": "Данные серии",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateSeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{seriesId}": {
+            "get": {
+                "description": "Возвращает серию со счётом и всеми партиями",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Получить серию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID серии",
+                        "name": "seriesId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tournaments": {
+            "get": {
+                "description": "Возвращает все турниры, начиная с последних созданных, без участников и пар",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Список турниров",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.TournamentResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создаёт турнир по круговой, швейцарской системе, на выбывание или арену на время; все партии турнира создаются с параметрами из game",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Создать турнир",
+                "parameters": [
+                    {
+                        "description": "Данные турнира",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateTournamentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tournaments/{tournamentId}": {
+            "get": {
+                "description": "Возвращает турнир с участниками и парами всех туров",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Получить турнир",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID турнира",
+                        "name": "tournamentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tournaments/{tournamentId}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Игрок арены перестаёт получать новые пары; начатую партию нужно доиграть",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Покинуть арену",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID турнира",
+                        "name": "tournamentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH, NOT_REGISTERED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "ARENA_ONLY, TOURNAMENT_FINISHED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tournaments/{tournamentId}/register": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавляет игрока в список участников, пока турнир не начался; на арену можно прийти, пока она идёт, и вернуться после ухода",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Зарегистрироваться на турнир",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID турнира",
+                        "name": "tournamentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Игрок, выполняющий действие",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "REGISTRATION_CLOSED, ALREADY_REGISTERED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tournaments/{tournamentId}/standings": {
+            "get": {
+                "description": "Возвращает таблицу по сыгранным партиям: очки, затем коэффициенты Бухгольца и Зоннеборна-Бергера; в турнире на выбывание выбывшие идут последними. На арене победа даёт 2 очка, ничья - 1, после двух побед подряд очки удваиваются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Турнирная таблица",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID турнира",
+                        "name": "tournamentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.StandingResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tournaments/{tournamentId}/start": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Организатор закрывает регистрацию и запускает первый тур, не дожидаясь времени старта",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Начать турнир",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID турнира",
+                        "name": "tournamentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Организатор турнира",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PlayerActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TournamentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH, NOT_ORGANIZER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "TOURNAMENT_STARTED, NOT_ENOUGH_PLAYERS",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/variants": {
+            "get": {
+                "description": "Возвращает разрешённые на сервере варианты с правилами по умолчанию и схемой параметров для CreateGameRequest.parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variants"
+                ],
+                "summary": "Список вариантов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.VariantResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Вебхук получает POST-запросы о событиях партий: game.created, move.played, game.finished. Тело подписано HMAC-SHA256 ключом из ответа, подпись передаётся в заголовке X-Nails-Signature. Вебхук игрока получает его партии, включая закрытые; вебхук турнира регистрирует организатор, глобальный - администратор. Адреса loopback, link-local и внутренних сетей отклоняются, если сервер не разрешает их явно",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Зарегистрировать вебхук",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ администратора",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Параметры вебхука",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RegisterWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH, ADMIN_KEY_REQUIRED, NOT_ORGANIZER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "TOURNAMENT_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{webhookId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет вебхук вместе с его доставками; доступно владельцу и администратору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Удалить вебхук",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ администратора",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID вебхука",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID владельца; с токеном игрока можно не указывать",
+                        "name": "playerId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH, NOT_WEBHOOK_OWNER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "WEBHOOK_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает последние доставки вебхука с указанным состоянием; по умолчанию - недоставленные после всех попыток",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Доставки вебхука",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ администратора",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID вебхука",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID владельца; с токеном игрока можно не указывать",
+                        "name": "playerId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "default": "dead",
+                        "description": "Состояние доставки",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH, NOT_WEBHOOK_OWNER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "WEBHOOK_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{webhookId}/deliveries/{deliveryId}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает недоставленное событие в очередь; счётчик попыток начинается заново",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Повторить доставку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ администратора",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID вебхука",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID доставки",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID владельца; с токеном игрока можно не указывать",
+                        "name": "playerId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookDeliveryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "AUTHENTICATION_REQUIRED",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "PLAYER_MISMATCH, NOT_WEBHOOK_OWNER",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "WEBHOOK_NOT_FOUND, DELIVERY_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "DELIVERY_NOT_DEAD",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Проверяет работоспособность сервера и базы данных",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "utils"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "Статус сервера",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
+        "dtos.BoardLayout": {
+            "description": "Раскладка нерегулярного поля: указывается одно из полей",
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "preset": {
+                    "type": "string",
+                    "example": "tournament-1"
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dtos.BoardPresetResponse": {
+            "description": "Сохранённый пресет поля",
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ChatMessageRequest": {
+            "description": "Сообщение в чат партии",
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "players",
+                        "spectators"
+                    ],
+                    "example": "players"
+                },
+                "playerId": {
+                    "description": "PlayerID - автор; с токеном можно не указывать",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ChatMessageResponse": {
+            "description": "Сообщение из чата партии",
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ClockResponse": {
+            "description": "Состояние часов партии; оставшееся время игрока на ходу учитывает текущий ход",
+            "type": "object",
+            "properties": {
+                "firstPlayerRemainingMs": {
+                    "type": "integer"
+                },
+                "secondPlayerRemainingMs": {
+                    "type": "integer"
+                },
+                "timeControl": {
+                    "$ref": "#/definitions/dtos.TimeControl"
+                },
+                "turnDeadline": {
+                    "type": "string"
+                },
+                "turnStartedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateBoardPresetRequest": {
+            "description": "Запрос на сохранение пресета поля: координаты либо зерно с размером",
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "tournament-1"
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
         "dtos.CreateGameRequest": {
             "description": "Запрос на создание игры",
             "type": "object",
             "properties": {
-                "firstPlayerId": {
+                "board": {
+                    "$ref": "#/definitions/dtos.BoardLayout"
+                },
+                "firstPlayerId": {
+                    "type": "string"
+                },
+                "grid": {
+                    "$ref": "#/definitions/dtos.Grid"
+                },
+                "line_size": {
+                    "type": "integer"
+                },
+                "parameters": {
+                    "description": "Parameters - параметры варианта по его схеме из GET /api/variants; перекрывают одноимённые поля запроса",
+                    "type": "object"
+                },
+                "playerIds": {
+                    "description": "PlayerIDs - от 2 до 6 игроков в порядке ходов вместо firstPlayerId и secondPlayerId",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "private": {
+                    "description": "Private - партию не видно в GET /api/games/live и смотреть её могут только её игроки; нужны токены игроков на сервере",
+                    "type": "boolean"
+                },
+                "rated": {
+                    "type": "boolean"
+                },
+                "schedule": {
+                    "description": "Schedule - число гвоздей за ход, последний элемент повторяется; [1, 2] - как в Connect6",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "secondPlayerId": {
+                    "type": "string"
+                },
+                "startPosition": {
+                    "description": "StartPosition - начальная расстановка по позициям: 0 - свободно, -1 - закрыто,\n1..6 - гвоздь игрока на этом месте; для форы и задач",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0,
+                        1,
+                        -1,
+                        0,
+                        2
+                    ]
+                },
+                "timeControl": {
+                    "$ref": "#/definitions/dtos.TimeControl"
+                },
+                "variant": {
+                    "type": "string",
+                    "enum": [
+                        "standard",
+                        "pie",
+                        "circular",
+                        "grid",
+                        "fog",
+                        "simultaneous",
+                        "handicap",
+                        "misere"
+                    ],
+                    "example": "standard"
+                }
+            }
+        },
+        "dtos.CreateGameResponse": {
+            "description": "Ответ с созданной игрой",
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "firstPlayerId": {
+                    "type": "string"
+                },
+                "gameId": {
+                    "type": "string"
+                },
+                "grid": {
+                    "$ref": "#/definitions/dtos.Grid"
+                },
+                "lineSize": {
+                    "type": "integer"
+                },
+                "playerToken": {
+                    "description": "PlayerToken - токен создателя партии для заголовка Authorization: Bearer; действует\nтолько в этой партии. Остальные игроки получают токены через вход по паролю.\nВ варианте fog без токена свои гвозди игроку не видны",
+                    "type": "string"
+                },
+                "private": {
+                    "type": "boolean"
+                },
+                "rated": {
+                    "type": "boolean"
+                },
+                "rules": {
+                    "$ref": "#/definitions/dtos.Rules"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secondPlayerId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timeControl": {
+                    "$ref": "#/definitions/dtos.TimeControl"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateSeriesRequest": {
+            "description": "Запрос на создание серии партий двух игроков",
+            "type": "object",
+            "properties": {
+                "bestOf": {
+                    "description": "BestOf - наибольшее число засчитываемых партий, от 1 до 15",
+                    "type": "integer",
+                    "example": 3
+                },
+                "firstPlayerId": {
+                    "type": "string"
+                },
+                "game": {
+                    "$ref": "#/definitions/dtos.GameSettingsRequest"
+                },
+                "secondPlayerId": {
+                    "type": "string"
+                },
+                "startsAt": {
+                    "description": "StartsAt - время начала первой партии; без него серия начинается сразу",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateTournamentRequest": {
+            "description": "Запрос на создание турнира",
+            "type": "object",
+            "properties": {
+                "durationMinutes": {
+                    "description": "DurationMinutes - продолжительность арены, задаётся только для неё",
+                    "type": "integer",
+                    "example": 60
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "round_robin",
+                        "swiss",
+                        "single_elimination",
+                        "double_elimination",
+                        "arena"
+                    ],
+                    "example": "swiss"
+                },
+                "game": {
+                    "$ref": "#/definitions/dtos.GameSettingsRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Weekly blitz"
+                },
+                "organizerId": {
+                    "description": "OrganizerID - с токеном игрока можно не указывать, организатором становится владелец токена",
+                    "type": "string"
+                },
+                "rounds": {
+                    "description": "Rounds - число туров, задаётся только для швейцарской системы",
+                    "type": "integer",
+                    "example": 5
+                },
+                "startsAt": {
+                    "description": "StartsAt - время автоматического старта; без него турнир запускает организатор",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ErrorResponse": {
+            "description": "Ошибка API. Поле code стабильно и предназначено для обработки на клиенте",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "NOT_YOUR_TURN"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "not this player's turn"
+                },
+                "requestId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.GameEventResponse": {
+            "description": "Событие из истории партии",
+            "type": "object",
+            "properties": {
+                "occurredAt": {
+                    "type": "string"
+                },
+                "payload": {},
+                "type": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.GameSettingsRequest": {
+            "description": "Параметры партии",
+            "type": "object",
+            "properties": {
+                "board": {
+                    "$ref": "#/definitions/dtos.BoardLayout"
+                },
+                "grid": {
+                    "$ref": "#/definitions/dtos.Grid"
+                },
+                "line_size": {
+                    "type": "integer"
+                },
+                "parameters": {
+                    "description": "Parameters - параметры варианта по его схеме из GET /api/variants; перекрывают одноимённые поля запроса",
+                    "type": "object"
+                },
+                "private": {
+                    "description": "Private - партию не видно в GET /api/games/live и смотреть её могут только её игроки; нужны токены игроков на сервере",
+                    "type": "boolean"
+                },
+                "rated": {
+                    "type": "boolean"
+                },
+                "schedule": {
+                    "description": "Schedule - число гвоздей за ход, последний элемент повторяется; [1, 2] - как в Connect6",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "startPosition": {
+                    "description": "StartPosition - начальная расстановка по позициям: 0 - свободно, -1 - закрыто,\n1..6 - гвоздь игрока на этом месте; для форы и задач",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0,
+                        1,
+                        -1,
+                        0,
+                        2
+                    ]
+                },
+                "timeControl": {
+                    "$ref": "#/definitions/dtos.TimeControl"
+                },
+                "variant": {
+                    "type": "string",
+                    "enum": [
+                        "standard",
+                        "pie",
+                        "circular",
+                        "grid",
+                        "fog",
+                        "simultaneous",
+                        "handicap",
+                        "misere"
+                    ],
+                    "example": "standard"
+                }
+            }
+        },
+        "dtos.GameStateResponse": {
+            "description": "Состояние игры",
+            "type": "object",
+            "properties": {
+                "clock": {
+                    "$ref": "#/definitions/dtos.ClockResponse"
+                },
+                "committedBy": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "currentPlayerId": {
+                    "type": "string"
+                },
+                "drawOfferedBy": {
+                    "type": "string"
+                },
+                "gameId": {
+                    "type": "string"
+                },
+                "grid": {
+                    "$ref": "#/definitions/dtos.Grid"
+                },
+                "line": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/enums.PositionState"
+                    }
+                },
+                "moveCount": {
+                    "type": "integer"
+                },
+                "placementsLeft": {
+                    "description": "PlacementsLeft - сколько гвоздей игроку на ходу осталось поставить в текущем ходе",
+                    "type": "integer"
+                },
+                "previousGameId": {
+                    "description": "PreviousGameID - партия, реваншем которой является эта; RematchGameID - созданный реванш",
+                    "type": "string"
+                },
+                "private": {
+                    "type": "boolean"
+                },
+                "ranking": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rematchGameId": {
+                    "type": "string"
+                },
+                "rematchOfferedBy": {
+                    "type": "string"
+                },
+                "round": {
+                    "description": "Round - номер текущего раунда варианта simultaneous, CommittedBy - уже выбравшие в нём позицию",
+                    "type": "integer"
+                },
+                "rules": {
+                    "$ref": "#/definitions/dtos.Rules"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "spectators": {
+                    "description": "Spectators - сколько зрителей сейчас следят за партией через поток",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "takebackRequestedBy": {
+                    "type": "string"
+                },
+                "termination": {
+                    "type": "string"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.GeneratedBoardResponse": {
+            "description": "Поле, построенное по зерну",
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "seed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.Grid": {
+            "description": "Двумерное поле: клетки в line идут построчно, позиция = row*width + col",
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "metric": {
+                    "type": "string",
+                    "enum": [
+                        "manhattan",
+                        "euclidean"
+                    ],
+                    "example": "manhattan"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dtos.LiveGameResponse": {
+            "description": "Идущая партия в списке для зрителей",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentPlayerId": {
+                    "type": "string"
+                },
+                "gameId": {
+                    "type": "string"
+                },
+                "moveCount": {
+                    "type": "integer"
+                },
+                "rated": {
+                    "type": "boolean"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "spectators": {
+                    "type": "integer"
+                },
+                "timeControl": {
+                    "$ref": "#/definitions/dtos.TimeControl"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.LoginRequest": {
+            "description": "Вход игрока по почте и паролю",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "first@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "first-password"
+                }
+            }
+        },
+        "dtos.LoginResponse": {
+            "description": "Токен входа для заголовка Authorization: Bearer; действует во всех партиях, турнирах и сериях игрока",
+            "type": "object",
+            "properties": {
+                "playerId": {
                     "type": "string"
                 },
-                "line_size": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MoveRequest": {
+            "description": "Запрос на выполнение хода",
+            "type": "object",
+            "properties": {
+                "col": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "position": {
                     "type": "integer"
                 },
-                "secondPlayerId": {
+                "row": {
+                    "description": "Row и Col задают клетку на двумерном поле вместо position",
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "description": "Type - тип хода; swap доступен только в варианте pie вместо первого ответного хода",
+                    "type": "string",
+                    "enum": [
+                        "place",
+                        "swap"
+                    ],
+                    "example": "place"
+                }
+            }
+        },
+        "dtos.NotificationPreferences": {
+            "description": "Настройки писем о заочных партиях",
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "description": "Mode - письмо о каждом ходе сразу, сводка за период или без писем",
+                    "type": "string",
+                    "enum": [
+                        "immediate",
+                        "digest",
+                        "off"
+                    ],
+                    "example": "immediate"
+                },
+                "reminders": {
+                    "description": "Reminders - напоминать, что время на ход истекает; в режиме digest напоминания приходят сразу",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.PlayerActionRequest": {
+            "description": "Запрос на действие игрока в партии (сдаться, прервать, ничья)",
+            "type": "object",
+            "properties": {
+                "playerId": {
                     "type": "string"
                 }
             }
         },
-        "dtos.CreateGameResponse": {
-            "description": "Ответ с созданной игрой",
+        "dtos.RegisterWebhookRequest": {
+            "description": "Запрос на регистрацию вебхука; глобальный вебхук регистрируется с заголовком X-Admin-Key",
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events - на какие события подписаться; пусто - на все",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "game.created",
+                            "move.played",
+                            "game.finished"
+                        ]
+                    }
+                },
+                "playerId": {
+                    "description": "PlayerID - владелец вебхука; с токеном игрока можно не указывать",
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "global",
+                        "player",
+                        "tournament"
+                    ],
+                    "example": "player"
+                },
+                "tournamentId": {
+                    "description": "TournamentID - обязателен для вебхука турнира",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/nails"
+                }
+            }
+        },
+        "dtos.Rules": {
+            "description": "Правила партии",
+            "type": "object",
+            "properties": {
+                "endCondition": {
+                    "type": "string",
+                    "enum": [
+                        "board_full"
+                    ],
+                    "example": "board_full"
+                },
+                "hiddenNails": {
+                    "type": "boolean"
+                },
+                "pieRule": {
+                    "type": "boolean"
+                },
+                "scoring": {
+                    "type": "string",
+                    "enum": [
+                        "longest_thread",
+                        "shortest_thread"
+                    ],
+                    "example": "longest_thread"
+                },
+                "topology": {
+                    "type": "string",
+                    "enum": [
+                        "line",
+                        "ring",
+                        "grid"
+                    ],
+                    "example": "line"
+                },
+                "turnOrder": {
+                    "type": "string",
+                    "enum": [
+                        "sequential",
+                        "simultaneous"
+                    ],
+                    "example": "sequential"
+                }
+            }
+        },
+        "dtos.SeriesGameResponse": {
+            "description": "Партия серии; прерванная партия переигрывается и не засчитывается",
             "type": "object",
             "properties": {
+                "finishedAt": {
+                    "type": "string"
+                },
                 "firstPlayerId": {
                     "type": "string"
                 },
                 "gameId": {
                     "type": "string"
                 },
-                "lineSize": {
-                    "type": "integer"
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "",
+                        "first_won",
+                        "second_won",
+                        "draw",
+                        "aborted"
+                    ]
+                }
+            }
+        },
+        "dtos.SeriesResponse": {
+            "description": "Серия партий со счётом; цвет меняется в каждой следующей партии",
+            "type": "object",
+            "properties": {
+                "bestOf": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "firstPlayerId": {
+                    "type": "string"
+                },
+                "firstPlayerScore": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SeriesGameResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
                 },
                 "secondPlayerId": {
                     "type": "string"
                 },
+                "secondPlayerScore": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "startsAt": {
+                    "type": "string"
+                },
                 "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "in_progress",
+                        "finished"
+                    ]
+                },
+                "timeControl": {
+                    "$ref": "#/definitions/dtos.TimeControl"
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "winnerId": {
+                    "description": "WinnerID пуст, пока серия идёт, и при равном счёте",
                     "type": "string"
                 }
             }
         },
-        "dtos.GameStateResponse": {
-            "description": "Состояние игры",
+        "dtos.StandingResponse": {
+            "description": "Строка турнирной таблицы",
             "type": "object",
             "properties": {
-                "currentPlayerId": {
+                "buchholz": {
+                    "type": "number"
+                },
+                "draws": {
+                    "type": "integer"
+                },
+                "eliminated": {
+                    "type": "boolean"
+                },
+                "losses": {
+                    "type": "integer"
+                },
+                "onFire": {
+                    "description": "OnFire - игрок арены выиграл две партии подряд и получает двойные очки",
+                    "type": "boolean"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number",
+                    "example": 3.5
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "sonnebornBerger": {
+                    "type": "number"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.TimeControl": {
+            "description": "Контроль времени: unlimited, fischer (baseSeconds + incrementSeconds), per_move (moveSeconds) или correspondence (daysPerMove)",
+            "type": "object",
+            "properties": {
+                "baseSeconds": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "daysPerMove": {
+                    "type": "integer"
+                },
+                "incrementSeconds": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "moveSeconds": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "fischer"
+                }
+            }
+        },
+        "dtos.TournamentPairingResponse": {
+            "description": "Пара тура; без второго игрока первый пропускает тур и получает очко",
+            "type": "object",
+            "properties": {
+                "board": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "firstPlayerId": {
                     "type": "string"
                 },
                 "gameId": {
                     "type": "string"
                 },
-                "line": {
+                "replays": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "",
+                        "first_won",
+                        "second_won",
+                        "draw",
+                        "bye",
+                        "aborted"
+                    ]
+                },
+                "round": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "secondPlayerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.TournamentPlayerResponse": {
+            "description": "Участник турнира",
+            "type": "object",
+            "properties": {
+                "paused": {
+                    "description": "Paused - игрок арены ушёл и не получает новых пар",
+                    "type": "boolean"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.TournamentResponse": {
+            "description": "Турнир с участниками и парами",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentRound": {
+                    "type": "integer"
+                },
+                "durationMinutes": {
+                    "description": "DurationMinutes и EndsAt - продолжительность арены и время её окончания",
+                    "type": "integer"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "round_robin",
+                        "swiss",
+                        "single_elimination",
+                        "double_elimination",
+                        "arena"
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organizerId": {
+                    "type": "string"
+                },
+                "pairings": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/enums.PositionState"
+                        "$ref": "#/definitions/dtos.TournamentPairingResponse"
                     }
                 },
-                "moveCount": {
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.TournamentPlayerResponse"
+                    }
+                },
+                "rounds": {
                     "type": "integer"
                 },
+                "startsAt": {
+                    "type": "string"
+                },
                 "status": {
+                    "type": "string",
+                    "enum": [
+                        "registration",
+                        "in_progress",
+                        "finished"
+                    ]
+                },
+                "timeControl": {
+                    "$ref": "#/definitions/dtos.TimeControl"
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "standard"
+                }
+            }
+        },
+        "dtos.VariantParameter": {
+            "description": "Параметр варианта",
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "lineSize"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "integer",
+                        "integer[]",
+                        "string",
+                        "object"
+                    ],
+                    "example": "integer"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dtos.MoveRequest": {
-            "description": "Запрос на выполнение хода",
+        "dtos.VariantResponse": {
+            "description": "Вариант игры с правилами по умолчанию и схемой параметров",
             "type": "object",
             "properties": {
-                "playerId": {
+                "description": {
                     "type": "string"
                 },
-                "position": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.VariantParameter"
+                    }
+                },
+                "rules": {
+                    "$ref": "#/definitions/dtos.Rules"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dtos.WebhookDeliveryResponse": {
+            "description": "Отправка события на вебхук",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "move.played"
+                },
+                "gameId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string",
+                    "example": "webhook responded with status 503"
+                },
+                "nextAttemptAt": {
+                    "description": "NextAttemptAt - время следующей попытки ожидающей доставки",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "dead"
+                    ]
+                }
+            }
+        },
+        "dtos.WebhookResponse": {
+            "description": "Вебхук; ключ подписи возвращается только при регистрации",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "global",
+                        "player",
+                        "tournament"
+                    ]
+                },
+                "secret": {
+                    "description": "Secret - ключ HMAC-SHA256 для проверки заголовка X-Nails-Signature",
+                    "type": "string"
+                },
+                "tournamentId": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/nails"
                 }
             }
         },
@@ -293,12 +4031,14 @@ const docTemplate = `{
             "enum": [
                 0,
                 1,
-                2
+                2,
+                -1
             ],
             "x-enum-varnames": [
                 "Empty",
                 "FirstPlayer",
-                "SecondPlayer"
+                "SecondPlayer",
+                "Blocked"
             ]
         }
     },
@@ -0,0 +1,339 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/bytes"
+
+	"nails_game/internal/models/enums"
+)
+
+// Config - итоговая конфигурация приложения. Значения собираются слоями:
+// значения по умолчанию, файл конфигурации, переменные окружения, флаги
+// командной строки. Каждый следующий слой перекрывает предыдущий.
+type Config struct {
+	Server        ServerConfig        `yaml:"server"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Game          GameConfig          `yaml:"game"`
+	Auth          AuthConfig          `yaml:"auth"`
+	Limits        LimitsConfig        `yaml:"limits"`
+	Chat          ChatConfig          `yaml:"chat"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks"`
+}
+
+type ServerConfig struct {
+	Port            string        `yaml:"port" env:"PORT" flag:"port" usage:"HTTP server port"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" flag:"read-timeout" usage:"HTTP read timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" flag:"write-timeout" usage:"HTTP write timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"graceful shutdown timeout"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"POSTGRES_HOST" flag:"db-host" usage:"Postgres host"`
+	Port     string `yaml:"port" env:"POSTGRES_PORT" flag:"db-port" usage:"Postgres port"`
+	User     string `yaml:"user" env:"POSTGRES_USER" flag:"db-user" usage:"Postgres user"`
+	Password string `yaml:"password" env:"POSTGRES_PASSWORD" flag:"db-password" usage:"Postgres password" secret:"true"`
+	DBName   string `yaml:"dbname" env:"POSTGRES_DB" flag:"db-name" usage:"Postgres database name"`
+	SSLMode  string `yaml:"sslmode" env:"POSTGRES_SSLMODE" flag:"db-sslmode" usage:"Postgres sslmode"`
+	// SeedPlayers заполняет пустую базу игроками из seed_players.json с паролями из README,
+	// поэтому годится только для разработки
+	SeedPlayers bool `yaml:"seed_players" env:"SEED_PLAYERS" flag:"seed-players" usage:"seed an empty database with the demo players from seed_players.json, for development only"`
+}
+
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
+		c.Host, c.User, c.Password, c.DBName, c.Port, c.SSLMode)
+}
+
+type GameConfig struct {
+	DefaultLineSize         int           `yaml:"default_line_size" env:"LINE_SIZE" flag:"line-size" usage:"number of positions used when a game does not specify one"`
+	MinLineSize             int           `yaml:"min_line_size" env:"MIN_LINE_SIZE" flag:"min-line-size" usage:"smallest allowed number of positions"`
+	MaxLineSize             int           `yaml:"max_line_size" env:"MAX_LINE_SIZE" flag:"max-line-size" usage:"largest allowed number of positions"`
+	AllowedVariants         []string      `yaml:"allowed_variants" env:"ALLOWED_VARIANTS" flag:"allowed-variants" usage:"comma-separated list of variants players may create"`
+	AllowedTimeControls     []string      `yaml:"allowed_time_controls" env:"ALLOWED_TIME_CONTROLS" flag:"allowed-time-controls" usage:"comma-separated list of time control types players may create"`
+	MaxCoordinateGap        int           `yaml:"max_coordinate_gap" env:"MAX_COORDINATE_GAP" flag:"max-coordinate-gap" usage:"largest gap between neighbouring positions of a generated board"`
+	AllowTakebacksInRated   bool          `yaml:"allow_takebacks_in_rated" env:"ALLOW_TAKEBACKS_IN_RATED" flag:"allow-takebacks-in-rated" usage:"allow takebacks in rated games"`
+	ClockCheckInterval      time.Duration `yaml:"clock_check_interval" env:"CLOCK_CHECK_INTERVAL" flag:"clock-check-interval" usage:"how often games are checked for expired clocks"`
+	TournamentCheckInterval time.Duration `yaml:"tournament_check_interval" env:"TOURNAMENT_CHECK_INTERVAL" flag:"tournament-check-interval" usage:"how often tournaments are checked for finished rounds"`
+	ArenaPairingInterval    time.Duration `yaml:"arena_pairing_interval" env:"ARENA_PAIRING_INTERVAL" flag:"arena-pairing-interval" usage:"how often waiting arena players are paired"`
+	SeriesCheckInterval     time.Duration `yaml:"series_check_interval" env:"SERIES_CHECK_INTERVAL" flag:"series-check-interval" usage:"how often series are checked for finished games"`
+	FogSpectatorDelay       time.Duration `yaml:"fog_spectator_delay" env:"FOG_SPECTATOR_DELAY" flag:"fog-spectator-delay" usage:"how far spectators of fog games lag behind the players"`
+}
+
+type AuthConfig struct {
+	TokenSecret string        `yaml:"token_secret" env:"AUTH_TOKEN_SECRET" flag:"auth-token-secret" usage:"secret used to sign player tokens" secret:"true"`
+	TokenTTL    time.Duration `yaml:"token_ttl" env:"AUTH_TOKEN_TTL" flag:"auth-token-ttl" usage:"player token lifetime"`
+}
+
+type LimitsConfig struct {
+	MaxBodySize       string  `yaml:"max_body_size" env:"LIMIT_MAX_BODY_SIZE" flag:"max-body-size" usage:"maximum request body size, e.g. 1M"`
+	RequestsPerSecond float64 `yaml:"requests_per_second" env:"LIMIT_REQUESTS_PER_SECOND" flag:"requests-per-second" usage:"per-client request rate, 0 disables the limiter"`
+	Burst             int     `yaml:"burst" env:"LIMIT_BURST" flag:"burst" usage:"per-client request burst"`
+}
+
+type ChatConfig struct {
+	MaxMessageLength int           `yaml:"max_message_length" env:"CHAT_MAX_MESSAGE_LENGTH" flag:"chat-max-message-length" usage:"longest chat message in characters"`
+	MaxMessages      int           `yaml:"max_messages" env:"CHAT_MAX_MESSAGES" flag:"chat-max-messages" usage:"how many chat messages a player may send per rate window"`
+	RateWindow       time.Duration `yaml:"rate_window" env:"CHAT_RATE_WINDOW" flag:"chat-rate-window" usage:"window of the per-player chat rate limit"`
+	BannedWords      []string      `yaml:"banned_words" env:"CHAT_BANNED_WORDS" flag:"chat-banned-words" usage:"comma-separated list of words masked in chat messages"`
+}
+
+type NotificationsConfig struct {
+	SMTPHost       string        `yaml:"smtp_host" env:"SMTP_HOST" flag:"smtp-host" usage:"SMTP server for email notifications, empty disables them"`
+	SMTPPort       string        `yaml:"smtp_port" env:"SMTP_PORT" flag:"smtp-port" usage:"SMTP server port"`
+	SMTPUsername   string        `yaml:"smtp_username" env:"SMTP_USERNAME" flag:"smtp-username" usage:"SMTP user, empty sends without authentication"`
+	SMTPPassword   string        `yaml:"smtp_password" env:"SMTP_PASSWORD" flag:"smtp-password" usage:"SMTP password" secret:"true"`
+	From           string        `yaml:"from" env:"NOTIFICATIONS_FROM" flag:"notifications-from" usage:"sender address of notification emails"`
+	CheckInterval  time.Duration `yaml:"check_interval" env:"NOTIFICATIONS_CHECK_INTERVAL" flag:"notifications-check-interval" usage:"how often correspondence games are checked for players to notify"`
+	ReminderBefore time.Duration `yaml:"reminder_before" env:"NOTIFICATIONS_REMINDER_BEFORE" flag:"notifications-reminder-before" usage:"how long before the move deadline a reminder is sent"`
+	DigestInterval time.Duration `yaml:"digest_interval" env:"NOTIFICATIONS_DIGEST_INTERVAL" flag:"notifications-digest-interval" usage:"shortest period between two digests to one player"`
+}
+
+type WebhooksConfig struct {
+	AdminKey         string        `yaml:"admin_key" env:"WEBHOOKS_ADMIN_KEY" flag:"webhooks-admin-key" usage:"key for managing global webhooks, empty disables them" secret:"true"`
+	DispatchInterval time.Duration `yaml:"dispatch_interval" env:"WEBHOOKS_DISPATCH_INTERVAL" flag:"webhooks-dispatch-interval" usage:"how often pending webhook deliveries are sent"`
+	Timeout          time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" flag:"webhooks-timeout" usage:"how long to wait for a webhook response"`
+	MaxAttempts      int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" flag:"webhooks-max-attempts" usage:"attempts before a delivery is moved to the dead-letter list"`
+	RetryBase        time.Duration `yaml:"retry_base" env:"WEBHOOKS_RETRY_BASE" flag:"webhooks-retry-base" usage:"delay before the first retry, doubled on every next one"`
+	RetryMax         time.Duration `yaml:"retry_max" env:"WEBHOOKS_RETRY_MAX" flag:"webhooks-retry-max" usage:"longest delay between retries"`
+	// AllowPrivateTargets разрешает вебхуки на loopback и адреса внутренних сетей, например для отладки
+	AllowPrivateTargets bool `yaml:"allow_private_targets" env:"WEBHOOKS_ALLOW_PRIVATE_TARGETS" flag:"webhooks-allow-private-targets" usage:"allow webhooks to loopback, link-local and private network addresses"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            "8080",
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:    "localhost",
+			Port:    "5432",
+			User:    "postgres",
+			DBName:  "nails_db",
+			SSLMode: "disable",
+		},
+		Game: GameConfig{
+			DefaultLineSize: 20,
+			MinLineSize:     3,
+			MaxLineSize:     200,
+			AllowedVariants: []string{
+				string(enums.StandardVariant),
+				string(enums.PieVariant),
+				string(enums.CircularVariant),
+				string(enums.GridVariant),
+				string(enums.HandicapVariant),
+				string(enums.MisereVariant),
+			},
+			AllowedTimeControls: []string{
+				string(enums.UnlimitedTimeControl),
+				string(enums.FischerTimeControl),
+				string(enums.PerMoveTimeControl),
+				string(enums.CorrespondenceTimeControl),
+			},
+			MaxCoordinateGap:        5,
+			ClockCheckInterval:      time.Second,
+			TournamentCheckInterval: 10 * time.Second,
+			ArenaPairingInterval:    3 * time.Second,
+			SeriesCheckInterval:     5 * time.Second,
+			FogSpectatorDelay:       2 * time.Minute,
+		},
+		Auth: AuthConfig{
+			TokenTTL: 24 * time.Hour,
+		},
+		Limits: LimitsConfig{
+			MaxBodySize:       "1M",
+			RequestsPerSecond: 20,
+			Burst:             40,
+		},
+		Chat: ChatConfig{
+			MaxMessageLength: 500,
+			MaxMessages:      5,
+			RateWindow:       10 * time.Second,
+		},
+		Notifications: NotificationsConfig{
+			SMTPPort:       "25",
+			CheckInterval:  time.Minute,
+			ReminderBefore: 12 * time.Hour,
+			DigestInterval: 24 * time.Hour,
+		},
+		Webhooks: WebhooksConfig{
+			DispatchInterval: time.Second,
+			Timeout:          10 * time.Second,
+			MaxAttempts:      8,
+			RetryBase:        10 * time.Second,
+			RetryMax:         time.Hour,
+		},
+	}
+}
+
+// ValidationErrors содержит все найденные проблемы конфигурации сразу
+type ValidationErrors []string
+
+func (e ValidationErrors) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e, "\n  - ")
+}
+
+func (c *Config) Validate() error {
+	var problems ValidationErrors
+
+	if !isPort(c.Server.Port) {
+		problems = append(problems, fmt.Sprintf("server.port: %q is not a valid port", c.Server.Port))
+	}
+	if c.Server.ReadTimeout <= 0 {
+		problems = append(problems, "server.read_timeout: must be positive")
+	}
+	if c.Server.WriteTimeout <= 0 {
+		problems = append(problems, "server.write_timeout: must be positive")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "server.shutdown_timeout: must be positive")
+	}
+
+	if c.Database.Host == "" {
+		problems = append(problems, "database.host: is required")
+	}
+	if !isPort(c.Database.Port) {
+		problems = append(problems, fmt.Sprintf("database.port: %q is not a valid port", c.Database.Port))
+	}
+	if c.Database.User == "" {
+		problems = append(problems, "database.user: is required")
+	}
+	if c.Database.DBName == "" {
+		problems = append(problems, "database.dbname: is required")
+	}
+	switch c.Database.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		problems = append(problems, fmt.Sprintf("database.sslmode: unknown mode %q", c.Database.SSLMode))
+	}
+
+	if c.Game.MinLineSize <= 0 {
+		problems = append(problems, "game.min_line_size: must be positive")
+	}
+	if c.Game.MaxLineSize < c.Game.MinLineSize {
+		problems = append(problems, "game.max_line_size: must not be less than game.min_line_size")
+	}
+	if c.Game.DefaultLineSize < c.Game.MinLineSize || c.Game.DefaultLineSize > c.Game.MaxLineSize {
+		problems = append(problems, fmt.Sprintf("game.default_line_size: must be between %d and %d",
+			c.Game.MinLineSize, c.Game.MaxLineSize))
+	}
+	if len(c.Game.AllowedVariants) == 0 {
+		problems = append(problems, "game.allowed_variants: at least one variant is required")
+	}
+	for _, v := range c.Game.AllowedVariants {
+		if !enums.Variant(v).IsKnown() {
+			problems = append(problems, fmt.Sprintf("game.allowed_variants: unknown variant %q", v))
+		}
+	}
+	if len(c.Game.AllowedTimeControls) == 0 {
+		problems = append(problems, "game.allowed_time_controls: at least one time control is required")
+	}
+	for _, tc := range c.Game.AllowedTimeControls {
+		if !enums.TimeControlType(tc).IsKnown() {
+			problems = append(problems, fmt.Sprintf("game.allowed_time_controls: unknown time control %q", tc))
+		}
+	}
+
+	if c.Game.MaxCoordinateGap <= 0 {
+		problems = append(problems, "game.max_coordinate_gap: must be positive")
+	}
+	if c.Game.ClockCheckInterval <= 0 {
+		problems = append(problems, "game.clock_check_interval: must be positive")
+	}
+	if c.Game.TournamentCheckInterval <= 0 {
+		problems = append(problems, "game.tournament_check_interval: must be positive")
+	}
+	if c.Game.ArenaPairingInterval <= 0 {
+		problems = append(problems, "game.arena_pairing_interval: must be positive")
+	}
+	if c.Game.SeriesCheckInterval <= 0 {
+		problems = append(problems, "game.series_check_interval: must be positive")
+	}
+	if c.Game.FogSpectatorDelay < 0 {
+		problems = append(problems, "game.fog_spectator_delay: must not be negative")
+	}
+	for _, v := range c.Game.AllowedVariants {
+		if enums.Variant(v) == enums.FogVariant && c.Auth.TokenSecret == "" {
+			problems = append(problems, "auth.token_secret: is required when the fog variant is allowed")
+		}
+	}
+
+	if c.Auth.TokenTTL <= 0 {
+		problems = append(problems, "auth.token_ttl: must be positive")
+	}
+
+	if c.Limits.RequestsPerSecond < 0 {
+		problems = append(problems, "limits.requests_per_second: must not be negative")
+	}
+	if c.Limits.RequestsPerSecond > 0 && c.Limits.Burst <= 0 {
+		problems = append(problems, "limits.burst: must be positive when the rate limiter is enabled")
+	}
+	if c.Limits.MaxBodySize == "" {
+		problems = append(problems, "limits.max_body_size: is required")
+	} else if size, err := bytes.Parse(c.Limits.MaxBodySize); err != nil || size <= 0 {
+		problems = append(problems, fmt.Sprintf("limits.max_body_size: %q is not a valid size, e.g. 512K or 1M", c.Limits.MaxBodySize))
+	}
+
+	if c.Chat.MaxMessageLength <= 0 {
+		problems = append(problems, "chat.max_message_length: must be positive")
+	}
+	if c.Chat.MaxMessages <= 0 {
+		problems = append(problems, "chat.max_messages: must be positive")
+	}
+	if c.Chat.RateWindow <= 0 {
+		problems = append(problems, "chat.rate_window: must be positive")
+	}
+
+	if c.Notifications.SMTPHost != "" {
+		if !isPort(c.Notifications.SMTPPort) {
+			problems = append(problems, fmt.Sprintf("notifications.smtp_port: %q is not a valid port", c.Notifications.SMTPPort))
+		}
+		if c.Notifications.From == "" {
+			problems = append(problems, "notifications.from: is required when notifications.smtp_host is set")
+		}
+	}
+	if c.Notifications.CheckInterval <= 0 {
+		problems = append(problems, "notifications.check_interval: must be positive")
+	}
+	if c.Notifications.ReminderBefore <= 0 {
+		problems = append(problems, "notifications.reminder_before: must be positive")
+	}
+	if c.Notifications.DigestInterval <= 0 {
+		problems = append(problems, "notifications.digest_interval: must be positive")
+	}
+
+	if c.Webhooks.DispatchInterval <= 0 {
+		problems = append(problems, "webhooks.dispatch_interval: must be positive")
+	}
+	if c.Webhooks.Timeout <= 0 {
+		problems = append(problems, "webhooks.timeout: must be positive")
+	}
+	if c.Webhooks.MaxAttempts <= 0 {
+		problems = append(problems, "webhooks.max_attempts: must be positive")
+	}
+	if c.Webhooks.RetryBase <= 0 {
+		problems = append(problems, "webhooks.retry_base: must be positive")
+	}
+	if c.Webhooks.RetryMax < c.Webhooks.RetryBase {
+		problems = append(problems, "webhooks.retry_max: must not be shorter than webhooks.retry_base")
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+	return nil
+}
+
+func isPort(value string) bool {
+	var port int
+	if _, err := fmt.Sscanf(value, "%d", &port); err != nil {
+		return false
+	}
+	return fmt.Sprint(port) == value && port > 0 && port <= 65535
+}
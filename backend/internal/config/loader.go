@@ -0,0 +1,220 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const configFileEnv = "CONFIG_FILE"
+
+// field - лист структуры Config вместе с его тегами
+type field struct {
+	path  string
+	value reflect.Value
+	tag   reflect.StructTag
+}
+
+// Load собирает конфигурацию из всех слоёв. args - аргументы командной строки
+// без имени программы; оставшиеся после флагов аргументы возвращаются как команда.
+// Ошибка валидации возвращается вместе с собранной конфигурацией.
+func Load(args []string) (*Config, []string, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("nails_game", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := fs.String("config", os.Getenv(configFileEnv), "path to a YAML config file")
+	fields := collectFields(cfg)
+	for _, f := range fields {
+		name := f.tag.Get("flag")
+		switch {
+		case name == "":
+		case f.value.Kind() == reflect.Bool:
+			// логический флаг можно указать без значения: --seed-players
+			fs.Bool(name, false, f.tag.Get("usage"))
+		default:
+			fs.String(name, "", f.tag.Get("usage"))
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	var problems ValidationErrors
+
+	if *configFile != "" {
+		fileProblems, err := loadFile(cfg, *configFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		problems = append(problems, fileProblems...)
+	}
+
+	for _, f := range fields {
+		name := f.tag.Get("env")
+		if name == "" {
+			continue
+		}
+		if raw, ok := os.LookupEnv(name); ok && raw != "" {
+			if err := setValue(f.value, raw); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: env %s: %v", f.path, name, err))
+			}
+		}
+	}
+
+	setFlags := make(map[string]bool)
+	fs.Visit(func(fl *flag.Flag) { setFlags[fl.Name] = true })
+	for _, f := range fields {
+		name := f.tag.Get("flag")
+		if name == "" || !setFlags[name] {
+			continue
+		}
+		if err := setValue(f.value, fs.Lookup(name).Value.String()); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: flag --%s: %v", f.path, name, err))
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		problems = append(problems, err.(ValidationErrors)...)
+	}
+	if len(problems) > 0 {
+		return cfg, fs.Args(), problems
+	}
+	return cfg, fs.Args(), nil
+}
+
+func loadFile(cfg *Config, path string) (ValidationErrors, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	var problems ValidationErrors
+	for _, f := range collectFields(cfg) {
+		node := lookupNode(root.Content[0], f.path)
+		if node == nil {
+			continue
+		}
+		raw := node.Value
+		if node.Kind == yaml.SequenceNode {
+			items := make([]string, 0, len(node.Content))
+			for _, item := range node.Content {
+				items = append(items, item.Value)
+			}
+			raw = strings.Join(items, ",")
+		}
+		if err := setValue(f.value, raw); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s: %v", f.path, path, err))
+		}
+	}
+	return problems, nil
+}
+
+func lookupNode(node *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	if node.Kind != yaml.ScalarNode && node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node
+}
+
+func collectFields(cfg *Config) []field {
+	var fields []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			path := sf.Tag.Get("yaml")
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			fv := v.Field(i)
+			if fv.Kind() == reflect.Struct {
+				walk(fv, path)
+				continue
+			}
+			fields = append(fields, field{path: path, value: fv, tag: sf.Tag})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return fields
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported config type %s", v.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+// Print выводит итоговую конфигурацию в YAML, скрывая значения секретов
+func Print(w io.Writer, cfg *Config) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range collectFields(cfg) {
+		value := formatValue(f.value)
+		if f.tag.Get("secret") == "true" && value.Value != "" {
+			value.Value = redacted
+		}
+		insertNode(root, strings.Split(f.path, "."), value)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	return encoder.Close()
+}
+
+func insertNode(node *yaml.Node, path []string, value *yaml.Node) {
+	key := path[0]
+	if len(path) == 1 {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			insertNode(node.Content[i+1], path[1:], value)
+			return
+		}
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+	insertNode(child, path[1:], value)
+}
+
+func formatValue(v reflect.Value) *yaml.Node {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: time.Duration(v.Int()).String()}
+	}
+	if v.Kind() == reflect.Slice {
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for i := 0; i < v.Len(); i++ {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v.Index(i).Interface())})
+		}
+		return seq
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v.Interface())}
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// viewerKey - ключ контекста echo, под которым лежит игрок из токена
+const viewerKey = "viewerId"
+
+// NewAuthMiddleware определяет игрока по заголовку Authorization: Bearer <token>.
+// Запрос без токена выполняется от имени зрителя; если tokens не заданы, заголовок игнорируется.
+// Токен партии принимается только в запросах к этой партии
+func NewAuthMiddleware(tokens services.TokenService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			header := ctx.Request().Header.Get(echo.HeaderAuthorization)
+			if tokens == nil || header == "" {
+				return next(ctx)
+			}
+
+			token, _ := strings.CutPrefix(header, "Bearer ")
+			gameID, _ := uuid.Parse(ctx.Param("gameId"))
+			playerID, err := tokens.Verify(token, gameID)
+			if err != nil {
+				return err
+			}
+			ctx.Set(viewerKey, playerID)
+			return next(ctx)
+		}
+	}
+}
+
+// actingPlayer определяет, от имени какого игрока выполняется действие. Если сервер выдаёт
+// токены, действовать может только владелец токена, поэтому зрители не могут ходить за игроков;
+// без playerId в запросе действует владелец токена
+func actingPlayer(ctx echo.Context, tokens services.TokenService, playerID uuid.UUID) (uuid.UUID, error) {
+	if tokens == nil {
+		return playerID, nil
+	}
+	viewer := viewerID(ctx)
+	if viewer == uuid.Nil {
+		return uuid.Nil, serviceErrors.NewUnauthenticatedError(serviceErrors.CodeAuthenticationRequired, "player token is required")
+	}
+	if playerID != uuid.Nil && playerID != viewer {
+		return uuid.Nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerMismatch, "token belongs to another player")
+	}
+	return viewer, nil
+}
+
+// viewerID возвращает игрока, выполняющего запрос, или uuid.Nil для зрителя
+func viewerID(ctx echo.Context) uuid.UUID {
+	playerID, _ := ctx.Get(viewerKey).(uuid.UUID)
+	return playerID
+}
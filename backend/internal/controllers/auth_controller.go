@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"nails_game/internal/models/dtos"
+	services "nails_game/internal/services/interfaces"
+)
+
+type AuthController struct {
+	authService services.AuthService
+}
+
+func NewAuthController(authService services.AuthService) *AuthController {
+	return &AuthController{authService: authService}
+}
+
+// Login выдаёт игроку токен входа
+// @Summary Войти
+// @Description Проверяет почту и пароль игрока и выдаёт токен, действующий во всех его партиях, турнирах и сериях. Токены из ответа на создание партии действуют только в ней
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param request body dtos.LoginRequest true "Почта и пароль"
+// @Success 200 {object} dtos.LoginResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "INVALID_CREDENTIALS"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/auth/login [post]
+func (c *AuthController) Login(ctx echo.Context) error {
+	var req dtos.LoginRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	player, token, err := c.authService.Login(req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, dtos.LoginResponse{PlayerID: player.ID, Token: token})
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	services "nails_game/internal/services/interfaces"
+)
+
+type BoardController struct {
+	boardService services.BoardService
+}
+
+func NewBoardController(boardService services.BoardService) *BoardController {
+	return &BoardController{boardService: boardService}
+}
+
+// CreatePreset сохраняет пресет поля
+// @Summary Сохранить пресет поля
+// @Description Сохраняет именованную раскладку поля из явных координат или из зерна и размера
+// @Tags boards
+// @Accept json
+// @Produce json
+// @Param request body dtos.CreateBoardPresetRequest true "Данные пресета"
+// @Success 201 {object} dtos.BoardPresetResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "BOARD_PRESET_EXISTS"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/boards/presets [post]
+func (c *BoardController) CreatePreset(ctx echo.Context) error {
+	var req dtos.CreateBoardPresetRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	layout := models.BoardLayout{Coordinates: req.Coordinates, Seed: req.Seed}
+	preset, err := c.boardService.CreatePreset(req.Name, layout, req.Size)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, mapBoardPresetToResponse(*preset))
+}
+
+// ListPresets возвращает сохранённые пресеты полей
+// @Summary Список пресетов полей
+// @Description Возвращает все сохранённые пресеты полей по имени
+// @Tags boards
+// @Produce json
+// @Success 200 {array} dtos.BoardPresetResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/boards/presets [get]
+func (c *BoardController) ListPresets(ctx echo.Context) error {
+	presets, err := c.boardService.ListPresets()
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dtos.BoardPresetResponse, 0, len(presets))
+	for _, preset := range presets {
+		resp = append(resp, mapBoardPresetToResponse(preset))
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPreset возвращает пресет поля
+// @Summary Получить пресет поля
+// @Description Возвращает координаты сохранённого пресета
+// @Tags boards
+// @Produce json
+// @Param name path string true "Имя пресета"
+// @Success 200 {object} dtos.BoardPresetResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/boards/presets/{name} [get]
+func (c *BoardController) GetPreset(ctx echo.Context) error {
+	preset, err := c.boardService.GetPreset(ctx.Param("name"))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, mapBoardPresetToResponse(*preset))
+}
+
+// GenerateBoard строит поле по зерну
+// @Summary Сгенерировать поле
+// @Description Строит координаты поля по зерну; одинаковые зерно и размер всегда дают одинаковое поле
+// @Tags boards
+// @Produce json
+// @Param seed query int true "Зерно генератора"
+// @Param size query int true "Количество позиций"
+// @Success 200 {object} dtos.GeneratedBoardResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Router /api/boards/generate [get]
+func (c *BoardController) GenerateBoard(ctx echo.Context) error {
+	seed, err := strconv.ParseInt(ctx.QueryParam("seed"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid seed")
+	}
+	size, err := strconv.Atoi(ctx.QueryParam("size"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid size")
+	}
+
+	coordinates, err := c.boardService.GenerateBoard(seed, size)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, dtos.GeneratedBoardResponse{Seed: seed, Coordinates: coordinates})
+}
+
+func mapBoardPresetToResponse(preset models.BoardPreset) dtos.BoardPresetResponse {
+	return dtos.BoardPresetResponse{
+		Name:        preset.Name,
+		Coordinates: preset.Coordinates,
+		Seed:        preset.Seed,
+		CreatedAt:   preset.CreatedAt,
+	}
+}
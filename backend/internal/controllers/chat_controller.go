@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	"nails_game/internal/models/enums"
+	services "nails_game/internal/services/interfaces"
+)
+
+type ChatController struct {
+	chatService services.ChatService
+	// tokens - nil, если сервер не выдаёт токены игроков
+	tokens services.TokenService
+}
+
+func NewChatController(chatService services.ChatService, tokens services.TokenService) *ChatController {
+	return &ChatController{chatService: chatService, tokens: tokens}
+}
+
+// SendMessage отправляет сообщение в чат партии
+// @Summary Написать в чат
+// @Description Игроки пишут в канал players, зрители - в канал spectators; игроки могут писать зрителям после окончания партии. Текст проходит фильтр слов, длина и частота сообщений ограничены
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.ChatMessageRequest true "Сообщение"
+// @Success 201 {object} dtos.ChatMessageResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH, SPECTATORS_ONLY"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 429 {object} dtos.ErrorResponse "CHAT_RATE_LIMITED"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/chat [post]
+func (c *ChatController) SendMessage(ctx echo.Context) error {
+	gameID, err := uuid.Parse(ctx.Param("gameId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid game ID")
+	}
+
+	var req dtos.ChatMessageRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	playerID, err := actingPlayer(ctx, c.tokens, req.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	message, err := c.chatService.Send(gameID, playerID, enums.ChatChannel(req.Channel), req.Text)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, mapChatMessage(*message))
+}
+
+// ListMessages возвращает чат партии
+// @Summary Получить чат
+// @Description Игрокам возвращается чат игроков, зрителям - чат зрителей, после окончания партии - оба канала. Игрок, отключивший чат, не видит сообщений соперников
+// @Tags chat
+// @Produce json
+// @Security ApiKeyAuth
+// @Param gameId path string true "ID игры"
+// @Success 200 {array} dtos.ChatMessageResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "INVALID_TOKEN, AUTHENTICATION_REQUIRED"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/chat [get]
+func (c *ChatController) ListMessages(ctx echo.Context) error {
+	gameID, err := uuid.Parse(ctx.Param("gameId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid game ID")
+	}
+
+	messages, err := c.chatService.Messages(gameID, viewerID(ctx))
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dtos.ChatMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		resp = append(resp, mapChatMessage(message))
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// Mute отключает сообщения соперников
+// @Summary Отключить чат соперника
+// @Description Игрок перестаёт видеть сообщения соперников в чате игроков, в том числе отправленные раньше
+// @Tags chat
+// @Accept json
+// @Security ApiKeyAuth
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 204
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/chat/mute [post]
+func (c *ChatController) Mute(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.chatService.Mute)
+}
+
+// Unmute снова показывает сообщения соперников
+// @Summary Включить чат соперника
+// @Description Возвращает игроку сообщения соперников в чате игроков
+// @Tags chat
+// @Accept json
+// @Security ApiKeyAuth
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 204
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/chat/unmute [post]
+func (c *ChatController) Unmute(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.chatService.Unmute)
+}
+
+func (c *ChatController) handlePlayerAction(ctx echo.Context, action func(gameID, playerID uuid.UUID) error) error {
+	gameID, err := uuid.Parse(ctx.Param("gameId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid game ID")
+	}
+
+	var req dtos.PlayerActionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	playerID, err := actingPlayer(ctx, c.tokens, req.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	if err := action(gameID, playerID); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func mapChatMessage(message models.ChatMessage) dtos.ChatMessageResponse {
+	return dtos.ChatMessageResponse{
+		ID:        message.ID,
+		Channel:   string(message.Channel),
+		PlayerID:  message.PlayerID,
+		Text:      message.Text,
+		CreatedAt: message.CreatedAt,
+	}
+}
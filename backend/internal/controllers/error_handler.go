@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"nails_game/internal/models/dtos"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+// NewErrorHandler возвращает обработчик ошибок echo, приводящий все ошибки
+// к единому формату dtos.ErrorResponse
+func NewErrorHandler(logger *logrus.Logger) echo.HTTPErrorHandler {
+	return func(err error, ctx echo.Context) {
+		if ctx.Response().Committed {
+			return
+		}
+
+		status, resp := mapError(err)
+		resp.RequestID = ctx.Response().Header().Get(echo.HeaderXRequestID)
+
+		if status >= http.StatusInternalServerError {
+			logger.WithError(err).WithField("requestId", resp.RequestID).Error("Request failed")
+		}
+
+		var writeErr error
+		if ctx.Request().Method == http.MethodHead {
+			writeErr = ctx.NoContent(status)
+		} else {
+			writeErr = ctx.JSON(status, resp)
+		}
+		if writeErr != nil {
+			logger.WithError(writeErr).Error("Failed to write error response")
+		}
+	}
+}
+
+func mapError(err error) (int, dtos.ErrorResponse) {
+	var coded serviceErrors.CodedError
+	if errors.As(err, &coded) {
+		return statusForError(err), dtos.ErrorResponse{
+			Code:    string(coded.ErrorCode()),
+			Message: coded.Error(),
+			Details: coded.ErrorDetails(),
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		message, ok := httpErr.Message.(string)
+		if !ok {
+			message = http.StatusText(httpErr.Code)
+		}
+		return httpErr.Code, dtos.ErrorResponse{
+			Code:    string(codeForStatus(httpErr.Code)),
+			Message: message,
+		}
+	}
+
+	return http.StatusInternalServerError, dtos.ErrorResponse{
+		Code:    string(serviceErrors.CodeInternal),
+		Message: "internal server error",
+	}
+}
+
+func statusForError(err error) int {
+	var (
+		notFound        *serviceErrors.NotFoundError
+		unauthenticated *serviceErrors.UnauthenticatedError
+		unauthorized    *serviceErrors.UnauthorizedError
+		invalidOp       *serviceErrors.InvalidOperationError
+		validation      *serviceErrors.ValidationError
+		rateLimited     *serviceErrors.RateLimitedError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.As(err, &unauthenticated):
+		return http.StatusUnauthorized
+	case errors.As(err, &unauthorized):
+		return http.StatusForbidden
+	case errors.As(err, &invalidOp):
+		return http.StatusConflict
+	case errors.As(err, &validation):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &rateLimited):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func codeForStatus(status int) serviceErrors.Code {
+	switch status {
+	case http.StatusNotFound:
+		return serviceErrors.CodeRouteNotFound
+	case http.StatusTooManyRequests:
+		return serviceErrors.CodeRateLimited
+	case http.StatusUnprocessableEntity:
+		return serviceErrors.CodeValidationFailed
+	default:
+		if status >= http.StatusInternalServerError {
+			return serviceErrors.CodeInternal
+		}
+		return serviceErrors.CodeBadRequest
+	}
+}
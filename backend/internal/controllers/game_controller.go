@@ -1,35 +1,61 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	"nails_game/internal/models"
 	"nails_game/internal/models/dtos"
-	"nails_game/internal/services/errors"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
 	services "nails_game/internal/services/interfaces"
 )
 
+// streamHeartbeatInterval - как часто поток партии без новых событий напоминает о себе
+const streamHeartbeatInterval = 15 * time.Second
+
 type GameController struct {
 	gameService services.GameService
+	chatService services.ChatService
+	// tokens - nil, если сервер не выдаёт токены игроков
+	tokens     services.TokenService
+	spectators services.SpectatorRegistry
+	feed       services.GameFeed
 }
 
-func NewGameController(gameService services.GameService) *GameController {
-	return &GameController{gameService: gameService}
+func NewGameController(
+	gameService services.GameService,
+	chatService services.ChatService,
+	tokens services.TokenService,
+	spectators services.SpectatorRegistry,
+	feed services.GameFeed,
+) *GameController {
+	return &GameController{gameService: gameService, chatService: chatService, tokens: tokens, spectators: spectators, feed: feed}
 }
 
 // CreateGame создает новую игру
 // @Summary Создать новую игру
-// @Description Создает новую игру между двумя игроками
+// @Description Создает новую игру между двумя игроками или от трёх до шести игроками из playerIds; поле может быть нерегулярным - из пресета, явных координат или по зерну. Создать партию может только один из её игроков по токену входа
 // @Tags games
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param request body dtos.CreateGameRequest true "Данные для создания игры"
 // @Success 201 {object} dtos.CreateGameResponse
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED, INVALID_TOKEN"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
 // @Router /api/game [post]
 func (c *GameController) CreateGame(ctx echo.Context) error {
 	var req dtos.CreateGameRequest
@@ -37,35 +63,68 @@ func (c *GameController) CreateGame(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	game, err := c.gameService.CreateGame(req.LineSize, req.FirstPlayerID, req.SecondPlayerID)
+	settings := mapGameSettings(req.GameSettingsRequest)
+
+	playerIDs := []uuid.UUID{req.FirstPlayerID, req.SecondPlayerID}
+	if len(req.PlayerIDs) > 0 {
+		if req.FirstPlayerID != uuid.Nil || req.SecondPlayerID != uuid.Nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "use either playerIds or firstPlayerId and secondPlayerId")
+		}
+		playerIDs = req.PlayerIDs
+	}
+	// токен партии здесь не принимается, поэтому создатель входит по паролю
+	creatorID, err := actingPlayer(ctx, c.tokens, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	if c.tokens != nil && !slices.Contains(playerIDs, creatorID) {
+		return serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerMismatch, "token belongs to none of the game players")
+	}
+
+	game, err := c.gameService.CreateGame(settings, playerIDs)
 	if err != nil {
-		return handleServiceError(err)
+		return err
 	}
 
 	resp := dtos.CreateGameResponse{
 		GameID:         game.ID,
 		LineSize:       len(game.Line),
-		FirstPlayerID:  req.FirstPlayerID,
-		SecondPlayerID: req.SecondPlayerID,
+		Coordinates:    game.Coordinates,
+		Grid:           mapGrid(game),
+		Schedule:       game.Schedule,
+		Variant:        string(game.Variant),
+		Rules:          mapRules(game.Rules),
+		TimeControl:    mapTimeControl(game.TimeControl),
+		Rated:          game.Rated,
+		Private:        game.Private,
+		FirstPlayerID:  game.FirstPlayerID,
+		SecondPlayerID: game.SecondPlayerID,
+		Seats:          game.Players(),
 		Status:         game.Status.String(),
 	}
+	if c.tokens != nil {
+		resp.PlayerToken = c.tokens.Issue(creatorID, game.ID)
+	}
 
 	return ctx.JSON(http.StatusCreated, resp)
 }
 
 // MakeMove выполняет ход в игре
 // @Summary Сделать ход
-// @Description Выполняет ход в указанной игре
+// @Description Выполняет ход в указанной игре; на двумерном поле клетка задаётся row и col. В варианте pie второй игрок может первым ходом поменяться сторонами, в варианте simultaneous ход тайно выбирает позицию на текущий раунд
 // @Tags games
 // @Accept json
 // @Produce json
 // @Param gameId path string true "ID игры"
 // @Param request body dtos.MoveRequest true "Данные хода"
 // @Success 200 {object} dtos.GameStateResponse
-// @Failure 400 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_NOT_IN_GAME, PLAYER_MISMATCH"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "NOT_YOUR_TURN, POSITION_TAKEN, POSITION_BLOCKED, ALREADY_COMMITTED, SWAP_NOT_ALLOWED, GAME_FINISHED, TIME_EXPIRED"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
 // @Router /api/game/{gameId}/move [post]
 func (c *GameController) MakeMove(ctx echo.Context) error {
 	gameID, err := uuid.Parse(ctx.Param("gameId"))
@@ -78,30 +137,282 @@ func (c *GameController) MakeMove(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	playerID, err := actingPlayer(ctx, c.tokens, req.PlayerID)
+	if err != nil {
+		return err
+	}
+
 	move := models.Move{
 		GameID:   gameID,
-		PlayerID: req.PlayerID,
+		PlayerID: playerID,
 		Position: req.Position,
+		Type:     enums.MoveType(req.Type),
+	}
+	if (req.Row == nil) != (req.Col == nil) {
+		return echo.NewHTTPError(http.StatusBadRequest, "row and col must be given together")
+	}
+	if req.Row != nil {
+		move.Cell = &models.Cell{Row: *req.Row, Col: *req.Col}
 	}
 
 	game, err := c.gameService.MakeMove(move)
 	if err != nil {
-		return handleServiceError(err)
+		return err
+	}
+
+	resp := c.mapGameStateToResponse(game.Game, game.Game.LineSeenBy(viewerID(ctx)))
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// Resign сдаётся в партии
+// @Summary Сдаться
+// @Description Игрок сдаётся, победа присуждается сопернику
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, TWO_PLAYER_ONLY"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/resign [post]
+func (c *GameController) Resign(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.Resign)
+}
+
+// Abort прерывает партию
+// @Summary Прервать игру
+// @Description Прерывает партию без результата; доступно только до первого хода каждого игрока
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, ABORT_NOT_ALLOWED"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/abort [post]
+func (c *GameController) Abort(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.Abort)
+}
+
+// OfferDraw предлагает ничью
+// @Summary Предложить ничью
+// @Description Предлагает сопернику ничью; встречное предложение засчитывается как согласие
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, TWO_PLAYER_ONLY, DRAW_ALREADY_OFFERED"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/draw/offer [post]
+func (c *GameController) OfferDraw(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.OfferDraw)
+}
+
+// AcceptDraw принимает предложение ничьей
+// @Summary Принять ничью
+// @Description Принимает предложение ничьей соперника, партия завершается вничью
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, TWO_PLAYER_ONLY, NO_DRAW_OFFER"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/draw/accept [post]
+func (c *GameController) AcceptDraw(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.AcceptDraw)
+}
+
+// DeclineDraw отклоняет предложение ничьей
+// @Summary Отклонить ничью
+// @Description Отклоняет предложение ничьей соперника
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, TWO_PLAYER_ONLY, NO_DRAW_OFFER"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/draw/decline [post]
+func (c *GameController) DeclineDraw(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.DeclineDraw)
+}
+
+// RequestTakeback просит соперника вернуть последний ход
+// @Summary Попросить вернуть ход
+// @Description Просит соперника вернуть последний ход игрока вместе с ответными ходами; в партиях с контролем времени недоступно
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, TWO_PLAYER_ONLY, TAKEBACKS_DISABLED, TAKEBACK_ALREADY_REQUESTED, NO_MOVE_TO_TAKE_BACK"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/takeback/request [post]
+func (c *GameController) RequestTakeback(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.RequestTakeback)
+}
+
+// AcceptTakeback принимает просьбу соперника вернуть ход
+// @Summary Вернуть ход
+// @Description Принимает просьбу соперника и отменяет его последний ход вместе с ответными ходами
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, TWO_PLAYER_ONLY, NO_TAKEBACK_REQUEST, NO_MOVE_TO_TAKE_BACK"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/takeback/accept [post]
+func (c *GameController) AcceptTakeback(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.AcceptTakeback)
+}
+
+// DeclineTakeback отклоняет просьбу соперника вернуть ход
+// @Summary Отказать в возврате хода
+// @Description Отклоняет просьбу соперника вернуть ход
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_FINISHED, TWO_PLAYER_ONLY, NO_TAKEBACK_REQUEST"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/takeback/decline [post]
+func (c *GameController) DeclineTakeback(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.DeclineTakeback)
+}
+
+// OfferRematch предлагает реванш
+// @Summary Предложить реванш
+// @Description Предлагает сопернику после окончания партии сыграть ещё раз с теми же правилами и сменой цвета; встречное предложение засчитывается как согласие
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_NOT_FINISHED, TWO_PLAYER_ONLY, REMATCH_EXISTS, REMATCH_ALREADY_OFFERED"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/rematch/offer [post]
+func (c *GameController) OfferRematch(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.OfferRematch)
+}
+
+// AcceptRematch принимает предложение реванша
+// @Summary Принять реванш
+// @Description Создаёт партию-реванш; её ID возвращается в rematchGameId
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_NOT_FINISHED, TWO_PLAYER_ONLY, REMATCH_EXISTS, NO_REMATCH_OFFER"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/rematch/accept [post]
+func (c *GameController) AcceptRematch(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.AcceptRematch)
+}
+
+// DeclineRematch отклоняет предложение реванша
+// @Summary Отклонить реванш
+// @Description Отклоняет предложение соперника сыграть ещё раз
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param gameId path string true "ID игры"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.GameStateResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "GAME_NOT_FINISHED, TWO_PLAYER_ONLY, REMATCH_EXISTS, NO_REMATCH_OFFER"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/rematch/decline [post]
+func (c *GameController) DeclineRematch(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.gameService.DeclineRematch)
+}
+
+func (c *GameController) handlePlayerAction(
+	ctx echo.Context,
+	action func(gameID, playerID uuid.UUID) (*models.Game, error),
+) error {
+	gameID, err := uuid.Parse(ctx.Param("gameId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid game ID")
+	}
+
+	var req dtos.PlayerActionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	playerID, err := actingPlayer(ctx, c.tokens, req.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	game, err := action(gameID, playerID)
+	if err != nil {
+		return err
 	}
 
-	resp := mapGameStateToResponse(game.Game)
+	resp := c.mapGameStateToResponse(game, game.LineSeenBy(viewerID(ctx)))
 	return ctx.JSON(http.StatusOK, resp)
 }
 
 // GetGame возвращает состояние игры
 // @Summary Получить состояние игры
-// @Description Возвращает текущее состояние указанной игры
+// @Description Возвращает текущее состояние указанной игры. В варианте fog игрок с токеном видит только свои и открытые им гвозди, а зрители - поле с задержкой. Закрытая партия доступна только её игрокам
 // @Tags games
 // @Produce json
+// @Security ApiKeyAuth
 // @Param gameId path string true "ID игры"
 // @Success 200 {object} dtos.GameStateResponse
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 401 {object} dtos.ErrorResponse "INVALID_TOKEN, AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_NOT_IN_GAME"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
 // @Router /api/game/{gameId} [get]
 func (c *GameController) GetGame(ctx echo.Context) error {
 	gameID, err := uuid.Parse(ctx.Param("gameId"))
@@ -109,34 +420,376 @@ func (c *GameController) GetGame(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid game ID")
 	}
 
-	game, err := c.gameService.GetGame(gameID)
+	view, err := c.gameService.GetGame(gameID, viewerID(ctx))
+	if err != nil {
+		return err
+	}
+
+	resp := c.mapGameStateToResponse(view.Game, view.Line)
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// GetGameEvents возвращает историю событий игры
+// @Summary Получить историю игры
+// @Description Возвращает поток событий указанной игры в порядке их применения; закрытая партия доступна только её игрокам
+// @Tags games
+// @Produce json
+// @Security ApiKeyAuth
+// @Param gameId path string true "ID игры"
+// @Success 200 {array} dtos.GameEventResponse
+// @Failure 401 {object} dtos.ErrorResponse "INVALID_TOKEN, AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_NOT_IN_GAME"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/events [get]
+func (c *GameController) GetGameEvents(ctx echo.Context) error {
+	gameID, err := uuid.Parse(ctx.Param("gameId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid game ID")
+	}
+
+	events, err := c.gameService.GetGameEvents(gameID, viewerID(ctx))
 	if err != nil {
-		return handleServiceError(err)
+		return err
 	}
 
-	resp := mapGameStateToResponse(game)
+	resp := make([]dtos.GameEventResponse, 0, len(events))
+	for _, e := range events {
+		resp = append(resp, mapGameEvent(e))
+	}
 	return ctx.JSON(http.StatusOK, resp)
 }
 
-func mapGameStateToResponse(game *models.Game) dtos.GameStateResponse {
-	return dtos.GameStateResponse{
-		GameID:          game.ID,
-		Status:          game.Status.String(),
-		CurrentPlayerID: game.CurrentPlayerID,
-		Line:            game.Line,
-		MoveCount:       game.MoveCount,
+// StreamGame передаёт события партии по мере их появления
+// @Summary Следить за партией
+// @Description Поток Server-Sent Events: сначала вся история партии, затем новые события; каждое событие - dtos.GameEventResponse с id, равным версии. Видимые запросившему сообщения чата приходят событиями chat с dtos.ChatMessageResponse. Поток закрывается после окончания партии. Запрос без токена или с токеном не участника партии считается зрителем и учитывается в spectators; закрытая партия доступна только её игрокам
+// @Tags games
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param gameId path string true "ID игры"
+// @Success 200 {array} dtos.GameEventResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "INVALID_TOKEN, AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_NOT_IN_GAME"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/game/{gameId}/stream [get]
+func (c *GameController) StreamGame(ctx echo.Context) error {
+	gameID, err := uuid.Parse(ctx.Param("gameId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid game ID")
+	}
+
+	// подписка раньше первой загрузки, чтобы не пропустить событие между ними
+	updates, unsubscribe := c.feed.Subscribe(gameID)
+	defer unsubscribe()
+
+	viewer := viewerID(ctx)
+	view, err := c.gameService.GetGame(gameID, viewer)
+	if err != nil {
+		return err
+	}
+	if !view.Game.HasPlayer(viewer) {
+		defer c.spectators.Watch(gameID)()
+	}
+	finished := view.Game.Status != enums.InProgress
+
+	res := ctx.Response()
+	// поток живёт дольше таймаута записи сервера
+	err = http.NewResponseController(res.Writer).SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.WriteHeader(http.StatusOK)
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// события fog открываются зрителям с задержкой, поэтому отправленные отмечаются по версии
+	sent := make(map[int]bool)
+	sentChat := make(map[uuid.UUID]bool)
+	for {
+		events, err := c.gameService.GetGameEvents(gameID, viewer)
+		if err != nil {
+			return err
+		}
+		messages, err := c.chatService.MessagesIn(view.Game, viewer)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			if sent[e.Version] {
+				continue
+			}
+			if err := writeStreamEvent(res, fmt.Sprintf("id: %d\nevent: %s", e.Version, e.Event.EventType()), mapGameEvent(e)); err != nil {
+				return nil
+			}
+			sent[e.Version] = true
+			finished = finished || e.Event.EventType() == enums.GameFinishedEvent
+		}
+		for _, message := range messages {
+			if sentChat[message.ID] {
+				continue
+			}
+			if err := writeStreamEvent(res, "event: chat", mapChatMessage(message)); err != nil {
+				return nil
+			}
+			sentChat[message.ID] = true
+		}
+		res.Flush()
+
+		if finished {
+			return nil
+		}
+		// история загружается заново только по сигналу об изменении партии
+		if !waitForUpdate(ctx, res, updates, heartbeat.C) {
+			return nil
+		}
 	}
 }
 
-func handleServiceError(err error) *echo.HTTPError {
-	switch err.(type) {
-	case *errors.NotFoundError:
-		return echo.NewHTTPError(http.StatusNotFound, err.Error())
-	case *errors.UnauthorizedError:
-		return echo.NewHTTPError(http.StatusForbidden, err.Error())
-	case *errors.InvalidOperationError:
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+// waitForUpdate ждёт сигнала об изменении партии, отправляя в поток heartbeat;
+// false - клиент ушёл и поток пора закрыть
+func waitForUpdate(ctx echo.Context, res *echo.Response, updates <-chan struct{}, heartbeat <-chan time.Time) bool {
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return false
+		case <-updates:
+			return true
+		case <-heartbeat:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return false
+			}
+			res.Flush()
+		}
+	}
+}
+
+// writeStreamEvent пишет в поток событие с заголовками header и данными data в JSON
+func writeStreamEvent(w io.Writer, header string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\ndata: %s\n\n", header, payload)
+	return err
+}
+
+// ListLiveGames возвращает идущие партии
+// @Summary Идущие партии
+// @Description Возвращает до 100 идущих партий, начиная с последних созданных, с числом зрителей; закрытые партии в список не попадают. Фильтра по диапазону рейтинга нет: рейтинга у игроков нет, есть только флаг rated
+// @Tags games
+// @Produce json
+// @Param variant query string false "Вариант"
+// @Param rated query bool false "Только рейтинговые или только товарищеские"
+// @Param tournamentId query string false "ID турнира"
+// @Success 200 {array} dtos.LiveGameResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/games/live [get]
+func (c *GameController) ListLiveGames(ctx echo.Context) error {
+	filter := models.LiveGameFilter{Variant: enums.Variant(ctx.QueryParam("variant"))}
+	if value := ctx.QueryParam("rated"); value != "" {
+		rated, err := strconv.ParseBool(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid rated filter")
+		}
+		filter.Rated = &rated
+	}
+	if value := ctx.QueryParam("tournamentId"); value != "" {
+		tournamentID, err := uuid.Parse(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid tournament ID")
+		}
+		filter.TournamentID = &tournamentID
+	}
+
+	games, err := c.gameService.ListLiveGames(filter)
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dtos.LiveGameResponse, 0, len(games))
+	for _, game := range games {
+		resp = append(resp, dtos.LiveGameResponse{
+			GameID:          game.ID,
+			Variant:         string(game.Variant),
+			TimeControl:     mapTimeControl(game.TimeControl),
+			Rated:           game.Rated,
+			Seats:           game.Players(),
+			CurrentPlayerID: game.CurrentPlayerID,
+			MoveCount:       game.MoveCount,
+			Spectators:      c.spectators.Count(game.ID),
+			CreatedAt:       game.CreatedAt,
+		})
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func mapGameEvent(e models.RecordedGameEvent) dtos.GameEventResponse {
+	return dtos.GameEventResponse{
+		Version:    e.Version,
+		Type:       string(e.Event.EventType()),
+		OccurredAt: e.OccurredAt,
+		Payload:    e.Event,
+	}
+}
+
+// mapGameStateToResponse строит ответ с полем line, видимым запросившему
+func (c *GameController) mapGameStateToResponse(game *models.Game, line []enums.PositionState) dtos.GameStateResponse {
+	resp := dtos.GameStateResponse{
+		GameID:              game.ID,
+		Status:              game.Status.String(),
+		Variant:             string(game.Variant),
+		Rules:               mapRules(game.Rules),
+		Private:             game.Private,
+		Termination:         string(game.Termination),
+		CurrentPlayerID:     game.CurrentPlayerID,
+		Seats:               game.Players(),
+		Scores:              game.Scores,
+		Ranking:             game.Ranking,
+		Line:                line,
+		Coordinates:         game.Coordinates,
+		Grid:                mapGrid(game),
+		MoveCount:           game.MoveCount,
+		Schedule:            game.Schedule,
+		DrawOfferedBy:       game.DrawOfferedBy,
+		TakebackRequestedBy: game.TakebackRequestedBy,
+		PreviousGameID:      game.PreviousGameID,
+		RematchOfferedBy:    game.RematchOfferedBy,
+		RematchGameID:       game.RematchGameID,
+		Spectators:          c.spectators.Count(game.ID),
+	}
+	if game.Status == enums.InProgress {
+		resp.PlacementsLeft = game.PlacementsLeft()
+	}
+	if game.Rules.TurnOrder == enums.SimultaneousTurns {
+		resp.Round = game.Round
+		for _, playerID := range game.Players() {
+			if game.HasCommitted(playerID) {
+				resp.CommittedBy = append(resp.CommittedBy, playerID)
+			}
+		}
+	}
+
+	if !game.TimeControl.IsUnlimited() {
+		now := time.Now()
+		resp.Clock = &dtos.ClockResponse{
+			TimeControl:             mapTimeControl(game.TimeControl),
+			FirstPlayerRemainingMs:  game.RemainingTime(game.FirstPlayerID, now).Milliseconds(),
+			SecondPlayerRemainingMs: game.RemainingTime(game.SecondPlayerID, now).Milliseconds(),
+			TurnStartedAt:           game.TurnStartedAt,
+			TurnDeadline:            game.TurnDeadline,
+		}
+	}
+
+	return resp
+}
+
+// ListVariants возвращает доступные варианты игры
+// @Summary Список вариантов
+// @Description Возвращает разрешённые на сервере варианты с правилами по умолчанию и схемой параметров для CreateGameRequest.parameters
+// @Tags variants
+// @Produce json
+// @Success 200 {array} dtos.VariantResponse
+// @Router /api/variants [get]
+func (c *GameController) ListVariants(ctx echo.Context) error {
+	variants := c.gameService.ListVariants()
+
+	resp := make([]dtos.VariantResponse, 0, len(variants))
+	for _, variant := range variants {
+		parameters := make([]dtos.VariantParameter, 0, len(variant.Parameters))
+		for _, p := range variant.Parameters {
+			parameters = append(parameters, dtos.VariantParameter{
+				Name:        p.Name,
+				Type:        p.Type,
+				Description: p.Description,
+				Required:    p.Required,
+				Values:      p.Values,
+			})
+		}
+		resp = append(resp, dtos.VariantResponse{
+			Name:        string(variant.Name),
+			Description: variant.Description,
+			Rules:       mapRules(variant.Rules),
+			Schedule:    variant.Schedule,
+			Parameters:  parameters,
+		})
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func mapRules(rules models.Rules) dtos.Rules {
+	return dtos.Rules{
+		Topology:     string(rules.Topology),
+		TurnOrder:    string(rules.TurnOrder),
+		Scoring:      string(rules.Scoring),
+		EndCondition: string(rules.EndCondition),
+		PieRule:      rules.PieRule,
+		HiddenNails:  rules.HiddenNails,
+	}
+}
+
+func mapGrid(game *models.Game) *dtos.Grid {
+	grid, ok := game.Grid()
+	if !ok {
+		return nil
+	}
+	return &dtos.Grid{Width: grid.Width, Height: grid.Height, Metric: string(grid.Metric)}
+}
+
+func mapTimeControl(tc models.TimeControl) dtos.TimeControl {
+	return dtos.TimeControl{
+		Type:             string(tc.Type),
+		BaseSeconds:      tc.BaseSeconds,
+		IncrementSeconds: tc.IncrementSeconds,
+		MoveSeconds:      tc.MoveSeconds,
+		DaysPerMove:      tc.DaysPerMove,
+	}
+}
+
+// mapGameSettings переводит параметры партии из запроса в модель
+func mapGameSettings(req dtos.GameSettingsRequest) models.GameSettings {
+	settings := models.GameSettings{
+		LineSize:   req.LineSize,
+		Variant:    enums.Variant(req.Variant),
+		Rated:      req.Rated,
+		Private:    req.Private,
+		Schedule:   req.Schedule,
+		Parameters: req.Parameters,
+	}
+	if req.StartPosition != nil {
+		settings.StartPosition = make([]enums.PositionState, len(req.StartPosition))
+		for i, state := range req.StartPosition {
+			settings.StartPosition[i] = enums.PositionState(state)
+		}
+	}
+	if req.Grid != nil {
+		settings.Grid = models.Grid{
+			Width:  req.Grid.Width,
+			Height: req.Grid.Height,
+			Metric: enums.DistanceMetric(req.Grid.Metric),
+		}
+	}
+	if req.Board != nil {
+		settings.Board = models.BoardLayout{
+			Preset:      req.Board.Preset,
+			Coordinates: req.Board.Coordinates,
+			Seed:        req.Board.Seed,
+		}
+	}
+	if req.TimeControl != nil {
+		settings.TimeControl = models.TimeControl{
+			Type:             enums.TimeControlType(req.TimeControl.Type),
+			BaseSeconds:      req.TimeControl.BaseSeconds,
+			IncrementSeconds: req.TimeControl.IncrementSeconds,
+			MoveSeconds:      req.TimeControl.MoveSeconds,
+			DaysPerMove:      req.TimeControl.DaysPerMove,
+		}
 	}
+	return settings
 }
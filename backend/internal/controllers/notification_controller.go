@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	"nails_game/internal/models/enums"
+	services "nails_game/internal/services/interfaces"
+)
+
+type NotificationController struct {
+	notificationService services.NotificationService
+	// tokens - nil, если сервер не выдаёт токены игроков
+	tokens services.TokenService
+}
+
+func NewNotificationController(notificationService services.NotificationService, tokens services.TokenService) *NotificationController {
+	return &NotificationController{notificationService: notificationService, tokens: tokens}
+}
+
+// GetPreferences возвращает настройки писем игрока
+// @Summary Настройки уведомлений
+// @Description Возвращает, как игрок получает письма о своём ходе в заочных партиях
+// @Tags players
+// @Produce json
+// @Security ApiKeyAuth
+// @Param playerId path string true "ID игрока"
+// @Success 200 {object} dtos.NotificationPreferences
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/players/{playerId}/notifications [get]
+func (c *NotificationController) GetPreferences(ctx echo.Context) error {
+	playerID, err := c.player(ctx)
+	if err != nil {
+		return err
+	}
+
+	preferences, err := c.notificationService.Preferences(playerID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, mapNotificationPreferences(*preferences))
+}
+
+// UpdatePreferences меняет настройки писем игрока
+// @Summary Изменить настройки уведомлений
+// @Description Письма о ходе в заочных партиях приходят сразу, сводкой не чаще раза за период или не приходят; отдельно включаются напоминания об истекающем времени
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param playerId path string true "ID игрока"
+// @Param request body dtos.NotificationPreferences true "Настройки"
+// @Success 200 {object} dtos.NotificationPreferences
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/players/{playerId}/notifications [put]
+func (c *NotificationController) UpdatePreferences(ctx echo.Context) error {
+	playerID, err := c.player(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req dtos.NotificationPreferences
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	preferences, err := c.notificationService.UpdatePreferences(playerID, models.NotificationPreferences{
+		Mode:        enums.NotificationMode(req.Mode),
+		NoReminders: !req.Reminders,
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, mapNotificationPreferences(*preferences))
+}
+
+// player возвращает игрока из пути; настройки меняет только он сам
+func (c *NotificationController) player(ctx echo.Context) (uuid.UUID, error) {
+	playerID, err := uuid.Parse(ctx.Param("playerId"))
+	if err != nil {
+		return uuid.Nil, echo.NewHTTPError(http.StatusBadRequest, "invalid player ID")
+	}
+	return actingPlayer(ctx, c.tokens, playerID)
+}
+
+func mapNotificationPreferences(preferences models.NotificationPreferences) dtos.NotificationPreferences {
+	return dtos.NotificationPreferences{
+		Mode:      string(preferences.Mode),
+		Reminders: !preferences.NoReminders,
+	}
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+type SeriesController struct {
+	seriesService services.SeriesService
+	// tokens - nil, если сервер не выдаёт токены игроков
+	tokens services.TokenService
+}
+
+func NewSeriesController(seriesService services.SeriesService, tokens services.TokenService) *SeriesController {
+	return &SeriesController{seriesService: seriesService, tokens: tokens}
+}
+
+// CreateSeries создаёт серию партий
+// @Summary Создать серию
+// @Description Создаёт серию до победы в большинстве из bestOf партий; первая партия создаётся с параметрами из game сразу или в startsAt, каждая следующая - реванш предыдущей со сменой цвета. Создать серию может только один из её игроков
+// @Tags series
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dtos.CreateSeriesRequest true "Данные серии"
+// @Success 201 {object} dtos.SeriesResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/series [post]
+func (c *SeriesController) CreateSeries(ctx echo.Context) error {
+	var req dtos.CreateSeriesRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.checkSeriesPlayer(ctx, req); err != nil {
+		return err
+	}
+
+	series, err := c.seriesService.CreateSeries(models.SeriesSettings{
+		FirstPlayerID:  req.FirstPlayerID,
+		SecondPlayerID: req.SecondPlayerID,
+		BestOf:         req.BestOf,
+		StartsAt:       req.StartsAt,
+		Game:           mapGameSettings(req.Game),
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, mapSeriesToResponse(*series))
+}
+
+// checkSeriesPlayer пускает создать серию только одного из её игроков
+func (c *SeriesController) checkSeriesPlayer(ctx echo.Context, req dtos.CreateSeriesRequest) error {
+	playerID, err := actingPlayer(ctx, c.tokens, uuid.Nil)
+	if err != nil || c.tokens == nil {
+		return err
+	}
+	if playerID != req.FirstPlayerID && playerID != req.SecondPlayerID {
+		return serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerMismatch, "token belongs to neither series player")
+	}
+	return nil
+}
+
+// ListSeries возвращает серии
+// @Summary Список серий
+// @Description Возвращает все серии, начиная с последних созданных, без партий
+// @Tags series
+// @Produce json
+// @Success 200 {array} dtos.SeriesResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/series [get]
+func (c *SeriesController) ListSeries(ctx echo.Context) error {
+	series, err := c.seriesService.ListSeries()
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dtos.SeriesResponse, 0, len(series))
+	for _, s := range series {
+		resp = append(resp, mapSeriesToResponse(s))
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// GetSeries возвращает серию
+// @Summary Получить серию
+// @Description Возвращает серию со счётом и всеми партиями
+// @Tags series
+// @Produce json
+// @Param seriesId path string true "ID серии"
+// @Success 200 {object} dtos.SeriesResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/series/{seriesId} [get]
+func (c *SeriesController) GetSeries(ctx echo.Context) error {
+	seriesID, err := uuid.Parse(ctx.Param("seriesId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid series ID")
+	}
+
+	series, err := c.seriesService.GetSeries(seriesID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, mapSeriesToResponse(*series))
+}
+
+func mapSeriesToResponse(series models.Series) dtos.SeriesResponse {
+	resp := dtos.SeriesResponse{
+		ID:                series.ID,
+		FirstPlayerID:     series.FirstPlayerID,
+		SecondPlayerID:    series.SecondPlayerID,
+		BestOf:            series.BestOf,
+		Status:            string(series.Status),
+		Variant:           string(series.GameSettings.Variant),
+		TimeControl:       mapTimeControl(series.GameSettings.TimeControl),
+		FirstPlayerScore:  series.Score(series.FirstPlayerID),
+		SecondPlayerScore: series.Score(series.SecondPlayerID),
+		WinnerID:          series.WinnerID,
+		StartsAt:          series.StartsAt,
+		CreatedAt:         series.CreatedAt,
+		FinishedAt:        series.FinishedAt,
+	}
+	for _, g := range series.Games {
+		resp.Games = append(resp.Games, dtos.SeriesGameResponse{
+			Number:        g.Number,
+			GameID:        g.GameID,
+			FirstPlayerID: g.FirstPlayerID,
+			Result:        string(g.Result),
+			FinishedAt:    g.FinishedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	"nails_game/internal/models/enums"
+	services "nails_game/internal/services/interfaces"
+)
+
+type TournamentController struct {
+	tournamentService services.TournamentService
+	// tokens - nil, если сервер не выдаёт токены игроков
+	tokens services.TokenService
+}
+
+func NewTournamentController(tournamentService services.TournamentService, tokens services.TokenService) *TournamentController {
+	return &TournamentController{tournamentService: tournamentService, tokens: tokens}
+}
+
+// CreateTournament создаёт турнир
+// @Summary Создать турнир
+// @Description Создаёт турнир по круговой, швейцарской системе, на выбывание или арену на время; все партии турнира создаются с параметрами из game
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dtos.CreateTournamentRequest true "Данные турнира"
+// @Success 201 {object} dtos.TournamentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/tournaments [post]
+func (c *TournamentController) CreateTournament(ctx echo.Context) error {
+	var req dtos.CreateTournamentRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	settings := models.TournamentSettings{
+		Name:     req.Name,
+		Format:   enums.TournamentFormat(req.Format),
+		Rounds:   req.Rounds,
+		StartsAt: req.StartsAt,
+		Duration: time.Duration(req.DurationMinutes) * time.Minute,
+		Game:     mapGameSettings(req.Game),
+	}
+	organizerID, err := actingPlayer(ctx, c.tokens, req.OrganizerID)
+	if err != nil {
+		return err
+	}
+	tournament, err := c.tournamentService.CreateTournament(settings, organizerID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusCreated, mapTournamentToResponse(*tournament))
+}
+
+// ListTournaments возвращает турниры
+// @Summary Список турниров
+// @Description Возвращает все турниры, начиная с последних созданных, без участников и пар
+// @Tags tournaments
+// @Produce json
+// @Success 200 {array} dtos.TournamentResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/tournaments [get]
+func (c *TournamentController) ListTournaments(ctx echo.Context) error {
+	tournaments, err := c.tournamentService.ListTournaments()
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dtos.TournamentResponse, 0, len(tournaments))
+	for _, tournament := range tournaments {
+		resp = append(resp, mapTournamentToResponse(tournament))
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// GetTournament возвращает турнир
+// @Summary Получить турнир
+// @Description Возвращает турнир с участниками и парами всех туров
+// @Tags tournaments
+// @Produce json
+// @Param tournamentId path string true "ID турнира"
+// @Success 200 {object} dtos.TournamentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/tournaments/{tournamentId} [get]
+func (c *TournamentController) GetTournament(ctx echo.Context) error {
+	tournamentID, err := uuid.Parse(ctx.Param("tournamentId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid tournament ID")
+	}
+
+	tournament, err := c.tournamentService.GetTournament(tournamentID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, mapTournamentToResponse(*tournament))
+}
+
+// Register регистрирует игрока на турнир
+// @Summary Зарегистрироваться на турнир
+// @Description Добавляет игрока в список участников, пока турнир не начался; на арену можно прийти, пока она идёт, и вернуться после ухода
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param tournamentId path string true "ID турнира"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.TournamentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "REGISTRATION_CLOSED, ALREADY_REGISTERED"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/tournaments/{tournamentId}/register [post]
+func (c *TournamentController) Register(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.tournamentService.Register)
+}
+
+// Leave снимает игрока с арены
+// @Summary Покинуть арену
+// @Description Игрок арены перестаёт получать новые пары; начатую партию нужно доиграть
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param tournamentId path string true "ID турнира"
+// @Param request body dtos.PlayerActionRequest true "Игрок, выполняющий действие"
+// @Success 200 {object} dtos.TournamentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH, NOT_REGISTERED"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "ARENA_ONLY, TOURNAMENT_FINISHED"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/tournaments/{tournamentId}/leave [post]
+func (c *TournamentController) Leave(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.tournamentService.Leave)
+}
+
+// Start запускает турнир
+// @Summary Начать турнир
+// @Description Организатор закрывает регистрацию и запускает первый тур, не дожидаясь времени старта
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param tournamentId path string true "ID турнира"
+// @Param request body dtos.PlayerActionRequest true "Организатор турнира"
+// @Success 200 {object} dtos.TournamentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH, NOT_ORGANIZER"
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse "TOURNAMENT_STARTED, NOT_ENOUGH_PLAYERS"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/tournaments/{tournamentId}/start [post]
+func (c *TournamentController) Start(ctx echo.Context) error {
+	return c.handlePlayerAction(ctx, c.tournamentService.Start)
+}
+
+// GetStandings возвращает турнирную таблицу
+// @Summary Турнирная таблица
+// @Description Возвращает таблицу по сыгранным партиям: очки, затем коэффициенты Бухгольца и Зоннеборна-Бергера; в турнире на выбывание выбывшие идут последними. На арене победа даёт 2 очка, ничья - 1, после двух побед подряд очки удваиваются
+// @Tags tournaments
+// @Produce json
+// @Param tournamentId path string true "ID турнира"
+// @Success 200 {array} dtos.StandingResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/tournaments/{tournamentId}/standings [get]
+func (c *TournamentController) GetStandings(ctx echo.Context) error {
+	tournamentID, err := uuid.Parse(ctx.Param("tournamentId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid tournament ID")
+	}
+
+	standings, err := c.tournamentService.Standings(tournamentID)
+	if err != nil {
+		return err
+	}
+
+	resp := make([]dtos.StandingResponse, 0, len(standings))
+	for i, standing := range standings {
+		resp = append(resp, dtos.StandingResponse{
+			Rank:            i + 1,
+			PlayerID:        standing.PlayerID,
+			Seed:            standing.Seed,
+			Points:          standing.Points,
+			Wins:            standing.Wins,
+			Draws:           standing.Draws,
+			Losses:          standing.Losses,
+			Buchholz:        standing.Buchholz,
+			SonnebornBerger: standing.SonnebornBerger,
+			Eliminated:      standing.Eliminated,
+			OnFire:          standing.OnFire,
+		})
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *TournamentController) handlePlayerAction(
+	ctx echo.Context,
+	action func(tournamentID, playerID uuid.UUID) (*models.Tournament, error),
+) error {
+	tournamentID, err := uuid.Parse(ctx.Param("tournamentId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid tournament ID")
+	}
+
+	var req dtos.PlayerActionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	playerID, err := actingPlayer(ctx, c.tokens, req.PlayerID)
+	if err != nil {
+		return err
+	}
+	tournament, err := action(tournamentID, playerID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, mapTournamentToResponse(*tournament))
+}
+
+func mapTournamentToResponse(tournament models.Tournament) dtos.TournamentResponse {
+	resp := dtos.TournamentResponse{
+		ID:           tournament.ID,
+		Name:         tournament.Name,
+		Format:       string(tournament.Format),
+		Status:       string(tournament.Status),
+		OrganizerID:  tournament.OrganizerID,
+		Variant:      string(tournament.GameSettings.Variant),
+		TimeControl:  mapTimeControl(tournament.GameSettings.TimeControl),
+		Rounds:       tournament.Rounds,
+		CurrentRound: tournament.CurrentRound,
+		StartsAt:     tournament.StartsAt,
+		EndsAt:       tournament.EndsAt,
+		CreatedAt:    tournament.CreatedAt,
+		FinishedAt:   tournament.FinishedAt,
+	}
+	resp.DurationMinutes = int(tournament.Duration / time.Minute)
+	for _, p := range tournament.Players {
+		resp.Players = append(resp.Players, dtos.TournamentPlayerResponse{PlayerID: p.PlayerID, Seed: p.Seed, Paused: p.Paused})
+	}
+	for _, p := range tournament.Pairings {
+		resp.Pairings = append(resp.Pairings, dtos.TournamentPairingResponse{
+			Round:          p.Round,
+			Board:          p.Board,
+			FirstPlayerID:  p.FirstPlayerID,
+			SecondPlayerID: p.SecondPlayerID,
+			GameID:         p.GameID,
+			Result:         string(p.Result),
+			Replays:        p.Replays,
+			FinishedAt:     p.FinishedAt,
+		})
+	}
+	return resp
+}
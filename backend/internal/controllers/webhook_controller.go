@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	"nails_game/internal/models/enums"
+	services "nails_game/internal/services/interfaces"
+)
+
+// adminKeyHeader - заголовок с ключом администратора вебхуков
+const adminKeyHeader = "X-Admin-Key"
+
+type WebhookController struct {
+	webhookService services.WebhookService
+	// tokens - nil, если сервер не выдаёт токены игроков
+	tokens services.TokenService
+	// adminKey - пусто, если глобальные вебхуки отключены
+	adminKey string
+}
+
+func NewWebhookController(webhookService services.WebhookService, tokens services.TokenService, adminKey string) *WebhookController {
+	return &WebhookController{webhookService: webhookService, tokens: tokens, adminKey: adminKey}
+}
+
+// Register регистрирует вебхук
+// @Summary Зарегистрировать вебхук
+// @Description Вебхук получает POST-запросы о событиях партий: game.created, move.played, game.finished. Тело подписано HMAC-SHA256 ключом из ответа, подпись передаётся в заголовке X-Nails-Signature. Вебхук игрока получает его партии, включая закрытые; вебхук турнира регистрирует организатор, глобальный - администратор. Адреса loopback, link-local и внутренних сетей отклоняются, если сервер не разрешает их явно
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-Admin-Key header string false "Ключ администратора"
+// @Param request body dtos.RegisterWebhookRequest true "Параметры вебхука"
+// @Success 201 {object} dtos.WebhookResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH, ADMIN_KEY_REQUIRED, NOT_ORGANIZER"
+// @Failure 404 {object} dtos.ErrorResponse "TOURNAMENT_NOT_FOUND"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/webhooks [post]
+func (c *WebhookController) Register(ctx echo.Context) error {
+	var req dtos.RegisterWebhookRequest
+	if err := ctx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	actor, err := c.actor(ctx, req.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	settings := models.WebhookSettings{
+		URL:          req.URL,
+		Scope:        enums.WebhookScope(req.Scope),
+		TournamentID: req.TournamentID,
+	}
+	for _, event := range req.Events {
+		settings.Events = append(settings.Events, enums.WebhookEvent(event))
+	}
+
+	webhook, err := c.webhookService.Register(actor, settings)
+	if err != nil {
+		return err
+	}
+	resp := mapWebhook(webhook)
+	resp.Secret = webhook.Secret
+	return ctx.JSON(http.StatusCreated, resp)
+}
+
+// Delete удаляет вебхук
+// @Summary Удалить вебхук
+// @Description Удаляет вебхук вместе с его доставками; доступно владельцу и администратору
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-Admin-Key header string false "Ключ администратора"
+// @Param webhookId path string true "ID вебхука"
+// @Param playerId query string false "ID владельца; с токеном игрока можно не указывать"
+// @Success 204
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH, NOT_WEBHOOK_OWNER"
+// @Failure 404 {object} dtos.ErrorResponse "WEBHOOK_NOT_FOUND"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/webhooks/{webhookId} [delete]
+func (c *WebhookController) Delete(ctx echo.Context) error {
+	webhookID, actor, err := c.webhookRequest(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.webhookService.Delete(actor, webhookID); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries возвращает доставки вебхука
+// @Summary Доставки вебхука
+// @Description Возвращает последние доставки вебхука с указанным состоянием; по умолчанию - недоставленные после всех попыток
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-Admin-Key header string false "Ключ администратора"
+// @Param webhookId path string true "ID вебхука"
+// @Param playerId query string false "ID владельца; с токеном игрока можно не указывать"
+// @Param status query string false "Состояние доставки" Enums(pending, delivered, dead) default(dead)
+// @Success 200 {array} dtos.WebhookDeliveryResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH, NOT_WEBHOOK_OWNER"
+// @Failure 404 {object} dtos.ErrorResponse "WEBHOOK_NOT_FOUND"
+// @Failure 422 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/webhooks/{webhookId}/deliveries [get]
+func (c *WebhookController) ListDeliveries(ctx echo.Context) error {
+	webhookID, actor, err := c.webhookRequest(ctx)
+	if err != nil {
+		return err
+	}
+	status := enums.DeliveryStatus(ctx.QueryParam("status"))
+	if status == "" {
+		status = enums.DeadDelivery
+	}
+
+	deliveries, err := c.webhookService.Deliveries(actor, webhookID, status)
+	if err != nil {
+		return err
+	}
+	resp := make([]dtos.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		resp = append(resp, mapWebhookDelivery(&deliveries[i]))
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// RetryDelivery повторно отправляет недоставленное событие
+// @Summary Повторить доставку
+// @Description Возвращает недоставленное событие в очередь; счётчик попыток начинается заново
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-Admin-Key header string false "Ключ администратора"
+// @Param webhookId path string true "ID вебхука"
+// @Param deliveryId path string true "ID доставки"
+// @Param playerId query string false "ID владельца; с токеном игрока можно не указывать"
+// @Success 200 {object} dtos.WebhookDeliveryResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 401 {object} dtos.ErrorResponse "AUTHENTICATION_REQUIRED"
+// @Failure 403 {object} dtos.ErrorResponse "PLAYER_MISMATCH, NOT_WEBHOOK_OWNER"
+// @Failure 404 {object} dtos.ErrorResponse "WEBHOOK_NOT_FOUND, DELIVERY_NOT_FOUND"
+// @Failure 409 {object} dtos.ErrorResponse "DELIVERY_NOT_DEAD"
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/webhooks/{webhookId}/deliveries/{deliveryId}/retry [post]
+func (c *WebhookController) RetryDelivery(ctx echo.Context) error {
+	webhookID, actor, err := c.webhookRequest(ctx)
+	if err != nil {
+		return err
+	}
+	deliveryID, err := uuid.Parse(ctx.Param("deliveryId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid delivery ID")
+	}
+
+	delivery, err := c.webhookService.RetryDelivery(actor, webhookID, deliveryID)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, mapWebhookDelivery(delivery))
+}
+
+// webhookRequest разбирает вебхук из пути и того, кто им управляет
+func (c *WebhookController) webhookRequest(ctx echo.Context) (uuid.UUID, services.WebhookActor, error) {
+	webhookID, err := uuid.Parse(ctx.Param("webhookId"))
+	if err != nil {
+		return uuid.Nil, services.WebhookActor{}, echo.NewHTTPError(http.StatusBadRequest, "invalid webhook ID")
+	}
+	var playerID uuid.UUID
+	if value := ctx.QueryParam("playerId"); value != "" {
+		if playerID, err = uuid.Parse(value); err != nil {
+			return uuid.Nil, services.WebhookActor{}, echo.NewHTTPError(http.StatusBadRequest, "invalid player ID")
+		}
+	}
+	actor, err := c.actor(ctx, playerID)
+	return webhookID, actor, err
+}
+
+// actor - администратор, если запрос пришёл с верным ключом, иначе игрок по токену
+func (c *WebhookController) actor(ctx echo.Context, playerID uuid.UUID) (services.WebhookActor, error) {
+	key := ctx.Request().Header.Get(adminKeyHeader)
+	if c.adminKey != "" && key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(c.adminKey)) == 1 {
+		return services.WebhookActor{PlayerID: playerID, Admin: true}, nil
+	}
+	playerID, err := actingPlayer(ctx, c.tokens, playerID)
+	if err != nil {
+		return services.WebhookActor{}, err
+	}
+	return services.WebhookActor{PlayerID: playerID}, nil
+}
+
+func mapWebhook(webhook *models.Webhook) dtos.WebhookResponse {
+	resp := dtos.WebhookResponse{
+		ID:           webhook.ID,
+		URL:          webhook.URL,
+		Scope:        string(webhook.Scope),
+		OwnerID:      webhook.OwnerID,
+		TournamentID: webhook.TournamentID,
+		CreatedAt:    webhook.CreatedAt,
+	}
+	for _, event := range webhook.Events {
+		resp.Events = append(resp.Events, string(event))
+	}
+	return resp
+}
+
+func mapWebhookDelivery(delivery *models.WebhookDelivery) dtos.WebhookDeliveryResponse {
+	return dtos.WebhookDeliveryResponse{
+		ID:            delivery.ID,
+		Event:         string(delivery.Event),
+		GameID:        delivery.GameID,
+		Status:        string(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+		CreatedAt:     delivery.CreatedAt,
+		DeliveredAt:   delivery.DeliveredAt,
+	}
+}
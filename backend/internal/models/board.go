@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// BoardLayout - способ задать координаты позиций поля при создании партии или пресета;
+// указывается не больше одного из полей, без них позиции идут подряд 0..n-1
+type BoardLayout struct {
+	// Preset - имя сохранённого пресета
+	Preset string
+	// Coordinates - явные координаты позиций по возрастанию
+	Coordinates []int
+	// Seed - зерно генератора; одинаковое зерно и размер дают одинаковое поле
+	Seed *int64
+}
+
+func (l BoardLayout) IsEmpty() bool {
+	return l.Preset == "" && l.Coordinates == nil && l.Seed == nil
+}
+
+// BoardPreset - именованная раскладка поля, например для турниров
+type BoardPreset struct {
+	Name        string `gorm:"primaryKey"`
+	Coordinates []int  `gorm:"type:integer[]"`
+	Seed        *int64
+	CreatedAt   time.Time
+}
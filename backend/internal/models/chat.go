@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models/enums"
+)
+
+// ChatMessage - сообщение в чате партии
+type ChatMessage struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	GameID    uuid.UUID `gorm:"type:uuid"`
+	Channel   enums.ChatChannel
+	PlayerID  uuid.UUID `gorm:"type:uuid"`
+	Text      string
+	CreatedAt time.Time
+}
+
+// ChatMute - игрок партии отключил сообщения соперников в чате игроков
+type ChatMute struct {
+	GameID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	PlayerID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time
+}
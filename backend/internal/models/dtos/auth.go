@@ -0,0 +1,17 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// LoginRequest represents request for signing in
+// @Description Вход игрока по почте и паролю
+type LoginRequest struct {
+	Email    string `json:"email" example:"first@example.com"`
+	Password string `json:"password" example:"first-password"`
+}
+
+// LoginResponse represents a sign-in token
+// @Description Токен входа для заголовка Authorization: Bearer; действует во всех партиях, турнирах и сериях игрока
+type LoginResponse struct {
+	PlayerID uuid.UUID `json:"playerId"`
+	Token    string    `json:"token"`
+}
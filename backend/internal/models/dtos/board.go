@@ -0,0 +1,36 @@
+package dtos
+
+import "time"
+
+// BoardLayout represents board coordinates requested for a game
+// @Description Раскладка нерегулярного поля: указывается одно из полей
+type BoardLayout struct {
+	Preset      string `json:"preset,omitempty" example:"tournament-1"`
+	Coordinates []int  `json:"coordinates,omitempty"`
+	Seed        *int64 `json:"seed,omitempty" example:"42"`
+}
+
+// CreateBoardPresetRequest represents request for saving a board preset
+// @Description Запрос на сохранение пресета поля: координаты либо зерно с размером
+type CreateBoardPresetRequest struct {
+	Name        string `json:"name" example:"tournament-1"`
+	Coordinates []int  `json:"coordinates,omitempty"`
+	Seed        *int64 `json:"seed,omitempty" example:"42"`
+	Size        int    `json:"size,omitempty" example:"20"`
+}
+
+// BoardPresetResponse represents a saved board preset
+// @Description Сохранённый пресет поля
+type BoardPresetResponse struct {
+	Name        string    `json:"name"`
+	Coordinates []int     `json:"coordinates"`
+	Seed        *int64    `json:"seed,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// GeneratedBoardResponse represents coordinates generated from a seed
+// @Description Поле, построенное по зерну
+type GeneratedBoardResponse struct {
+	Seed        int64 `json:"seed"`
+	Coordinates []int `json:"coordinates"`
+}
@@ -0,0 +1,26 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatMessageRequest represents a chat message sent to a game
+// @Description Сообщение в чат партии
+type ChatMessageRequest struct {
+	// PlayerID - автор; с токеном можно не указывать
+	PlayerID uuid.UUID `json:"playerId"`
+	Channel  string    `json:"channel" enums:"players,spectators" example:"players"`
+	Text     string    `json:"text"`
+}
+
+// ChatMessageResponse represents a chat message
+// @Description Сообщение из чата партии
+type ChatMessageResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Channel   string    `json:"channel"`
+	PlayerID  uuid.UUID `json:"playerId"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
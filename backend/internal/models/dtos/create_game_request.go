@@ -2,10 +2,32 @@ package dtos
 
 import "github.com/google/uuid"
 
+// GameSettingsRequest represents settings of a game
+// @Description Параметры партии
+type GameSettingsRequest struct {
+	LineSize    int          `json:"line_size"`
+	Variant     string       `json:"variant" enums:"standard,pie,circular,grid,fog,simultaneous,handicap,misere" example:"standard"`
+	TimeControl *TimeControl `json:"timeControl,omitempty"`
+	Rated       bool         `json:"rated"`
+	// Private - партию не видно в GET /api/games/live и смотреть её могут только её игроки; нужны токены игроков на сервере
+	Private bool         `json:"private"`
+	Board   *BoardLayout `json:"board,omitempty"`
+	Grid    *Grid        `json:"grid,omitempty"`
+	// Schedule - число гвоздей за ход, последний элемент повторяется; [1, 2] - как в Connect6
+	Schedule []int `json:"schedule,omitempty"`
+	// Parameters - параметры варианта по его схеме из GET /api/variants; перекрывают одноимённые поля запроса
+	Parameters map[string]interface{} `json:"parameters,omitempty" swaggertype:"object"`
+	// StartPosition - начальная расстановка по позициям: 0 - свободно, -1 - закрыто,
+	// 1..6 - гвоздь игрока на этом месте; для форы и задач
+	StartPosition []int `json:"startPosition,omitempty" example:"0,1,-1,0,2"`
+}
+
 // CreateGameRequest represents request for creating a game
 // @Description Запрос на создание игры
 type CreateGameRequest struct {
-	LineSize       int       `json:"line_size"`
+	GameSettingsRequest
 	FirstPlayerID  uuid.UUID `json:"firstPlayerId"`
 	SecondPlayerID uuid.UUID `json:"secondPlayerId"`
+	// PlayerIDs - от 2 до 6 игроков в порядке ходов вместо firstPlayerId и secondPlayerId
+	PlayerIDs []uuid.UUID `json:"playerIds,omitempty"`
 }
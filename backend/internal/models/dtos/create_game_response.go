@@ -5,9 +5,22 @@ import "github.com/google/uuid"
 // CreateGameResponse represents response for created game
 // @Description Ответ с созданной игрой
 type CreateGameResponse struct {
-	GameID         uuid.UUID `json:"gameId"`
-	LineSize       int       `json:"lineSize"`
-	FirstPlayerID  uuid.UUID `json:"firstPlayerId"`
-	SecondPlayerID uuid.UUID `json:"secondPlayerId"`
-	Status         string    `json:"status"`
+	GameID         uuid.UUID   `json:"gameId"`
+	LineSize       int         `json:"lineSize"`
+	Coordinates    []int       `json:"coordinates,omitempty"`
+	Grid           *Grid       `json:"grid,omitempty"`
+	Schedule       []int       `json:"schedule,omitempty"`
+	Variant        string      `json:"variant"`
+	Rules          Rules       `json:"rules"`
+	TimeControl    TimeControl `json:"timeControl"`
+	Rated          bool        `json:"rated"`
+	Private        bool        `json:"private"`
+	FirstPlayerID  uuid.UUID   `json:"firstPlayerId"`
+	SecondPlayerID uuid.UUID   `json:"secondPlayerId"`
+	Seats          []uuid.UUID `json:"seats"`
+	Status         string      `json:"status"`
+	// PlayerToken - токен создателя партии для заголовка Authorization: Bearer; действует
+	// только в этой партии. Остальные игроки получают токены через вход по паролю.
+	// В варианте fog без токена свои гвозди игроку не видны
+	PlayerToken string `json:"playerToken,omitempty"`
 }
@@ -0,0 +1,10 @@
+package dtos
+
+// ErrorResponse represents an API error
+// @Description Ошибка API. Поле code стабильно и предназначено для обработки на клиенте
+type ErrorResponse struct {
+	Code      string                 `json:"code" example:"NOT_YOUR_TURN"`
+	Message   string                 `json:"message" example:"not this player's turn"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	RequestID string                 `json:"requestId,omitempty"`
+}
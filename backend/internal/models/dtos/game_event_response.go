@@ -0,0 +1,12 @@
+package dtos
+
+import "time"
+
+// GameEventResponse represents a recorded game event
+// @Description Событие из истории партии
+type GameEventResponse struct {
+	Version    int         `json:"version"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Payload    interface{} `json:"payload"`
+}
@@ -10,7 +10,31 @@ import (
 type GameStateResponse struct {
 	GameID          uuid.UUID             `json:"gameId"`
 	Status          string                `json:"status"`
+	Variant         string                `json:"variant"`
+	Rules           Rules                 `json:"rules"`
+	Private         bool                  `json:"private"`
+	Termination     string                `json:"termination,omitempty"`
 	CurrentPlayerID uuid.UUID             `json:"currentPlayerId"`
+	Seats           []uuid.UUID           `json:"seats"`
+	Scores          []float64             `json:"scores,omitempty"`
+	Ranking         []uuid.UUID           `json:"ranking,omitempty"`
 	Line            []enums.PositionState `json:"line"`
+	Coordinates     []int                 `json:"coordinates,omitempty"`
+	Grid            *Grid                 `json:"grid,omitempty"`
 	MoveCount       int                   `json:"moveCount"`
+	Schedule        []int                 `json:"schedule,omitempty"`
+	// PlacementsLeft - сколько гвоздей игроку на ходу осталось поставить в текущем ходе
+	PlacementsLeft int `json:"placementsLeft"`
+	// Round - номер текущего раунда варианта simultaneous, CommittedBy - уже выбравшие в нём позицию
+	Round               int            `json:"round,omitempty"`
+	CommittedBy         []uuid.UUID    `json:"committedBy,omitempty"`
+	Clock               *ClockResponse `json:"clock,omitempty"`
+	DrawOfferedBy       *uuid.UUID     `json:"drawOfferedBy,omitempty"`
+	TakebackRequestedBy *uuid.UUID     `json:"takebackRequestedBy,omitempty"`
+	// PreviousGameID - партия, реваншем которой является эта; RematchGameID - созданный реванш
+	PreviousGameID   *uuid.UUID `json:"previousGameId,omitempty"`
+	RematchOfferedBy *uuid.UUID `json:"rematchOfferedBy,omitempty"`
+	RematchGameID    *uuid.UUID `json:"rematchGameId,omitempty"`
+	// Spectators - сколько зрителей сейчас следят за партией через поток
+	Spectators int `json:"spectators"`
 }
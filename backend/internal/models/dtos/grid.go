@@ -0,0 +1,9 @@
+package dtos
+
+// Grid represents a two-dimensional board
+// @Description Двумерное поле: клетки в line идут построчно, позиция = row*width + col
+type Grid struct {
+	Width  int    `json:"width" example:"5"`
+	Height int    `json:"height" example:"4"`
+	Metric string `json:"metric,omitempty" enums:"manhattan,euclidean" example:"manhattan"`
+}
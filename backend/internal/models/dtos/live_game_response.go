@@ -0,0 +1,21 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LiveGameResponse represents an ongoing game open to spectators
+// @Description Идущая партия в списке для зрителей
+type LiveGameResponse struct {
+	GameID          uuid.UUID   `json:"gameId"`
+	Variant         string      `json:"variant"`
+	TimeControl     TimeControl `json:"timeControl"`
+	Rated           bool        `json:"rated"`
+	Seats           []uuid.UUID `json:"seats"`
+	CurrentPlayerID uuid.UUID   `json:"currentPlayerId"`
+	MoveCount       int         `json:"moveCount"`
+	Spectators      int         `json:"spectators"`
+	CreatedAt       time.Time   `json:"createdAt"`
+}
@@ -7,4 +7,9 @@ import "github.com/google/uuid"
 type MoveRequest struct {
 	PlayerID uuid.UUID `json:"playerId"`
 	Position int       `json:"position"`
+	// Row и Col задают клетку на двумерном поле вместо position
+	Row *int `json:"row,omitempty" example:"1"`
+	Col *int `json:"col,omitempty" example:"2"`
+	// Type - тип хода; swap доступен только в варианте pie вместо первого ответного хода
+	Type string `json:"type,omitempty" enums:"place,swap" example:"place"`
 }
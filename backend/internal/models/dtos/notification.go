@@ -0,0 +1,10 @@
+package dtos
+
+// NotificationPreferences represents notification settings of a player
+// @Description Настройки писем о заочных партиях
+type NotificationPreferences struct {
+	// Mode - письмо о каждом ходе сразу, сводка за период или без писем
+	Mode string `json:"mode" enums:"immediate,digest,off" example:"immediate"`
+	// Reminders - напоминать, что время на ход истекает; в режиме digest напоминания приходят сразу
+	Reminders bool `json:"reminders" example:"true"`
+}
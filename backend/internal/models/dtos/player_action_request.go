@@ -0,0 +1,9 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// PlayerActionRequest represents a request for a game action that is not a move
+// @Description Запрос на действие игрока в партии (сдаться, прервать, ничья)
+type PlayerActionRequest struct {
+	PlayerID uuid.UUID `json:"playerId"`
+}
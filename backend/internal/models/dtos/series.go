@@ -0,0 +1,49 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateSeriesRequest represents request for creating a series of games
+// @Description Запрос на создание серии партий двух игроков
+type CreateSeriesRequest struct {
+	FirstPlayerID  uuid.UUID `json:"firstPlayerId"`
+	SecondPlayerID uuid.UUID `json:"secondPlayerId"`
+	// BestOf - наибольшее число засчитываемых партий, от 1 до 15
+	BestOf int `json:"bestOf" example:"3"`
+	// StartsAt - время начала первой партии; без него серия начинается сразу
+	StartsAt *time.Time          `json:"startsAt,omitempty"`
+	Game     GameSettingsRequest `json:"game"`
+}
+
+// SeriesGameResponse represents a game of a series
+// @Description Партия серии; прерванная партия переигрывается и не засчитывается
+type SeriesGameResponse struct {
+	Number        int        `json:"number" example:"1"`
+	GameID        uuid.UUID  `json:"gameId"`
+	FirstPlayerID uuid.UUID  `json:"firstPlayerId"`
+	Result        string     `json:"result" enums:",first_won,second_won,draw,aborted"`
+	FinishedAt    *time.Time `json:"finishedAt,omitempty"`
+}
+
+// SeriesResponse represents a series of games
+// @Description Серия партий со счётом; цвет меняется в каждой следующей партии
+type SeriesResponse struct {
+	ID                uuid.UUID   `json:"id"`
+	FirstPlayerID     uuid.UUID   `json:"firstPlayerId"`
+	SecondPlayerID    uuid.UUID   `json:"secondPlayerId"`
+	BestOf            int         `json:"bestOf" example:"3"`
+	Status            string      `json:"status" enums:"scheduled,in_progress,finished"`
+	Variant           string      `json:"variant" example:"standard"`
+	TimeControl       TimeControl `json:"timeControl"`
+	FirstPlayerScore  float64     `json:"firstPlayerScore" example:"1.5"`
+	SecondPlayerScore float64     `json:"secondPlayerScore" example:"0.5"`
+	// WinnerID пуст, пока серия идёт, и при равном счёте
+	WinnerID   *uuid.UUID           `json:"winnerId,omitempty"`
+	StartsAt   *time.Time           `json:"startsAt,omitempty"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	FinishedAt *time.Time           `json:"finishedAt,omitempty"`
+	Games      []SeriesGameResponse `json:"games,omitempty"`
+}
@@ -0,0 +1,23 @@
+package dtos
+
+import "time"
+
+// TimeControl represents game time control settings
+// @Description Контроль времени: unlimited, fischer (baseSeconds + incrementSeconds), per_move (moveSeconds) или correspondence (daysPerMove)
+type TimeControl struct {
+	Type             string `json:"type" example:"fischer"`
+	BaseSeconds      int    `json:"baseSeconds,omitempty" example:"300"`
+	IncrementSeconds int    `json:"incrementSeconds,omitempty" example:"5"`
+	MoveSeconds      int    `json:"moveSeconds,omitempty"`
+	DaysPerMove      int    `json:"daysPerMove,omitempty"`
+}
+
+// ClockResponse represents players' clocks
+// @Description Состояние часов партии; оставшееся время игрока на ходу учитывает текущий ход
+type ClockResponse struct {
+	TimeControl             TimeControl `json:"timeControl"`
+	FirstPlayerRemainingMs  int64       `json:"firstPlayerRemainingMs"`
+	SecondPlayerRemainingMs int64       `json:"secondPlayerRemainingMs"`
+	TurnStartedAt           *time.Time  `json:"turnStartedAt,omitempty"`
+	TurnDeadline            *time.Time  `json:"turnDeadline,omitempty"`
+}
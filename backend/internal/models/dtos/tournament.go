@@ -0,0 +1,84 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateTournamentRequest represents request for creating a tournament
+// @Description Запрос на создание турнира
+type CreateTournamentRequest struct {
+	Name   string `json:"name" example:"Weekly blitz"`
+	Format string `json:"format" enums:"round_robin,swiss,single_elimination,double_elimination,arena" example:"swiss"`
+	// Rounds - число туров, задаётся только для швейцарской системы
+	Rounds int `json:"rounds,omitempty" example:"5"`
+	// DurationMinutes - продолжительность арены, задаётся только для неё
+	DurationMinutes int `json:"durationMinutes,omitempty" example:"60"`
+	// StartsAt - время автоматического старта; без него турнир запускает организатор
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+	// OrganizerID - с токеном игрока можно не указывать, организатором становится владелец токена
+	OrganizerID uuid.UUID           `json:"organizerId"`
+	Game        GameSettingsRequest `json:"game"`
+}
+
+// TournamentPlayerResponse represents a registered player
+// @Description Участник турнира
+type TournamentPlayerResponse struct {
+	PlayerID uuid.UUID `json:"playerId"`
+	Seed     int       `json:"seed" example:"1"`
+	// Paused - игрок арены ушёл и не получает новых пар
+	Paused bool `json:"paused,omitempty"`
+}
+
+// TournamentPairingResponse represents a pairing of a round
+// @Description Пара тура; без второго игрока первый пропускает тур и получает очко
+type TournamentPairingResponse struct {
+	Round          int        `json:"round" example:"1"`
+	Board          int        `json:"board" example:"1"`
+	FirstPlayerID  uuid.UUID  `json:"firstPlayerId"`
+	SecondPlayerID *uuid.UUID `json:"secondPlayerId,omitempty"`
+	GameID         *uuid.UUID `json:"gameId,omitempty"`
+	Result         string     `json:"result" enums:",first_won,second_won,draw,bye,aborted"`
+	Replays        int        `json:"replays"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+}
+
+// TournamentResponse represents a tournament
+// @Description Турнир с участниками и парами
+type TournamentResponse struct {
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	Format       string      `json:"format" enums:"round_robin,swiss,single_elimination,double_elimination,arena"`
+	Status       string      `json:"status" enums:"registration,in_progress,finished"`
+	OrganizerID  uuid.UUID   `json:"organizerId"`
+	Variant      string      `json:"variant" example:"standard"`
+	TimeControl  TimeControl `json:"timeControl"`
+	Rounds       int         `json:"rounds"`
+	CurrentRound int         `json:"currentRound"`
+	StartsAt     *time.Time  `json:"startsAt,omitempty"`
+	// DurationMinutes и EndsAt - продолжительность арены и время её окончания
+	DurationMinutes int                         `json:"durationMinutes,omitempty"`
+	EndsAt          *time.Time                  `json:"endsAt,omitempty"`
+	CreatedAt       time.Time                   `json:"createdAt"`
+	FinishedAt      *time.Time                  `json:"finishedAt,omitempty"`
+	Players         []TournamentPlayerResponse  `json:"players,omitempty"`
+	Pairings        []TournamentPairingResponse `json:"pairings,omitempty"`
+}
+
+// StandingResponse represents a row of tournament standings
+// @Description Строка турнирной таблицы
+type StandingResponse struct {
+	Rank            int       `json:"rank" example:"1"`
+	PlayerID        uuid.UUID `json:"playerId"`
+	Seed            int       `json:"seed"`
+	Points          float64   `json:"points" example:"3.5"`
+	Wins            int       `json:"wins"`
+	Draws           int       `json:"draws"`
+	Losses          int       `json:"losses"`
+	Buchholz        float64   `json:"buchholz"`
+	SonnebornBerger float64   `json:"sonnebornBerger"`
+	Eliminated      bool      `json:"eliminated"`
+	// OnFire - игрок арены выиграл две партии подряд и получает двойные очки
+	OnFire bool `json:"onFire"`
+}
@@ -0,0 +1,32 @@
+package dtos
+
+// Rules represents rules of a game
+// @Description Правила партии
+type Rules struct {
+	Topology     string `json:"topology" enums:"line,ring,grid" example:"line"`
+	TurnOrder    string `json:"turnOrder" enums:"sequential,simultaneous" example:"sequential"`
+	Scoring      string `json:"scoring" enums:"longest_thread,shortest_thread" example:"longest_thread"`
+	EndCondition string `json:"endCondition" enums:"board_full" example:"board_full"`
+	PieRule      bool   `json:"pieRule"`
+	HiddenNails  bool   `json:"hiddenNails"`
+}
+
+// VariantParameter represents a parameter of a variant
+// @Description Параметр варианта
+type VariantParameter struct {
+	Name        string   `json:"name" example:"lineSize"`
+	Type        string   `json:"type" enums:"integer,integer[],string,object" example:"integer"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	Values      []string `json:"values,omitempty"`
+}
+
+// VariantResponse represents a game variant
+// @Description Вариант игры с правилами по умолчанию и схемой параметров
+type VariantResponse struct {
+	Name        string             `json:"name" example:"standard"`
+	Description string             `json:"description"`
+	Rules       Rules              `json:"rules"`
+	Schedule    []int              `json:"schedule,omitempty"`
+	Parameters  []VariantParameter `json:"parameters"`
+}
@@ -0,0 +1,49 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegisterWebhookRequest represents request for registering a webhook
+// @Description Запрос на регистрацию вебхука; глобальный вебхук регистрируется с заголовком X-Admin-Key
+type RegisterWebhookRequest struct {
+	// PlayerID - владелец вебхука; с токеном игрока можно не указывать
+	PlayerID uuid.UUID `json:"playerId"`
+	URL      string    `json:"url" example:"https://example.com/nails"`
+	Scope    string    `json:"scope" enums:"global,player,tournament" example:"player"`
+	// TournamentID - обязателен для вебхука турнира
+	TournamentID *uuid.UUID `json:"tournamentId,omitempty"`
+	// Events - на какие события подписаться; пусто - на все
+	Events []string `json:"events,omitempty" enums:"game.created,move.played,game.finished"`
+}
+
+// WebhookResponse represents a registered webhook
+// @Description Вебхук; ключ подписи возвращается только при регистрации
+type WebhookResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	URL          string     `json:"url" example:"https://example.com/nails"`
+	Scope        string     `json:"scope" enums:"global,player,tournament"`
+	OwnerID      *uuid.UUID `json:"ownerId,omitempty"`
+	TournamentID *uuid.UUID `json:"tournamentId,omitempty"`
+	Events       []string   `json:"events,omitempty"`
+	// Secret - ключ HMAC-SHA256 для проверки заголовка X-Nails-Signature
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookDeliveryResponse represents a delivery of an event to a webhook
+// @Description Отправка события на вебхук
+type WebhookDeliveryResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Event    string    `json:"event" example:"move.played"`
+	GameID   uuid.UUID `json:"gameId"`
+	Status   string    `json:"status" enums:"pending,delivered,dead"`
+	Attempts int       `json:"attempts" example:"8"`
+	// NextAttemptAt - время следующей попытки ожидающей доставки
+	NextAttemptAt time.Time  `json:"nextAttemptAt"`
+	LastError     string     `json:"lastError,omitempty" example:"webhook responded with status 503"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty"`
+}
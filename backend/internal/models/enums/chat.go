@@ -0,0 +1,15 @@
+package enums
+
+// ChatChannel - канал чата партии
+type ChatChannel string
+
+const (
+	// PlayersChannel - чат игроков партии; зрители видят его после окончания партии
+	PlayersChannel ChatChannel = "players"
+	// SpectatorsChannel - чат зрителей; игроки видят его после окончания партии
+	SpectatorsChannel ChatChannel = "spectators"
+)
+
+func (c ChatChannel) IsKnown() bool {
+	return c == PlayersChannel || c == SpectatorsChannel
+}
@@ -0,0 +1,21 @@
+package enums
+
+type DistanceMetric string
+
+const (
+	// ManhattanMetric - расстояние по строкам и столбцам
+	ManhattanMetric DistanceMetric = "manhattan"
+	// EuclideanMetric - расстояние по прямой
+	EuclideanMetric DistanceMetric = "euclidean"
+)
+
+var KnownDistanceMetrics = []DistanceMetric{ManhattanMetric, EuclideanMetric}
+
+func (m DistanceMetric) IsKnown() bool {
+	for _, known := range KnownDistanceMetrics {
+		if m == known {
+			return true
+		}
+	}
+	return false
+}
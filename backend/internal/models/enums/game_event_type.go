@@ -0,0 +1,26 @@
+package enums
+
+type GameEventType string
+
+const (
+	GameCreatedEvent       GameEventType = "GameCreated"
+	GameImportedEvent      GameEventType = "GameImported"
+	MovePlayedEvent        GameEventType = "MovePlayed"
+	SidesSwappedEvent      GameEventType = "SidesSwapped"
+	NailRevealedEvent      GameEventType = "NailRevealed"
+	MoveCommittedEvent     GameEventType = "MoveCommitted"
+	RoundRevealedEvent     GameEventType = "RoundRevealed"
+	ClockFlaggedEvent      GameEventType = "ClockFlagged"
+	PlayerResignedEvent    GameEventType = "PlayerResigned"
+	GameAbortedEvent       GameEventType = "GameAborted"
+	DrawOfferedEvent       GameEventType = "DrawOffered"
+	DrawAcceptedEvent      GameEventType = "DrawAccepted"
+	DrawDeclinedEvent      GameEventType = "DrawDeclined"
+	TakebackRequestedEvent GameEventType = "TakebackRequested"
+	TakebackDeclinedEvent  GameEventType = "TakebackDeclined"
+	MovesTakenBackEvent    GameEventType = "MovesTakenBack"
+	GameFinishedEvent      GameEventType = "GameFinished"
+	RematchOfferedEvent    GameEventType = "RematchOffered"
+	RematchDeclinedEvent   GameEventType = "RematchDeclined"
+	RematchAcceptedEvent   GameEventType = "RematchAccepted"
+)
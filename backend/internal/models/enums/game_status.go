@@ -7,8 +7,11 @@ const (
 	Draw
 	FirstPlayerWon
 	SecondPlayerWon
+	Aborted
+	// Finished - партия на троих и более завершена, итог в рейтинге мест
+	Finished
 )
 
 func (s GameStatus) String() string {
-	return [...]string{"CREATED", "IN_PROGRESS", "FINISHED"}[s]
+	return [...]string{"IN_PROGRESS", "DRAW", "FIRST_PLAYER_WON", "SECOND_PLAYER_WON", "ABORTED", "FINISHED"}[s]
 }
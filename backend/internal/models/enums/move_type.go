@@ -0,0 +1,10 @@
+package enums
+
+type MoveType string
+
+const (
+	// PlaceMove - обычный ход: гвоздь ставится на свободную позицию
+	PlaceMove MoveType = "place"
+	// SwapMove - смена сторон по правилу пирога вместо первого ответного хода
+	SwapMove MoveType = "swap"
+)
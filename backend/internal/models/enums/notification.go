@@ -0,0 +1,38 @@
+package enums
+
+// NotificationMode - как игрок получает письма о своём ходе в заочных партиях
+type NotificationMode string
+
+const (
+	// ImmediateNotifications - письмо о каждом ходе сразу
+	ImmediateNotifications NotificationMode = "immediate"
+	// DigestNotifications - письма о ходах собираются в одну сводку за период
+	DigestNotifications NotificationMode = "digest"
+	// NoNotifications - ни писем о ходах, ни напоминаний
+	NoNotifications NotificationMode = "off"
+)
+
+var KnownNotificationModes = []NotificationMode{
+	ImmediateNotifications,
+	DigestNotifications,
+	NoNotifications,
+}
+
+func (m NotificationMode) IsKnown() bool {
+	for _, known := range KnownNotificationModes {
+		if m == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationKind - повод уведомления
+type NotificationKind string
+
+const (
+	// TurnNotification - в партии наступил ход игрока
+	TurnNotification NotificationKind = "turn"
+	// ReminderNotification - время игрока на ход скоро истечёт
+	ReminderNotification NotificationKind = "reminder"
+)
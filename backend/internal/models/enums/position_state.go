@@ -7,3 +7,13 @@ const (
 	FirstPlayer
 	SecondPlayer
 )
+
+// Blocked - позиция, закрытая в начальной расстановке: гвоздь на неё поставить нельзя
+// и ничьей нити она не принадлежит
+const Blocked PositionState = -1
+
+// SeatState - состояние позиции, занятой игроком на месте seat (с нуля);
+// для партий на двоих совпадает с FirstPlayer и SecondPlayer
+func SeatState(seat int) PositionState {
+	return PositionState(seat + 1)
+}
@@ -0,0 +1,47 @@
+package enums
+
+// Topology - форма поля
+type Topology string
+
+const (
+	LineTopology Topology = "line"
+	// RingTopology - последняя позиция соседствует с первой
+	RingTopology Topology = "ring"
+	GridTopology Topology = "grid"
+)
+
+// TurnOrder - порядок ходов
+type TurnOrder string
+
+const (
+	SequentialTurns TurnOrder = "sequential"
+	// SimultaneousTurns - игроки тайно выбирают позиции, и гвозди раунда открываются вместе
+	SimultaneousTurns TurnOrder = "simultaneous"
+)
+
+// ScoringRule - как длина нитей определяет победителя
+type ScoringRule string
+
+const (
+	LongestThreadWins  ScoringRule = "longest_thread"
+	ShortestThreadWins ScoringRule = "shortest_thread"
+)
+
+var KnownScoringRules = []ScoringRule{LongestThreadWins, ShortestThreadWins}
+
+func (r ScoringRule) IsKnown() bool {
+	for _, known := range KnownScoringRules {
+		if r == known {
+			return true
+		}
+	}
+	return false
+}
+
+// EndCondition - когда партия заканчивается сама, без сдачи и ничьей
+type EndCondition string
+
+const (
+	// BoardFullEnd - партия заканчивается, когда на поле не осталось свободных позиций
+	BoardFullEnd EndCondition = "board_full"
+)
@@ -0,0 +1,13 @@
+package enums
+
+// Termination - причина завершения партии
+type Termination string
+
+const (
+	NoTermination        Termination = ""
+	NormalTermination    Termination = "normal"
+	TimeoutTermination   Termination = "timeout"
+	ResignTermination    Termination = "resign"
+	AbortTermination     Termination = "abort"
+	AgreementTermination Termination = "agreement"
+)
@@ -0,0 +1,30 @@
+package enums
+
+type TimeControlType string
+
+const (
+	// UnlimitedTimeControl - партия без контроля времени
+	UnlimitedTimeControl TimeControlType = "unlimited"
+	// FischerTimeControl - базовое время на партию плюс добавка за каждый ход
+	FischerTimeControl TimeControlType = "fischer"
+	// PerMoveTimeControl - фиксированное время на каждый ход
+	PerMoveTimeControl TimeControlType = "per_move"
+	// CorrespondenceTimeControl - заданное число дней на каждый ход
+	CorrespondenceTimeControl TimeControlType = "correspondence"
+)
+
+var KnownTimeControls = []TimeControlType{
+	UnlimitedTimeControl,
+	FischerTimeControl,
+	PerMoveTimeControl,
+	CorrespondenceTimeControl,
+}
+
+func (t TimeControlType) IsKnown() bool {
+	for _, known := range KnownTimeControls {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package enums
+
+// TournamentFormat - система проведения турнира
+type TournamentFormat string
+
+const (
+	// RoundRobinFormat - каждый играет с каждым
+	RoundRobinFormat TournamentFormat = "round_robin"
+	// SwissFormat - игроки с равными очками встречаются друг с другом, без повторных встреч
+	SwissFormat TournamentFormat = "swiss"
+	// SingleEliminationFormat - проигравший выбывает
+	SingleEliminationFormat TournamentFormat = "single_elimination"
+	// DoubleEliminationFormat - игрок выбывает после второго поражения
+	DoubleEliminationFormat TournamentFormat = "double_elimination"
+	// ArenaFormat - турнир на время: освободившиеся игроки сразу получают новую пару,
+	// серия побед удваивает очки
+	ArenaFormat TournamentFormat = "arena"
+)
+
+var KnownTournamentFormats = []TournamentFormat{
+	RoundRobinFormat,
+	SwissFormat,
+	SingleEliminationFormat,
+	DoubleEliminationFormat,
+	ArenaFormat,
+}
+
+// FixedFormats - системы с турами, которые продвигает планировщик турниров
+var FixedFormats = []TournamentFormat{
+	RoundRobinFormat,
+	SwissFormat,
+	SingleEliminationFormat,
+	DoubleEliminationFormat,
+}
+
+func (f TournamentFormat) IsKnown() bool {
+	for _, known := range KnownTournamentFormats {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsElimination - турнир на выбывание: ничьи переигрываются, а не засчитываются
+func (f TournamentFormat) IsElimination() bool {
+	return f == SingleEliminationFormat || f == DoubleEliminationFormat
+}
+
+// TournamentStatus - стадия турнира
+type TournamentStatus string
+
+const (
+	RegistrationTournament TournamentStatus = "registration"
+	RunningTournament      TournamentStatus = "in_progress"
+	FinishedTournament     TournamentStatus = "finished"
+)
+
+// PairingResult - итог пары тура
+type PairingResult string
+
+const (
+	// PendingResult - партия пары ещё идёт
+	PendingResult   PairingResult = ""
+	FirstPlayerWin  PairingResult = "first_won"
+	SecondPlayerWin PairingResult = "second_won"
+	DrawResult      PairingResult = "draw"
+	// ByeResult - игроку не нашлось пары, ему засчитывается победа
+	ByeResult PairingResult = "bye"
+	// AbortedResult - партия арены прервана и не приносит очков
+	AbortedResult PairingResult = "aborted"
+)
+
+// SeriesStatus - стадия серии партий
+type SeriesStatus string
+
+const (
+	// ScheduledSeries - серия ждёт времени старта первой партии
+	ScheduledSeries SeriesStatus = "scheduled"
+	RunningSeries   SeriesStatus = "in_progress"
+	FinishedSeries  SeriesStatus = "finished"
+)
@@ -0,0 +1,41 @@
+package enums
+
+type Variant string
+
+const (
+	StandardVariant Variant = "standard"
+	// PieVariant - после первого гвоздя второй игрок может поменяться сторонами
+	PieVariant Variant = "pie"
+	// CircularVariant - поле замкнуто в кольцо: последняя позиция соседствует с первой
+	CircularVariant Variant = "circular"
+	// GridVariant - двумерное поле, гвозди игрока связываются с ближайшими своими гвоздями
+	GridVariant Variant = "grid"
+	// FogVariant - игрок видит только свои гвозди и чужие, на которые наткнулся
+	FogVariant Variant = "fog"
+	// SimultaneousVariant - игроки тайно выбирают позиции, и все гвозди раунда открываются вместе
+	SimultaneousVariant Variant = "simultaneous"
+	// HandicapVariant - партия с обязательной начальной расстановкой
+	HandicapVariant Variant = "handicap"
+	// MisereVariant - побеждает самая короткая нить
+	MisereVariant Variant = "misere"
+)
+
+var KnownVariants = []Variant{
+	StandardVariant,
+	PieVariant,
+	CircularVariant,
+	GridVariant,
+	FogVariant,
+	SimultaneousVariant,
+	HandicapVariant,
+	MisereVariant,
+}
+
+func (v Variant) IsKnown() bool {
+	for _, known := range KnownVariants {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
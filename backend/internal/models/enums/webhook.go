@@ -0,0 +1,57 @@
+package enums
+
+// WebhookScope - о каких партиях получает события вебхук
+type WebhookScope string
+
+const (
+	// GlobalWebhook - все открытые партии сервера; регистрирует администратор
+	GlobalWebhook WebhookScope = "global"
+	// PlayerWebhook - партии игрока, зарегистрировавшего вебхук, включая закрытые
+	PlayerWebhook WebhookScope = "player"
+	// TournamentWebhook - открытые партии турнира; регистрирует организатор
+	TournamentWebhook WebhookScope = "tournament"
+)
+
+func (s WebhookScope) IsKnown() bool {
+	return s == GlobalWebhook || s == PlayerWebhook || s == TournamentWebhook
+}
+
+// WebhookEvent - событие, которое отправляется на вебхук. События об изменении рейтинга
+// нет: у игроков нет рейтинга, партии лишь помечаются рейтинговыми флагом rated в game.created
+type WebhookEvent string
+
+const (
+	GameCreatedWebhook  WebhookEvent = "game.created"
+	MovePlayedWebhook   WebhookEvent = "move.played"
+	GameFinishedWebhook WebhookEvent = "game.finished"
+)
+
+var KnownWebhookEvents = []WebhookEvent{
+	GameCreatedWebhook,
+	MovePlayedWebhook,
+	GameFinishedWebhook,
+}
+
+func (e WebhookEvent) IsKnown() bool {
+	for _, known := range KnownWebhookEvents {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus - состояние доставки события на вебхук
+type DeliveryStatus string
+
+const (
+	// PendingDelivery - доставка ждёт первой или повторной попытки
+	PendingDelivery DeliveryStatus = "pending"
+	DeliveredStatus DeliveryStatus = "delivered"
+	// DeadDelivery - попытки исчерпаны, доставка попала в список недоставленных
+	DeadDelivery DeliveryStatus = "dead"
+)
+
+func (s DeliveryStatus) IsKnown() bool {
+	return s == PendingDelivery || s == DeliveredStatus || s == DeadDelivery
+}
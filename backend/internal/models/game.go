@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"nails_game/internal/models/enums"
@@ -8,11 +10,250 @@ import (
 
 type Game struct {
 	gorm.Model
-	ID              uuid.UUID             `gorm:"type:uuid;primaryKey"`
-	Line            []enums.PositionState `gorm:"type:integer[]"`
+	ID   uuid.UUID             `gorm:"type:uuid;primaryKey"`
+	Line []enums.PositionState `gorm:"type:integer[]"`
+	// Coordinates - координаты позиций на нерегулярном поле, пусто для позиций 0..n-1
+	Coordinates []int `gorm:"type:integer[]"`
+	// Width - ширина двумерного поля, 0 для поля-линии
+	Width   int
+	Metric  enums.DistanceMetric
+	Variant enums.Variant
+	// Rules - правила партии, разрешённые из варианта при создании
+	Rules Rules `gorm:"serializer:json"`
+	Rated bool
+	// Private - партию не показывают в списке идущих партий и зрителям без токена
+	Private         bool
 	Status          enums.GameStatus
+	Termination     enums.Termination
 	CurrentPlayerID uuid.UUID
 	FirstPlayerID   uuid.UUID
 	SecondPlayerID  uuid.UUID
 	MoveCount       int
+	Version         int
+
+	// Schedule - сколько гвоздей ставится за ход: i-й ход партии берёт i-й элемент,
+	// последний элемент повторяется; пусто - по одному гвоздю
+	Schedule []int `gorm:"serializer:json"`
+	// TurnCount - число завершённых ходов, TurnPlacements - гвоздей, уже поставленных в текущем
+	TurnCount      int
+	TurnPlacements int
+
+	// Revealed - позиции соперников, открытые каждому месту в варианте fog
+	Revealed [][]int `gorm:"serializer:json"`
+
+	// Round - число открытых раундов в варианте simultaneous, Commits - выбранные
+	// в текущем раунде позиции по местам, NoCommit - место ещё не выбрало
+	Round   int
+	Commits []int `gorm:"serializer:json"`
+
+	// Seats - игроки в порядке ходов; первые два места совпадают с FirstPlayerID и SecondPlayerID
+	Seats []uuid.UUID `gorm:"serializer:json"`
+	// Scores - длина нитей каждого места после заполнения поля
+	Scores []float64 `gorm:"serializer:json"`
+	// Ranking - игроки от победителя к последнему месту
+	Ranking []uuid.UUID `gorm:"serializer:json"`
+
+	TimeControl         TimeControl `gorm:"embedded;embeddedPrefix:time_control_"`
+	FirstPlayerClockMs  int64
+	SecondPlayerClockMs int64
+	TurnStartedAt       *time.Time
+	TurnDeadline        *time.Time
+
+	DrawOfferedBy       *uuid.UUID `gorm:"type:uuid"`
+	TakebackRequestedBy *uuid.UUID `gorm:"type:uuid"`
+
+	// PreviousGameID - партия, реваншем которой является эта; RematchGameID - созданный реванш этой партии
+	PreviousGameID   *uuid.UUID `gorm:"type:uuid"`
+	RematchOfferedBy *uuid.UUID `gorm:"type:uuid"`
+	RematchGameID    *uuid.UUID `gorm:"type:uuid"`
+	// TournamentID - турнир, в котором играется партия
+	TournamentID *uuid.UUID `gorm:"type:uuid"`
+
+	pendingEvents []GameEvent `gorm:"-"`
+}
+
+// Raise применяет событие к партии и ставит его в очередь на сохранение
+func (g *Game) Raise(event GameEvent) {
+	event.Apply(g)
+	g.Version++
+	g.pendingEvents = append(g.pendingEvents, event)
+}
+
+func (g *Game) PendingEvents() []GameEvent {
+	return g.pendingEvents
+}
+
+func (g *Game) ClearPendingEvents() {
+	g.pendingEvents = nil
+}
+
+// Players возвращает игроков в порядке ходов
+func (g *Game) Players() []uuid.UUID {
+	if len(g.Seats) == 0 {
+		return []uuid.UUID{g.FirstPlayerID, g.SecondPlayerID}
+	}
+	return g.Seats
+}
+
+// Seat возвращает место игрока или -1, если он не участвует в партии
+func (g *Game) Seat(playerID uuid.UUID) int {
+	for i, id := range g.Players() {
+		if id == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsMultiplayer - в партии больше двух игроков
+func (g *Game) IsMultiplayer() bool {
+	return len(g.Players()) > 2
+}
+
+// NoCommit - место ещё не выбрало позицию в текущем раунде
+const NoCommit = -1
+
+// TurnsPlayed - число завершённых ходов; без расписания каждый гвоздь - отдельный ход,
+// в варианте simultaneous раунд считается ходом каждого игрока
+func (g *Game) TurnsPlayed() int {
+	switch {
+	case g.Rules.TurnOrder == enums.SimultaneousTurns:
+		return g.Round * len(g.Players())
+	case len(g.Schedule) == 0:
+		return g.MoveCount
+	default:
+		return g.TurnCount
+	}
+}
+
+// HasCommitted - игрок уже выбрал позицию в текущем раунде
+func (g *Game) HasCommitted(playerID uuid.UUID) bool {
+	seat := g.Seat(playerID)
+	return seat >= 0 && seat < len(g.Commits) && g.Commits[seat] != NoCommit
+}
+
+// Quota - сколько гвоздей ставится за ход с номером turn (с нуля)
+func (g *Game) Quota(turn int) int {
+	switch {
+	case len(g.Schedule) == 0:
+		return 1
+	case turn < len(g.Schedule):
+		return g.Schedule[turn]
+	default:
+		return g.Schedule[len(g.Schedule)-1]
+	}
+}
+
+// PlacementsLeft - сколько гвоздей игрок на ходу ещё должен поставить в текущем ходе
+func (g *Game) PlacementsLeft() int {
+	return g.Quota(g.TurnsPlayed()) - g.TurnPlacements
+}
+
+// IsHidden - расстановка соперников скрыта от игроков: идёт партия со скрытыми гвоздями
+func (g *Game) IsHidden() bool {
+	return g.Rules.HiddenNails && g.Status == enums.InProgress
+}
+
+// IsHiddenFrom - на позиции стоит гвоздь соперника, который игрок ещё не видел
+func (g *Game) IsHiddenFrom(position int, playerID uuid.UUID) bool {
+	if !g.IsHidden() {
+		return false
+	}
+	state := g.Line[position]
+	if state == enums.Empty || state == enums.Blocked || state == enums.SeatState(g.Seat(playerID)) {
+		return false
+	}
+	seat := g.Seat(playerID)
+	if seat < 0 || seat >= len(g.Revealed) {
+		return true
+	}
+	for _, revealed := range g.Revealed[seat] {
+		if revealed == position {
+			return false
+		}
+	}
+	return true
+}
+
+// LineSeenBy возвращает поле глазами игрока: скрытые от него гвозди соперников
+// показываются свободными позициями. Не участвующему в партии скрыты все гвозди
+func (g *Game) LineSeenBy(playerID uuid.UUID) []enums.PositionState {
+	if !g.IsHidden() {
+		return g.Line
+	}
+	line := make([]enums.PositionState, len(g.Line))
+	for i, state := range g.Line {
+		if !g.IsHiddenFrom(i, playerID) {
+			line[i] = state
+		}
+	}
+	return line
+}
+
+// Opponent возвращает соперника игрока
+func (g *Game) Opponent(playerID uuid.UUID) uuid.UUID {
+	if playerID == g.FirstPlayerID {
+		return g.SecondPlayerID
+	}
+	return g.FirstPlayerID
+}
+
+// Coordinate возвращает координату позиции с учётом нерегулярного поля
+func (g *Game) Coordinate(position int) int {
+	if len(g.Coordinates) == 0 {
+		return position
+	}
+	return g.Coordinates[position]
+}
+
+func (g *Game) HasPlayer(playerID uuid.UUID) bool {
+	return g.Seat(playerID) >= 0
+}
+
+// Clock - время на часах игрока на момент начала текущего хода
+func (g *Game) Clock(playerID uuid.UUID) time.Duration {
+	if playerID == g.FirstPlayerID {
+		return time.Duration(g.FirstPlayerClockMs) * time.Millisecond
+	}
+	return time.Duration(g.SecondPlayerClockMs) * time.Millisecond
+}
+
+func (g *Game) setClock(playerID uuid.UUID, remaining time.Duration) {
+	if playerID == g.FirstPlayerID {
+		g.FirstPlayerClockMs = remaining.Milliseconds()
+	} else {
+		g.SecondPlayerClockMs = remaining.Milliseconds()
+	}
+}
+
+// RemainingTime - сколько времени остаётся у игрока в момент now с учётом идущего хода
+func (g *Game) RemainingTime(playerID uuid.UUID, now time.Time) time.Duration {
+	remaining := g.Clock(playerID)
+	if g.Status == enums.InProgress && playerID == g.CurrentPlayerID && g.TurnStartedAt != nil {
+		remaining -= now.Sub(*g.TurnStartedAt)
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func (g *Game) startTurn(at time.Time) {
+	if g.TimeControl.IsUnlimited() {
+		return
+	}
+	startedAt := at
+	deadline := at.Add(g.Clock(g.CurrentPlayerID))
+	g.TurnStartedAt = &startedAt
+	g.TurnDeadline = &deadline
+}
+
+// Replay восстанавливает партию из полного потока событий
+func Replay(gameID uuid.UUID, events []GameEvent) *Game {
+	game := &Game{ID: gameID}
+	for _, event := range events {
+		event.Apply(game)
+		game.Version++
+	}
+	return game
 }
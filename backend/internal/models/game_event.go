@@ -0,0 +1,477 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models/enums"
+)
+
+// GameEvent - доменное событие партии. Текущее состояние Game является
+// проекцией потока событий: каждое событие только применяет уже принятое
+// сервисом решение и не содержит игровых правил.
+type GameEvent interface {
+	EventType() enums.GameEventType
+	Apply(game *Game)
+}
+
+// RecordedGameEvent - событие, сохранённое в потоке партии
+type RecordedGameEvent struct {
+	Version    int
+	OccurredAt time.Time
+	Event      GameEvent
+}
+
+type GameCreated struct {
+	LineSize    int                  `json:"lineSize"`
+	Coordinates []int                `json:"coordinates,omitempty"`
+	BoardPreset string               `json:"boardPreset,omitempty"`
+	BoardSeed   *int64               `json:"boardSeed,omitempty"`
+	Width       int                  `json:"width,omitempty"`
+	Metric      enums.DistanceMetric `json:"metric,omitempty"`
+	Variant     enums.Variant        `json:"variant"`
+	// Rules - пусто у партий, созданных до появления правил
+	Rules          *Rules      `json:"rules,omitempty"`
+	TimeControl    TimeControl `json:"timeControl"`
+	Rated          bool        `json:"rated,omitempty"`
+	Private        bool        `json:"private,omitempty"`
+	FirstPlayerID  uuid.UUID   `json:"firstPlayerId"`
+	SecondPlayerID uuid.UUID   `json:"secondPlayerId"`
+	// Seats - все игроки в порядке ходов, пусто у партий на двоих до появления мест
+	Seats    []uuid.UUID `json:"seats,omitempty"`
+	Schedule []int       `json:"schedule,omitempty"`
+	// StartPosition - начальная расстановка, пусто у партий с пустого поля
+	StartPosition   []enums.PositionState `json:"startPosition,omitempty"`
+	CurrentPlayerID uuid.UUID             `json:"currentPlayerId"`
+	// PreviousGameID - партия, реваншем которой является эта
+	PreviousGameID *uuid.UUID `json:"previousGameId,omitempty"`
+	// TournamentID - турнир, в котором играется партия
+	TournamentID *uuid.UUID `json:"tournamentId,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+func (e *GameCreated) EventType() enums.GameEventType { return enums.GameCreatedEvent }
+
+// GameRules - правила создаваемой партии
+func (e *GameCreated) GameRules() Rules {
+	if e.Rules == nil {
+		return LegacyRules(e.Variant)
+	}
+	return *e.Rules
+}
+
+// HasPlayer проверяет, играет ли playerID в создаваемой партии
+func (e *GameCreated) HasPlayer(playerID uuid.UUID) bool {
+	return playerID == e.FirstPlayerID || playerID == e.SecondPlayerID || slices.Contains(e.Seats, playerID)
+}
+
+func (e *GameCreated) Apply(game *Game) {
+	game.Line = make([]enums.PositionState, e.LineSize)
+	copy(game.Line, e.StartPosition)
+	game.Coordinates = e.Coordinates
+	game.Width = e.Width
+	game.Metric = e.Metric
+	game.Variant = e.Variant
+	game.Rules = e.GameRules()
+	game.Status = enums.InProgress
+	game.FirstPlayerID = e.FirstPlayerID
+	game.SecondPlayerID = e.SecondPlayerID
+	game.Seats = e.Seats
+	if len(game.Seats) == 0 {
+		game.Seats = []uuid.UUID{e.FirstPlayerID, e.SecondPlayerID}
+	}
+	game.Schedule = e.Schedule
+	game.CurrentPlayerID = e.CurrentPlayerID
+	game.MoveCount = 0
+	game.TimeControl = e.TimeControl
+	game.Rated = e.Rated
+	game.Private = e.Private
+	game.PreviousGameID = e.PreviousGameID
+	game.TournamentID = e.TournamentID
+	game.setClock(e.FirstPlayerID, e.TimeControl.InitialBudget())
+	game.setClock(e.SecondPlayerID, e.TimeControl.InitialBudget())
+	game.startTurn(e.CreatedAt)
+}
+
+// GameImported переносит в поток событий партии, созданные до event sourcing
+type GameImported struct {
+	Line            []enums.PositionState `json:"line"`
+	Status          enums.GameStatus      `json:"status"`
+	FirstPlayerID   uuid.UUID             `json:"firstPlayerId"`
+	SecondPlayerID  uuid.UUID             `json:"secondPlayerId"`
+	CurrentPlayerID uuid.UUID             `json:"currentPlayerId"`
+	MoveCount       int                   `json:"moveCount"`
+}
+
+func (e *GameImported) EventType() enums.GameEventType { return enums.GameImportedEvent }
+
+func (e *GameImported) Apply(game *Game) {
+	game.Line = append([]enums.PositionState(nil), e.Line...)
+	game.Variant = enums.StandardVariant
+	game.Rules = LegacyRules(enums.StandardVariant)
+	game.Status = e.Status
+	if e.Status != enums.InProgress {
+		game.Termination = enums.NormalTermination
+	}
+	game.FirstPlayerID = e.FirstPlayerID
+	game.SecondPlayerID = e.SecondPlayerID
+	game.Seats = []uuid.UUID{e.FirstPlayerID, e.SecondPlayerID}
+	game.CurrentPlayerID = e.CurrentPlayerID
+	game.MoveCount = e.MoveCount
+}
+
+// MovePlayed фиксирует один гвоздь; ClockMs - время на часах походившего игрока после него.
+// Если NextPlayerID совпадает с PlayerID, игрок продолжает ход по расписанию
+type MovePlayed struct {
+	PlayerID     uuid.UUID           `json:"playerId"`
+	Position     int                 `json:"position"`
+	State        enums.PositionState `json:"state"`
+	NextPlayerID uuid.UUID           `json:"nextPlayerId"`
+	ClockMs      int64               `json:"clockMs,omitempty"`
+	PlayedAt     time.Time           `json:"playedAt"`
+}
+
+func (e *MovePlayed) EventType() enums.GameEventType { return enums.MovePlayedEvent }
+
+func (e *MovePlayed) Apply(game *Game) {
+	game.Line[e.Position] = e.State
+	game.MoveCount++
+	if e.NextPlayerID == e.PlayerID {
+		game.TurnPlacements++
+	} else {
+		game.TurnCount++
+		game.TurnPlacements = 0
+	}
+	game.CurrentPlayerID = e.NextPlayerID
+	if !game.TimeControl.IsUnlimited() {
+		game.setClock(e.PlayerID, time.Duration(e.ClockMs)*time.Millisecond)
+		game.startTurn(e.PlayedAt)
+	}
+}
+
+// NailRevealed - в варианте fog игрок попытался поставить гвоздь на позицию соперника:
+// позиция открывается ему, а ход переходит к следующему игроку
+type NailRevealed struct {
+	PlayerID     uuid.UUID `json:"playerId"`
+	Position     int       `json:"position"`
+	NextPlayerID uuid.UUID `json:"nextPlayerId"`
+	ClockMs      int64     `json:"clockMs,omitempty"`
+	RevealedAt   time.Time `json:"revealedAt"`
+}
+
+func (e *NailRevealed) EventType() enums.GameEventType { return enums.NailRevealedEvent }
+
+func (e *NailRevealed) Apply(game *Game) {
+	seat := game.Seat(e.PlayerID)
+	for len(game.Revealed) <= seat {
+		game.Revealed = append(game.Revealed, nil)
+	}
+	game.Revealed[seat] = append(game.Revealed[seat], e.Position)
+	game.TurnCount++
+	game.TurnPlacements = 0
+	game.CurrentPlayerID = e.NextPlayerID
+	if !game.TimeControl.IsUnlimited() {
+		game.setClock(e.PlayerID, time.Duration(e.ClockMs)*time.Millisecond)
+		game.startTurn(e.RevealedAt)
+	}
+}
+
+// MoveCommitted - в варианте simultaneous игрок тайно выбрал позицию на текущий раунд
+type MoveCommitted struct {
+	PlayerID    uuid.UUID `json:"playerId"`
+	Round       int       `json:"round"`
+	Position    int       `json:"position"`
+	CommittedAt time.Time `json:"committedAt"`
+}
+
+func (e *MoveCommitted) EventType() enums.GameEventType { return enums.MoveCommittedEvent }
+
+func (e *MoveCommitted) Apply(game *Game) {
+	seat := game.Seat(e.PlayerID)
+	if len(game.Commits) == 0 {
+		game.Commits = make([]int, len(game.Players()))
+		for i := range game.Commits {
+			game.Commits[i] = NoCommit
+		}
+	}
+	game.Commits[seat] = e.Position
+}
+
+// RoundRevealed открывает выбор всех игроков раунда: Positions - позиции по местам,
+// Blocked - позиции, выбранные несколькими игроками; они закрываются и гвоздей не получают
+type RoundRevealed struct {
+	Round      int       `json:"round"`
+	Positions  []int     `json:"positions"`
+	Blocked    []int     `json:"blocked,omitempty"`
+	RevealedAt time.Time `json:"revealedAt"`
+}
+
+func (e *RoundRevealed) EventType() enums.GameEventType { return enums.RoundRevealedEvent }
+
+func (e *RoundRevealed) Apply(game *Game) {
+	for _, position := range e.Blocked {
+		game.Line[position] = enums.Blocked
+	}
+	for seat, position := range e.Positions {
+		if game.Line[position] == enums.Empty {
+			game.Line[position] = enums.SeatState(seat)
+			game.MoveCount++
+		}
+	}
+	game.Round++
+	game.Commits = nil
+}
+
+// SidesSwapped - второй игрок воспользовался правилом пирога: он забирает поставленный
+// соперником гвоздь и становится первым игроком, а ход переходит к сопернику
+type SidesSwapped struct {
+	PlayerID  uuid.UUID `json:"playerId"`
+	ClockMs   int64     `json:"clockMs,omitempty"`
+	SwappedAt time.Time `json:"swappedAt"`
+}
+
+func (e *SidesSwapped) EventType() enums.GameEventType { return enums.SidesSwappedEvent }
+
+func (e *SidesSwapped) Apply(game *Game) {
+	game.FirstPlayerID, game.SecondPlayerID = game.SecondPlayerID, game.FirstPlayerID
+	if len(game.Seats) == 2 {
+		game.Seats = []uuid.UUID{game.FirstPlayerID, game.SecondPlayerID}
+	}
+	game.FirstPlayerClockMs, game.SecondPlayerClockMs = game.SecondPlayerClockMs, game.FirstPlayerClockMs
+	game.MoveCount++
+	game.TurnCount++
+	game.CurrentPlayerID = game.SecondPlayerID
+	if !game.TimeControl.IsUnlimited() {
+		game.setClock(e.PlayerID, time.Duration(e.ClockMs)*time.Millisecond)
+		game.startTurn(e.SwappedAt)
+	}
+}
+
+// ClockFlagged фиксирует, что у игрока закончилось время
+type ClockFlagged struct {
+	PlayerID  uuid.UUID `json:"playerId"`
+	FlaggedAt time.Time `json:"flaggedAt"`
+}
+
+func (e *ClockFlagged) EventType() enums.GameEventType { return enums.ClockFlaggedEvent }
+
+func (e *ClockFlagged) Apply(game *Game) {
+	game.setClock(e.PlayerID, 0)
+}
+
+type PlayerResigned struct {
+	PlayerID   uuid.UUID `json:"playerId"`
+	ResignedAt time.Time `json:"resignedAt"`
+}
+
+func (e *PlayerResigned) EventType() enums.GameEventType { return enums.PlayerResignedEvent }
+
+func (e *PlayerResigned) Apply(game *Game) {}
+
+type GameAborted struct {
+	PlayerID  uuid.UUID `json:"playerId"`
+	AbortedAt time.Time `json:"abortedAt"`
+}
+
+func (e *GameAborted) EventType() enums.GameEventType { return enums.GameAbortedEvent }
+
+func (e *GameAborted) Apply(game *Game) {}
+
+type DrawOffered struct {
+	PlayerID  uuid.UUID `json:"playerId"`
+	OfferedAt time.Time `json:"offeredAt"`
+}
+
+func (e *DrawOffered) EventType() enums.GameEventType { return enums.DrawOfferedEvent }
+
+func (e *DrawOffered) Apply(game *Game) {
+	offeredBy := e.PlayerID
+	game.DrawOfferedBy = &offeredBy
+}
+
+type DrawAccepted struct {
+	PlayerID   uuid.UUID `json:"playerId"`
+	AcceptedAt time.Time `json:"acceptedAt"`
+}
+
+func (e *DrawAccepted) EventType() enums.GameEventType { return enums.DrawAcceptedEvent }
+
+func (e *DrawAccepted) Apply(game *Game) {
+	game.DrawOfferedBy = nil
+}
+
+// DrawDeclined - отказ от ничьей; Implicit означает, что соперник сделал ход вместо ответа
+type DrawDeclined struct {
+	PlayerID   uuid.UUID `json:"playerId"`
+	Implicit   bool      `json:"implicit,omitempty"`
+	DeclinedAt time.Time `json:"declinedAt"`
+}
+
+func (e *DrawDeclined) EventType() enums.GameEventType { return enums.DrawDeclinedEvent }
+
+func (e *DrawDeclined) Apply(game *Game) {
+	game.DrawOfferedBy = nil
+}
+
+type TakebackRequested struct {
+	PlayerID    uuid.UUID `json:"playerId"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+func (e *TakebackRequested) EventType() enums.GameEventType { return enums.TakebackRequestedEvent }
+
+func (e *TakebackRequested) Apply(game *Game) {
+	requestedBy := e.PlayerID
+	game.TakebackRequestedBy = &requestedBy
+}
+
+// TakebackDeclined - отказ вернуть ход; Implicit означает, что соперник сделал ход вместо ответа
+type TakebackDeclined struct {
+	PlayerID   uuid.UUID `json:"playerId"`
+	Implicit   bool      `json:"implicit,omitempty"`
+	DeclinedAt time.Time `json:"declinedAt"`
+}
+
+func (e *TakebackDeclined) EventType() enums.GameEventType { return enums.TakebackDeclinedEvent }
+
+func (e *TakebackDeclined) Apply(game *Game) {
+	game.TakebackRequestedBy = nil
+}
+
+// MovesTakenBack отменяет последние ходы с согласия соперника. Positions перечислены
+// от последнего хода к более раннему; партия возвращается в состояние "идёт"
+type MovesTakenBack struct {
+	AcceptedBy      uuid.UUID `json:"acceptedBy"`
+	Positions       []int     `json:"positions"`
+	CurrentPlayerID uuid.UUID `json:"currentPlayerId"`
+	TakenBackAt     time.Time `json:"takenBackAt"`
+}
+
+func (e *MovesTakenBack) EventType() enums.GameEventType { return enums.MovesTakenBackEvent }
+
+func (e *MovesTakenBack) Apply(game *Game) {
+	for _, position := range e.Positions {
+		game.Line[position] = enums.Empty
+	}
+	game.MoveCount -= len(e.Positions)
+	game.TurnCount -= len(e.Positions)
+	game.TurnPlacements = 0
+	game.CurrentPlayerID = e.CurrentPlayerID
+	game.Status = enums.InProgress
+	game.Termination = enums.NoTermination
+	game.Scores = nil
+	game.Ranking = nil
+	game.TakebackRequestedBy = nil
+	game.DrawOfferedBy = nil
+	game.startTurn(e.TakenBackAt)
+}
+
+type GameFinished struct {
+	Status      enums.GameStatus  `json:"status"`
+	Termination enums.Termination `json:"termination"`
+	// Scores и Ranking заполняются, когда партия закончилась заполнением поля
+	Scores  []float64   `json:"scores,omitempty"`
+	Ranking []uuid.UUID `json:"ranking,omitempty"`
+}
+
+func (e *GameFinished) EventType() enums.GameEventType { return enums.GameFinishedEvent }
+
+func (e *GameFinished) Apply(game *Game) {
+	game.Status = e.Status
+	game.Termination = e.Termination
+	game.Scores = e.Scores
+	game.Ranking = e.Ranking
+	game.TurnDeadline = nil
+	game.DrawOfferedBy = nil
+	game.TakebackRequestedBy = nil
+}
+
+type RematchOffered struct {
+	PlayerID  uuid.UUID `json:"playerId"`
+	OfferedAt time.Time `json:"offeredAt"`
+}
+
+func (e *RematchOffered) EventType() enums.GameEventType { return enums.RematchOfferedEvent }
+
+func (e *RematchOffered) Apply(game *Game) {
+	offeredBy := e.PlayerID
+	game.RematchOfferedBy = &offeredBy
+}
+
+type RematchDeclined struct {
+	PlayerID   uuid.UUID `json:"playerId"`
+	DeclinedAt time.Time `json:"declinedAt"`
+}
+
+func (e *RematchDeclined) EventType() enums.GameEventType { return enums.RematchDeclinedEvent }
+
+func (e *RematchDeclined) Apply(game *Game) {
+	game.RematchOfferedBy = nil
+}
+
+// RematchAccepted связывает партию с её реваншем GameID; PlayerID - uuid.Nil,
+// если следующую партию создала серия, а не согласие игрока
+type RematchAccepted struct {
+	PlayerID   uuid.UUID `json:"playerId"`
+	GameID     uuid.UUID `json:"gameId"`
+	AcceptedAt time.Time `json:"acceptedAt"`
+}
+
+func (e *RematchAccepted) EventType() enums.GameEventType { return enums.RematchAcceptedEvent }
+
+func (e *RematchAccepted) Apply(game *Game) {
+	rematchID := e.GameID
+	game.RematchGameID = &rematchID
+	game.RematchOfferedBy = nil
+}
+
+// NewGameEvent возвращает пустое событие указанного типа для десериализации
+func NewGameEvent(eventType enums.GameEventType) (GameEvent, error) {
+	switch eventType {
+	case enums.GameCreatedEvent:
+		return &GameCreated{}, nil
+	case enums.GameImportedEvent:
+		return &GameImported{}, nil
+	case enums.MovePlayedEvent:
+		return &MovePlayed{}, nil
+	case enums.SidesSwappedEvent:
+		return &SidesSwapped{}, nil
+	case enums.NailRevealedEvent:
+		return &NailRevealed{}, nil
+	case enums.MoveCommittedEvent:
+		return &MoveCommitted{}, nil
+	case enums.RoundRevealedEvent:
+		return &RoundRevealed{}, nil
+	case enums.ClockFlaggedEvent:
+		return &ClockFlagged{}, nil
+	case enums.PlayerResignedEvent:
+		return &PlayerResigned{}, nil
+	case enums.GameAbortedEvent:
+		return &GameAborted{}, nil
+	case enums.DrawOfferedEvent:
+		return &DrawOffered{}, nil
+	case enums.DrawAcceptedEvent:
+		return &DrawAccepted{}, nil
+	case enums.DrawDeclinedEvent:
+		return &DrawDeclined{}, nil
+	case enums.TakebackRequestedEvent:
+		return &TakebackRequested{}, nil
+	case enums.TakebackDeclinedEvent:
+		return &TakebackDeclined{}, nil
+	case enums.MovesTakenBackEvent:
+		return &MovesTakenBack{}, nil
+	case enums.GameFinishedEvent:
+		return &GameFinished{}, nil
+	case enums.RematchOfferedEvent:
+		return &RematchOffered{}, nil
+	case enums.RematchDeclinedEvent:
+		return &RematchDeclined{}, nil
+	case enums.RematchAcceptedEvent:
+		return &RematchAccepted{}, nil
+	default:
+		return nil, fmt.Errorf("unknown game event type: %s", eventType)
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/google/uuid"
+
+	"nails_game/internal/models/enums"
+)
+
+// GameSettings - параметры партии, запрошенные при её создании
+type GameSettings struct {
+	LineSize    int
+	Variant     enums.Variant
+	TimeControl TimeControl
+	Rated       bool
+	// Private - партия не попадает в список идущих партий и не видна зрителям без токена
+	Private bool
+	Board   BoardLayout
+	// Grid - размеры и метрика поля для варианта grid
+	Grid Grid
+	// Schedule - число гвоздей за ход, см. Game.Schedule
+	Schedule []int
+	// StartPosition - начальная расстановка поля: гвозди игроков и закрытые позиции
+	StartPosition []enums.PositionState
+	// Scoring - правило подсчёта вместо правила варианта
+	Scoring enums.ScoringRule
+	// Parameters - параметры варианта в том виде, в каком пришли в запросе
+	Parameters map[string]any
+	// Rules - правила, разрешённые из варианта и параметров
+	Rules Rules
+	// TournamentID - турнир, для которого создаётся партия; задаёт сервис турниров
+	TournamentID *uuid.UUID
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"math"
+
+	"nails_game/internal/models/enums"
+)
+
+// Cell - клетка двумерного поля
+type Cell struct {
+	Row int
+	Col int
+}
+
+// Grid - геометрия двумерного поля. Клетки хранятся в Game.Line построчно,
+// позиция клетки (row, col) равна row*Width + col
+type Grid struct {
+	Width  int
+	Height int
+	Metric enums.DistanceMetric
+}
+
+// Grid возвращает геометрию поля, если партия играется на двумерном поле
+func (g *Game) Grid() (Grid, bool) {
+	if g.Width == 0 {
+		return Grid{}, false
+	}
+	return Grid{Width: g.Width, Height: len(g.Line) / g.Width, Metric: g.Metric}, true
+}
+
+func (g Grid) Size() int {
+	return g.Width * g.Height
+}
+
+func (g Grid) Cell(position int) Cell {
+	return Cell{Row: position / g.Width, Col: position % g.Width}
+}
+
+// Position возвращает позицию клетки; false, если клетка вне поля
+func (g Grid) Position(cell Cell) (int, bool) {
+	if cell.Row < 0 || cell.Row >= g.Height || cell.Col < 0 || cell.Col >= g.Width {
+		return 0, false
+	}
+	return cell.Row*g.Width + cell.Col, true
+}
+
+// Distance - расстояние между двумя позициями в выбранной метрике
+func (g Grid) Distance(a, b int) float64 {
+	ca, cb := g.Cell(a), g.Cell(b)
+	dr := math.Abs(float64(ca.Row - cb.Row))
+	dc := math.Abs(float64(ca.Col - cb.Col))
+	if g.Metric == enums.EuclideanMetric {
+		return math.Hypot(dr, dc)
+	}
+	return dr + dc
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/google/uuid"
+
+	"nails_game/internal/models/enums"
+)
+
+// LiveGameFilter - условия отбора идущих партий; пустое поле не ограничивает выборку.
+// Рейтинга у игроков нет, поэтому вместо диапазона рейтинга отбор идёт по флагу Rated
+type LiveGameFilter struct {
+	Variant      enums.Variant
+	Rated        *bool
+	TournamentID *uuid.UUID
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"nails_game/internal/models/enums"
 )
 
 type Move struct {
@@ -11,4 +13,7 @@ type Move struct {
 	GameID   uuid.UUID
 	PlayerID uuid.UUID
 	Position int
+	Type     enums.MoveType
+	// Cell - клетка хода на двумерном поле, заменяет Position
+	Cell *Cell `gorm:"-"`
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"nails_game/internal/models/enums"
+)
+
+// NotificationPreferences - какие письма о заочных партиях получает игрок
+type NotificationPreferences struct {
+	Mode enums.NotificationMode `gorm:"default:immediate"`
+	// NoReminders - не напоминать, что время на ход истекает
+	NoReminders bool
+}
+
+// Notification - уведомление игрока о ходе в партии; хранится, чтобы не отправлять
+// его дважды и собирать сводки
+type Notification struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	PlayerID uuid.UUID `gorm:"type:uuid"`
+	GameID   uuid.UUID `gorm:"type:uuid"`
+	Kind     enums.NotificationKind
+	// TurnStartedAt - начало хода, к которому относится уведомление
+	TurnStartedAt time.Time
+	Deadline      *time.Time
+	CreatedAt     time.Time
+	// SentAt пусто, пока уведомление ждёт отправки или сводки
+	SentAt *time.Time
+}
@@ -11,5 +11,7 @@ type Player struct {
 	Name         string
 	Email        string `gorm:"unique"`
 	PasswordHash string
-	Games        []*Game `gorm:"many2many:player_games;"`
+	// Notifications - настройки писем о заочных партиях
+	Notifications NotificationPreferences `gorm:"embedded;embeddedPrefix:notify_"`
+	Games         []*Game                 `gorm:"many2many:player_games;"`
 }
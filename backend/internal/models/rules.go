@@ -0,0 +1,45 @@
+package models
+
+import "nails_game/internal/models/enums"
+
+// Rules - правила партии, зафиксированные при её создании: изменение описания
+// варианта на сервере не меняет правил уже начатых партий
+type Rules struct {
+	Topology     enums.Topology     `json:"topology"`
+	TurnOrder    enums.TurnOrder    `json:"turnOrder"`
+	Scoring      enums.ScoringRule  `json:"scoring"`
+	EndCondition enums.EndCondition `json:"endCondition"`
+	// PieRule - второй игрок может первым ходом поменяться сторонами
+	PieRule bool `json:"pieRule,omitempty"`
+	// HiddenNails - игроки не видят гвоздей соперников, пока не наткнутся на них
+	HiddenNails bool `json:"hiddenNails,omitempty"`
+}
+
+// IsZero - правила не записаны: партия создана до их появления
+func (r Rules) IsZero() bool {
+	return r == Rules{}
+}
+
+// LegacyRules восстанавливает правила партий, созданных до появления Rules, по их варианту.
+// Функция описывает прошлое поведение сервера и не должна меняться вместе с вариантами
+func LegacyRules(variant enums.Variant) Rules {
+	rules := Rules{
+		Topology:     enums.LineTopology,
+		TurnOrder:    enums.SequentialTurns,
+		Scoring:      enums.LongestThreadWins,
+		EndCondition: enums.BoardFullEnd,
+	}
+	switch variant {
+	case enums.PieVariant:
+		rules.PieRule = true
+	case enums.CircularVariant:
+		rules.Topology = enums.RingTopology
+	case enums.GridVariant:
+		rules.Topology = enums.GridTopology
+	case enums.FogVariant:
+		rules.HiddenNails = true
+	case enums.SimultaneousVariant:
+		rules.TurnOrder = enums.SimultaneousTurns
+	}
+	return rules
+}
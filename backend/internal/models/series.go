@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"nails_game/internal/models/enums"
+)
+
+// SeriesSettings - параметры серии партий, запрошенные игроками
+type SeriesSettings struct {
+	FirstPlayerID  uuid.UUID
+	SecondPlayerID uuid.UUID
+	// BestOf - наибольшее число засчитываемых партий; серию выигрывает набравший больше половины очков
+	BestOf int
+	// StartsAt - время начала первой партии; без него серия начинается сразу
+	StartsAt *time.Time
+	Game     GameSettings
+}
+
+// Series - серия связанных партий двух игроков: каждая следующая партия - реванш
+// предыдущей со сменой цвета
+type Series struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	FirstPlayerID  uuid.UUID `gorm:"type:uuid"`
+	SecondPlayerID uuid.UUID `gorm:"type:uuid"`
+	BestOf         int
+	Status         enums.SeriesStatus
+	// GameSettings - параметры первой партии, остальные повторяют её правила
+	GameSettings GameSettings `gorm:"serializer:json"`
+	StartsAt     *time.Time
+	// WinnerID пуст, пока серия идёт, и после ничейного счёта
+	WinnerID   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+
+	Games []SeriesGame `gorm:"foreignKey:SeriesID"`
+}
+
+// SeriesGame - партия серии; прерванная партия переигрывается и не приносит очков,
+// а после трёх переигровок подряд серия заканчивается с текущим счётом
+type SeriesGame struct {
+	SeriesID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Number   int       `gorm:"primaryKey"`
+	GameID   uuid.UUID `gorm:"type:uuid"`
+	// FirstPlayerID - игрок, который ходит в партии первым
+	FirstPlayerID uuid.UUID `gorm:"type:uuid"`
+	Result        enums.PairingResult
+	FinishedAt    *time.Time
+}
+
+// Score возвращает очки игрока в серии: 1 за победу, 0.5 за ничью
+func (s *Series) Score(playerID uuid.UUID) float64 {
+	score := 0.0
+	for _, game := range s.Games {
+		score += game.Score(playerID)
+	}
+	return score
+}
+
+// Played возвращает число засчитанных партий серии
+func (s *Series) Played() int {
+	played := 0
+	for _, game := range s.Games {
+		if game.Counts() {
+			played++
+		}
+	}
+	return played
+}
+
+// LastGame возвращает последнюю партию серии или nil, если серия не началась
+func (s *Series) LastGame() *SeriesGame {
+	if len(s.Games) == 0 {
+		return nil
+	}
+	return &s.Games[len(s.Games)-1]
+}
+
+// Counts - партия сыграна и засчитывается в счёт серии
+func (g *SeriesGame) Counts() bool {
+	return g.Result != enums.PendingResult && g.Result != enums.AbortedResult
+}
+
+func (g *SeriesGame) Score(playerID uuid.UUID) float64 {
+	switch g.Result {
+	case enums.DrawResult:
+		return 0.5
+	case enums.FirstPlayerWin:
+		if g.FirstPlayerID == playerID {
+			return 1
+		}
+	case enums.SecondPlayerWin:
+		if g.FirstPlayerID != playerID {
+			return 1
+		}
+	}
+	return 0
+}
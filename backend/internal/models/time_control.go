@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"nails_game/internal/models/enums"
+)
+
+type TimeControl struct {
+	Type             enums.TimeControlType `json:"type"`
+	BaseSeconds      int                   `json:"baseSeconds"`
+	IncrementSeconds int                   `json:"incrementSeconds"`
+	MoveSeconds      int                   `json:"moveSeconds"`
+	DaysPerMove      int                   `json:"daysPerMove"`
+}
+
+func (tc TimeControl) IsUnlimited() bool {
+	return tc.Type == "" || tc.Type == enums.UnlimitedTimeControl
+}
+
+// InitialBudget - время на часах каждого игрока в начале партии
+func (tc TimeControl) InitialBudget() time.Duration {
+	switch tc.Type {
+	case enums.FischerTimeControl:
+		return time.Duration(tc.BaseSeconds) * time.Second
+	case enums.PerMoveTimeControl:
+		return time.Duration(tc.MoveSeconds) * time.Second
+	case enums.CorrespondenceTimeControl:
+		return time.Duration(tc.DaysPerMove) * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// AfterMove - время на часах игрока после хода, если до хода оставалось remaining
+func (tc TimeControl) AfterMove(remaining time.Duration) time.Duration {
+	switch tc.Type {
+	case enums.FischerTimeControl:
+		return remaining + time.Duration(tc.IncrementSeconds)*time.Second
+	case enums.PerMoveTimeControl, enums.CorrespondenceTimeControl:
+		return tc.InitialBudget()
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"nails_game/internal/models/enums"
+)
+
+// TournamentSettings - параметры турнира, запрошенные организатором
+type TournamentSettings struct {
+	Name   string
+	Format enums.TournamentFormat
+	// Rounds - число туров швейцарской системы; для остальных систем вычисляется
+	Rounds int
+	// StartsAt - время автоматического старта; без него турнир запускает организатор
+	StartsAt *time.Time
+	// Duration - продолжительность арены
+	Duration time.Duration
+	// Game - параметры партий турнира
+	Game GameSettings
+}
+
+type Tournament struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name        string
+	Format      enums.TournamentFormat
+	Status      enums.TournamentStatus
+	OrganizerID uuid.UUID `gorm:"type:uuid"`
+	// GameSettings - проверенные параметры, с которыми создаются все партии турнира
+	GameSettings GameSettings `gorm:"serializer:json"`
+	// Rounds - число туров; 0 в турнире на выбывание - до одного оставшегося игрока
+	Rounds       int
+	CurrentRound int
+	StartsAt     *time.Time
+	// Duration и EndsAt - продолжительность арены и время её окончания после старта
+	Duration   time.Duration
+	EndsAt     *time.Time
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+
+	Players  []TournamentPlayer  `gorm:"foreignKey:TournamentID"`
+	Pairings []TournamentPairing `gorm:"foreignKey:TournamentID"`
+}
+
+// TournamentPlayer - участник турнира; Seed - номер по порядку регистрации
+type TournamentPlayer struct {
+	TournamentID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	PlayerID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Seed         int
+	RegisteredAt time.Time
+	// Paused - игрок арены покинул турнир и не получает новых пар
+	Paused bool
+}
+
+// TournamentPairing - пара тура; без второго игрока первому засчитывается победа без игры
+type TournamentPairing struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TournamentID   uuid.UUID `gorm:"type:uuid"`
+	Round          int
+	Board          int
+	FirstPlayerID  uuid.UUID  `gorm:"type:uuid"`
+	SecondPlayerID *uuid.UUID `gorm:"type:uuid"`
+	GameID         *uuid.UUID `gorm:"type:uuid"`
+	Result         enums.PairingResult
+	// Replays - сколько раз партия переиграна после прерывания или ничьей на выбывание
+	Replays int
+	// FinishedAt - когда результат партии записан в турнир
+	FinishedAt *time.Time
+}
+
+// Standing - строка турнирной таблицы
+type Standing struct {
+	PlayerID uuid.UUID
+	Seed     int
+	Points   float64
+	Wins     int
+	Draws    int
+	Losses   int
+	// Buchholz - сумма очков соперников, SonnebornBerger - сумма очков побеждённых
+	// соперников и половины очков соперников, с которыми сыграна ничья
+	Buchholz        float64
+	SonnebornBerger float64
+	// Eliminated - игрок выбыл из турнира на выбывание
+	Eliminated bool
+	// OnFire - игрок арены выиграл две партии подряд, и его очки удваиваются
+	OnFire bool
+}
+
+func (t *Tournament) HasPlayer(playerID uuid.UUID) bool {
+	return t.Player(playerID) != nil
+}
+
+// Player возвращает участника турнира или nil
+func (t *Tournament) Player(playerID uuid.UUID) *TournamentPlayer {
+	for i := range t.Players {
+		if t.Players[i].PlayerID == playerID {
+			return &t.Players[i]
+		}
+	}
+	return nil
+}
+
+// RoundPairings возвращает пары указанного тура
+func (t *Tournament) RoundPairings(round int) []*TournamentPairing {
+	var pairings []*TournamentPairing
+	for i := range t.Pairings {
+		if t.Pairings[i].Round == round {
+			pairings = append(pairings, &t.Pairings[i])
+		}
+	}
+	return pairings
+}
+
+// Opponent возвращает соперника игрока в паре или uuid.Nil, если пары нет
+func (p *TournamentPairing) Opponent(playerID uuid.UUID) uuid.UUID {
+	switch {
+	case p.SecondPlayerID == nil:
+		return uuid.Nil
+	case p.FirstPlayerID == playerID:
+		return *p.SecondPlayerID
+	case *p.SecondPlayerID == playerID:
+		return p.FirstPlayerID
+	}
+	return uuid.Nil
+}
+
+// IsBye - игрок пары не получил соперника
+func (p *TournamentPairing) IsBye() bool {
+	return p.SecondPlayerID == nil
+}
+
+// Score возвращает очки игрока за пару: 1 за победу и пропуск тура, 0.5 за ничью
+func (p *TournamentPairing) Score(playerID uuid.UUID) float64 {
+	switch p.Result {
+	case enums.ByeResult:
+		return 1
+	case enums.DrawResult:
+		return 0.5
+	case enums.FirstPlayerWin:
+		if p.FirstPlayerID == playerID {
+			return 1
+		}
+	case enums.SecondPlayerWin:
+		if p.FirstPlayerID != playerID {
+			return 1
+		}
+	}
+	return 0
+}
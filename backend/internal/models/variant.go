@@ -0,0 +1,24 @@
+package models
+
+import "nails_game/internal/models/enums"
+
+// VariantDefinition - описание именованного варианта игры: правила по умолчанию
+// и параметры, которые можно задать при создании партии
+type VariantDefinition struct {
+	Name        enums.Variant
+	Description string
+	Rules       Rules
+	// Schedule - расписание гвоздей за ход по умолчанию, пусто - по одному гвоздю
+	Schedule   []int
+	Parameters []VariantParameter
+}
+
+// VariantParameter - параметр варианта в схеме запроса на создание партии
+type VariantParameter struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	// Values - допустимые значения строкового параметра
+	Values []string
+}
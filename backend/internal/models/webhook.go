@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"nails_game/internal/models/enums"
+)
+
+// WebhookSettings - параметры вебхука, запрошенные при регистрации
+type WebhookSettings struct {
+	URL   string
+	Scope enums.WebhookScope
+	// TournamentID задаётся только для вебхука турнира
+	TournamentID *uuid.UUID
+	// Events - на какие события подписан вебхук; пусто - на все
+	Events []enums.WebhookEvent
+}
+
+type Webhook struct {
+	ID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	URL   string
+	Scope enums.WebhookScope
+	// Secret - ключ подписи тела запроса HMAC-SHA256
+	Secret string
+	// OwnerID - игрок, зарегистрировавший вебхук; пусто у глобальных вебхуков
+	OwnerID      *uuid.UUID           `gorm:"type:uuid"`
+	TournamentID *uuid.UUID           `gorm:"type:uuid"`
+	Events       []enums.WebhookEvent `gorm:"serializer:json"`
+	CreatedAt    time.Time
+}
+
+// WebhookDelivery - отправка одного события на вебхук; после исчерпания попыток
+// остаётся в списке недоставленных, пока её не отправят повторно
+type WebhookDelivery struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	WebhookID uuid.UUID `gorm:"type:uuid"`
+	Webhook   *Webhook  `gorm:"foreignKey:WebhookID"`
+	GameID    uuid.UUID `gorm:"type:uuid"`
+	Event     enums.WebhookEvent
+	// Payload - подписываемое тело запроса
+	Payload       []byte `gorm:"type:jsonb"`
+	Status        enums.DeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+// WebhookPayload - тело запроса на вебхук
+type WebhookPayload struct {
+	// ID - идентификатор события, одинаковый для всех вебхуков; по нему отбрасываются повторы
+	ID         uuid.UUID          `json:"id"`
+	Event      enums.WebhookEvent `json:"event"`
+	GameID     uuid.UUID          `json:"gameId"`
+	OccurredAt time.Time          `json:"occurredAt"`
+	Data       interface{}        `json:"data"`
+}
+
+type GameCreatedData struct {
+	Variant   enums.Variant `json:"variant"`
+	Rated     bool          `json:"rated"`
+	PlayerIDs []uuid.UUID   `json:"playerIds"`
+	// PreviousGameID - партия, реваншем которой является эта
+	PreviousGameID *uuid.UUID `json:"previousGameId,omitempty"`
+}
+
+// MovePlayedData - ход игрока; в партиях со скрытыми гвоздями позиция не раскрывается до конца партии
+type MovePlayedData struct {
+	PlayerID uuid.UUID `json:"playerId"`
+	Position *int      `json:"position,omitempty"`
+	// Round - раунд партии с одновременными ходами
+	Round        int        `json:"round,omitempty"`
+	NextPlayerID *uuid.UUID `json:"nextPlayerId,omitempty"`
+}
+
+type GameFinishedData struct {
+	Status      string            `json:"status"`
+	Termination enums.Termination `json:"termination"`
+	// WinnerID пуст при ничьей, прерванной партии и в партиях на троих и более
+	WinnerID *uuid.UUID  `json:"winnerId,omitempty"`
+	Ranking  []uuid.UUID `json:"ranking,omitempty"`
+	Scores   []float64   `json:"scores,omitempty"`
+}
+
+// Subscribed - вебхук получает события этого вида
+func (w *Webhook) Subscribed(event enums.WebhookEvent) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
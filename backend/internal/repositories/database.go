@@ -3,60 +3,45 @@ package repositories
 import (
 	"fmt"
 	"github.com/sirupsen/logrus"
-	"os"
-	"strconv"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
-	"nails_game/internal/models"
-	"nails_game/internal/models/dtos"
+	"nails_game/internal/config"
+	"nails_game/internal/repositories/migrations"
 )
 
-func InitDB(logger *logrus.Logger) (*gorm.DB, *dtos.Config, error) {
-	cfg, err := loadConfig()
+func InitDB(logger *logrus.Logger, cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := Connect(cfg)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, err
 	}
 
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
-		cfg.Host, cfg.User, cfg.Password, cfg.DBName, cfg.Port)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	migrator, err := migrations.NewMigrator(db)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
 	}
 
-	if err := db.AutoMigrate(
-		&models.Player{},
-		&models.Game{},
-		&models.Move{},
-	); err != nil {
-		return nil, nil, fmt.Errorf("failed to auto-migrate: %w", err)
+	applied, err := migrator.Up()
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
+	logger.WithField("applied", applied).Info("Database migrations applied")
 
-	if err := SeedDatabase(db, "seed_players.json"); err != nil {
-		logger.WithError(err).Warn("Database seeding failed - continuing without seed data")
+	if cfg.SeedPlayers {
+		if err := SeedDatabase(db, "seed_players.json"); err != nil {
+			logger.WithError(err).Warn("Database seeding failed - continuing without seed data")
+		}
 	}
 
-	return db, cfg, nil
+	return db, nil
 }
 
-func loadConfig() (*dtos.Config, error) {
-	lineSize, err := strconv.Atoi(os.Getenv("LINE_SIZE"))
+func Connect(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
 	if err != nil {
-		return nil, fmt.Errorf("invalid LINE_SIZE value: %v", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	return &dtos.Config{
-		DatabaseConfig: dtos.DatabaseConfig{
-			Host:     os.Getenv("POSTGRES_HOST"),
-			Port:     os.Getenv("POSTGRES_PORT"),
-			User:     os.Getenv("POSTGRES_USER"),
-			Password: os.Getenv("POSTGRES_PASSWORD"),
-		},
-		GameSettings: dtos.GameSettings{
-			LineSize: lineSize,
-		},
-	}, nil
+	return db, nil
 }
@@ -0,0 +1,41 @@
+package implementation
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"nails_game/internal/models"
+	"nails_game/internal/repositories/interfaces"
+)
+
+type boardPresetRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardPresetRepository(db *gorm.DB) interfaces.BoardPresetRepository {
+	return &boardPresetRepository{db: db}
+}
+
+func (r *boardPresetRepository) Create(preset *models.BoardPreset) error {
+	return r.db.Create(preset).Error
+}
+
+func (r *boardPresetRepository) GetByName(name string) (*models.BoardPreset, error) {
+	var preset models.BoardPreset
+	if err := r.db.First(&preset, "name = ?", name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrBoardPresetNotFound
+		}
+		return nil, err
+	}
+	return &preset, nil
+}
+
+func (r *boardPresetRepository) List() ([]models.BoardPreset, error) {
+	var presets []models.BoardPreset
+	if err := r.db.Order("name").Find(&presets).Error; err != nil {
+		return nil, err
+	}
+	return presets, nil
+}
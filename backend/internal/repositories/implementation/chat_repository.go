@@ -0,0 +1,44 @@
+package implementation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"nails_game/internal/models"
+	"nails_game/internal/repositories/interfaces"
+)
+
+type chatRepository struct {
+	db *gorm.DB
+}
+
+func NewChatRepository(db *gorm.DB) interfaces.ChatRepository {
+	return &chatRepository{db: db}
+}
+
+func (r *chatRepository) Create(message *models.ChatMessage) error {
+	return r.db.Create(message).Error
+}
+
+func (r *chatRepository) ListByGame(gameID uuid.UUID) ([]models.ChatMessage, error) {
+	var messages []models.ChatMessage
+	err := r.db.Where("game_id = ?", gameID).Order("created_at").Find(&messages).Error
+	return messages, err
+}
+
+func (r *chatRepository) ListMutes(gameID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&models.ChatMute{}).Where("game_id = ?", gameID).Pluck("player_id", &ids).Error
+	return ids, err
+}
+
+func (r *chatRepository) SetMuted(gameID, playerID uuid.UUID, muted bool) error {
+	if !muted {
+		return r.db.Delete(&models.ChatMute{}, "game_id = ? AND player_id = ?", gameID, playerID).Error
+	}
+	mute := &models.ChatMute{GameID: gameID, PlayerID: playerID, CreatedAt: time.Now().UTC()}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(mute).Error
+}
@@ -1,13 +1,54 @@
 package implementation
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
 	"nails_game/internal/repositories/interfaces"
 )
 
+// snapshotInterval - через сколько событий сохраняется новый снимок партии
+const snapshotInterval = 20
+
+// expiredBatchSize ограничивает число партий, обрабатываемых планировщиком за один проход
+const expiredBatchSize = 100
+
+// liveGamesLimit ограничивает список идущих партий
+const liveGamesLimit = 100
+
+type gameEventRecord struct {
+	ID         uint `gorm:"primaryKey"`
+	GameID     uuid.UUID
+	Version    int
+	Type       enums.GameEventType
+	Payload    []byte `gorm:"type:jsonb"`
+	OccurredAt time.Time
+}
+
+func (gameEventRecord) TableName() string {
+	return "game_events"
+}
+
+type gameSnapshotRecord struct {
+	GameID    uuid.UUID `gorm:"primaryKey"`
+	Version   int
+	State     []byte `gorm:"type:jsonb"`
+	CreatedAt time.Time
+}
+
+func (gameSnapshotRecord) TableName() string {
+	return "game_snapshots"
+}
+
+// gameRepository хранит партии как поток событий; строка в таблице games
+// является проекцией для запросов и перестраивается из событий
 type gameRepository struct {
 	db *gorm.DB
 }
@@ -16,21 +57,238 @@ func NewGameRepository(db *gorm.DB) interfaces.GameRepository {
 	return &gameRepository{db: db}
 }
 
-func (r *gameRepository) Create(game *models.Game) error {
-	return r.db.Create(game).Error
+func (r *gameRepository) Create(game *models.Game, deliveries ...models.WebhookDelivery) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.appendEvents(tx, game); err != nil {
+			return err
+		}
+		if err := tx.Create(game).Error; err != nil {
+			return err
+		}
+		if err := addDeliveries(tx, deliveries); err != nil {
+			return err
+		}
+		game.ClearPendingEvents()
+		return nil
+	})
 }
 
 func (r *gameRepository) GetByID(id uuid.UUID) (*models.Game, error) {
-	var game models.Game
-	if err := r.db.First(&game, "id = ?", id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("game not found")
+	game := &models.Game{ID: id}
+
+	var snapshot gameSnapshotRecord
+	err := r.db.First(&snapshot, "game_id = ?", id).Error
+	hasSnapshot := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if hasSnapshot {
+		if err := json.Unmarshal(snapshot.State, game); err != nil {
+			return nil, fmt.Errorf("failed to decode game snapshot: %w", err)
+		}
+		game.Version = snapshot.Version
+		if game.Rules.IsZero() {
+			game.Rules = models.LegacyRules(game.Variant)
 		}
+	}
+
+	events, err := r.loadEvents(r.db, id, game.Version)
+	if err != nil {
 		return nil, err
 	}
-	return &game, nil
+	if !hasSnapshot && len(events) == 0 {
+		return nil, interfaces.ErrGameNotFound
+	}
+
+	for _, recorded := range events {
+		recorded.Event.Apply(game)
+		game.Version = recorded.Version
+	}
+
+	return game, nil
+}
+
+func (r *gameRepository) Update(game *models.Game, deliveries ...models.WebhookDelivery) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.appendEvents(tx, game); err != nil {
+			return err
+		}
+		if err := tx.Omit("CreatedAt").Save(game).Error; err != nil {
+			return err
+		}
+		if err := addDeliveries(tx, deliveries); err != nil {
+			return err
+		}
+		if crossedSnapshotInterval(game) {
+			if err := r.saveSnapshot(tx, game); err != nil {
+				return err
+			}
+		}
+		game.ClearPendingEvents()
+		return nil
+	})
+}
+
+func (r *gameRepository) GetEvents(id uuid.UUID) ([]models.RecordedGameEvent, error) {
+	events, err := r.loadEvents(r.db, id, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, interfaces.ErrGameNotFound
+	}
+	return events, nil
+}
+
+// Rebuild заново проигрывает весь поток событий, игнорируя снимки,
+// и перезаписывает проекцию и снимок партии
+func (r *gameRepository) Rebuild(id uuid.UUID) (*models.Game, error) {
+	var game *models.Game
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		recorded, err := r.loadEvents(tx, id, 0)
+		if err != nil {
+			return err
+		}
+		if len(recorded) == 0 {
+			return interfaces.ErrGameNotFound
+		}
+
+		events := make([]models.GameEvent, 0, len(recorded))
+		for _, e := range recorded {
+			events = append(events, e.Event)
+		}
+		game = models.Replay(id, events)
+
+		if err := tx.Omit("CreatedAt").Save(game).Error; err != nil {
+			return err
+		}
+		return r.saveSnapshot(tx, game)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// ListExpired возвращает партии, у игрока на ходу в которых истекло время
+func (r *gameRepository) ListExpired(now time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&models.Game{}).
+		Where("status = ? AND turn_deadline <= ?", enums.InProgress, now).
+		Order("turn_deadline").
+		Limit(expiredBatchSize).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *gameRepository) ListLive(filter models.LiveGameFilter) ([]models.Game, error) {
+	query := r.db.Where("status = ? AND NOT private", enums.InProgress)
+	if filter.Variant != "" {
+		query = query.Where("variant = ?", filter.Variant)
+	}
+	if filter.Rated != nil {
+		query = query.Where("rated = ?", *filter.Rated)
+	}
+	if filter.TournamentID != nil {
+		query = query.Where("tournament_id = ?", *filter.TournamentID)
+	}
+
+	var games []models.Game
+	err := query.Order("created_at DESC").Limit(liveGamesLimit).Find(&games).Error
+	return games, err
+}
+
+func (r *gameRepository) ListInProgress(timeControl enums.TimeControlType) ([]models.Game, error) {
+	var games []models.Game
+	err := r.db.Where("status = ? AND time_control_type = ?", enums.InProgress, timeControl).
+		Order("turn_deadline").
+		Find(&games).Error
+	return games, err
+}
+
+func (r *gameRepository) appendEvents(tx *gorm.DB, game *models.Game) error {
+	pending := game.PendingEvents()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	version := game.Version - len(pending)
+	now := time.Now().UTC()
+	records := make([]gameEventRecord, 0, len(pending))
+	for _, event := range pending {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+		}
+		version++
+		records = append(records, gameEventRecord{
+			GameID:     game.ID,
+			Version:    version,
+			Type:       event.EventType(),
+			Payload:    payload,
+			OccurredAt: now,
+		})
+	}
+
+	if err := tx.Create(&records).Error; err != nil {
+		return fmt.Errorf("failed to append game events (concurrent update?): %w", err)
+	}
+	return nil
+}
+
+// addDeliveries записывает доставки вебхуков в транзакцию партии
+func addDeliveries(tx *gorm.DB, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := tx.Omit(clause.Associations).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to record webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *gameRepository) loadEvents(tx *gorm.DB, id uuid.UUID, afterVersion int) ([]models.RecordedGameEvent, error) {
+	var records []gameEventRecord
+	if err := tx.Where("game_id = ? AND version > ?", id, afterVersion).
+		Order("version").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]models.RecordedGameEvent, 0, len(records))
+	for _, record := range records {
+		event, err := models.NewGameEvent(record.Type)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(record.Payload, event); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event: %w", record.Type, err)
+		}
+		events = append(events, models.RecordedGameEvent{
+			Version:    record.Version,
+			OccurredAt: record.OccurredAt,
+			Event:      event,
+		})
+	}
+	return events, nil
+}
+
+func (r *gameRepository) saveSnapshot(tx *gorm.DB, game *models.Game) error {
+	state, err := json.Marshal(game)
+	if err != nil {
+		return fmt.Errorf("failed to encode game snapshot: %w", err)
+	}
+
+	snapshot := gameSnapshotRecord{
+		GameID:    game.ID,
+		Version:   game.Version,
+		State:     state,
+		CreatedAt: time.Now().UTC(),
+	}
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&snapshot).Error
 }
 
-func (r *gameRepository) Update(game *models.Game) error {
-	return r.db.Save(game).Error
+func crossedSnapshotInterval(game *models.Game) bool {
+	before := game.Version - len(game.PendingEvents())
+	return game.Version/snapshotInterval > before/snapshotInterval
 }
@@ -0,0 +1,37 @@
+package implementation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"nails_game/internal/models"
+	"nails_game/internal/repositories/interfaces"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) interfaces.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Add(notification *models.Notification) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(notification).Error
+}
+
+func (r *notificationRepository) ListPending() ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where("sent_at IS NULL").Order("created_at").Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationRepository) MarkSent(ids []uuid.UUID, sentAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Notification{}).Where("id IN ?", ids).Update("sent_at", sentAt).Error
+}
@@ -24,7 +24,18 @@ func (r *playerRepository) GetByID(id uuid.UUID) (*models.Player, error) {
 	var player models.Player
 	if err := r.db.First(&player, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("player not found")
+			return nil, interfaces.ErrPlayerNotFound
+		}
+		return nil, err
+	}
+	return &player, nil
+}
+
+func (r *playerRepository) GetByEmail(email string) (*models.Player, error) {
+	var player models.Player
+	if err := r.db.First(&player, "email = ?", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrPlayerNotFound
 		}
 		return nil, err
 	}
@@ -35,7 +46,7 @@ func (r *playerRepository) GetWithGames(id uuid.UUID) (*models.Player, error) {
 	var player models.Player
 	if err := r.db.Preload("Games").First(&player, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("player not found")
+			return nil, interfaces.ErrPlayerNotFound
 		}
 		return nil, err
 	}
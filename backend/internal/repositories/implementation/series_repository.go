@@ -0,0 +1,59 @@
+package implementation
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	"nails_game/internal/repositories/interfaces"
+)
+
+type seriesRepository struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepository(db *gorm.DB) interfaces.SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+func (r *seriesRepository) Create(series *models.Series) error {
+	return r.db.Create(series).Error
+}
+
+func (r *seriesRepository) GetByID(id uuid.UUID) (*models.Series, error) {
+	var series models.Series
+	err := r.db.
+		Preload("Games", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		First(&series, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *seriesRepository) List() ([]models.Series, error) {
+	var series []models.Series
+	if err := r.db.Order("created_at DESC").Find(&series).Error; err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *seriesRepository) ListActive() ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&models.Series{}).
+		Where("status <> ?", enums.FinishedSeries).
+		Order("created_at").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *seriesRepository) Update(series *models.Series) error {
+	return r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(series).Error
+}
@@ -0,0 +1,60 @@
+package implementation
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	"nails_game/internal/repositories/interfaces"
+)
+
+type tournamentRepository struct {
+	db *gorm.DB
+}
+
+func NewTournamentRepository(db *gorm.DB) interfaces.TournamentRepository {
+	return &tournamentRepository{db: db}
+}
+
+func (r *tournamentRepository) Create(tournament *models.Tournament) error {
+	return r.db.Create(tournament).Error
+}
+
+func (r *tournamentRepository) GetByID(id uuid.UUID) (*models.Tournament, error) {
+	var tournament models.Tournament
+	err := r.db.
+		Preload("Players", func(db *gorm.DB) *gorm.DB { return db.Order("seed") }).
+		Preload("Pairings", func(db *gorm.DB) *gorm.DB { return db.Order("round, board") }).
+		First(&tournament, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrTournamentNotFound
+		}
+		return nil, err
+	}
+	return &tournament, nil
+}
+
+func (r *tournamentRepository) List() ([]models.Tournament, error) {
+	var tournaments []models.Tournament
+	if err := r.db.Order("created_at DESC").Find(&tournaments).Error; err != nil {
+		return nil, err
+	}
+	return tournaments, nil
+}
+
+func (r *tournamentRepository) ListActive(formats []enums.TournamentFormat) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&models.Tournament{}).
+		Where("status <> ? AND format IN ?", enums.FinishedTournament, formats).
+		Order("created_at").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *tournamentRepository) Update(tournament *models.Tournament) error {
+	return r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(tournament).Error
+}
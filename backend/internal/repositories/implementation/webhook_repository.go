@@ -0,0 +1,112 @@
+package implementation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	"nails_game/internal/repositories/interfaces"
+)
+
+// deliveriesLimit ограничивает список доставок одного вебхука
+const deliveriesLimit = 100
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) interfaces.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *webhookRepository) GetByID(id uuid.UUID) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.First(&webhook, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Webhook{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) ListForGame(game *models.Game) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.
+		Where("scope = ?", enums.GlobalWebhook).
+		Or("scope = ? AND owner_id IN ?", enums.PlayerWebhook, game.Players()).
+		Or("scope = ? AND tournament_id = ?", enums.TournamentWebhook, game.TournamentID).
+		Order("created_at").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) GetDelivery(id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.First(&delivery, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// строки, которые сейчас забирает другой экземпляр, пропускаются, а не ждут его
+		var ids []uuid.UUID
+		if err := tx.Model(&models.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", enums.PendingDelivery, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leaseUntil).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Webhook").Where("id IN ?", ids).Order("created_at").Find(&deliveries).Error
+	})
+	return deliveries, err
+}
+
+func (r *webhookRepository) ListDeliveries(webhookID uuid.UUID, status enums.DeliveryStatus) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("webhook_id = ? AND status = ?", webhookID, status).
+		Order("created_at DESC").
+		Limit(deliveriesLimit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Omit(clause.Associations).Save(delivery).Error
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "nails_game/internal/models"
+
+type BoardPresetRepository interface {
+	Create(preset *models.BoardPreset) error
+	GetByName(name string) (*models.BoardPreset, error)
+	List() ([]models.BoardPreset, error)
+}
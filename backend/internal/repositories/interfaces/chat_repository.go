@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+	"nails_game/internal/models"
+)
+
+type ChatRepository interface {
+	Create(message *models.ChatMessage) error
+	// ListByGame возвращает сообщения партии в порядке отправки
+	ListByGame(gameID uuid.UUID) ([]models.ChatMessage, error)
+	// ListMutes возвращает игроков партии, отключивших сообщения соперников
+	ListMutes(gameID uuid.UUID) ([]uuid.UUID, error)
+	SetMuted(gameID, playerID uuid.UUID, muted bool) error
+}
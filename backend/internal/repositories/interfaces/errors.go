@@ -0,0 +1,13 @@
+package interfaces
+
+import "errors"
+
+var (
+	ErrGameNotFound        = errors.New("game not found")
+	ErrPlayerNotFound      = errors.New("player not found")
+	ErrBoardPresetNotFound = errors.New("board preset not found")
+	ErrTournamentNotFound  = errors.New("tournament not found")
+	ErrSeriesNotFound      = errors.New("series not found")
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrDeliveryNotFound    = errors.New("webhook delivery not found")
+)
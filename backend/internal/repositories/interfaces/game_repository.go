@@ -1,12 +1,23 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
 )
 
 type GameRepository interface {
-	Create(game *models.Game) error
+	// Create и Update записывают доставки вебхуков deliveries в одной транзакции с событиями партии
+	Create(game *models.Game, deliveries ...models.WebhookDelivery) error
 	GetByID(id uuid.UUID) (*models.Game, error)
-	Update(game *models.Game) error
+	Update(game *models.Game, deliveries ...models.WebhookDelivery) error
+	GetEvents(id uuid.UUID) ([]models.RecordedGameEvent, error)
+	Rebuild(id uuid.UUID) (*models.Game, error)
+	ListExpired(now time.Time) ([]uuid.UUID, error)
+	// ListLive возвращает идущие открытые партии, начиная с последних созданных
+	ListLive(filter models.LiveGameFilter) ([]models.Game, error)
+	// ListInProgress возвращает идущие партии с указанным контролем времени
+	ListInProgress(timeControl enums.TimeControlType) ([]models.Game, error)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"nails_game/internal/models"
+)
+
+type NotificationRepository interface {
+	// Add сохраняет уведомление, если о том же ходе того же вида ещё не уведомляли
+	Add(notification *models.Notification) error
+	// ListPending возвращает неотправленные уведомления в порядке создания
+	ListPending() ([]models.Notification, error)
+	MarkSent(ids []uuid.UUID, sentAt time.Time) error
+}
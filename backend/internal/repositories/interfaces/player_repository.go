@@ -8,6 +8,7 @@ import (
 type PlayerRepository interface {
 	Create(player *models.Player) error
 	GetByID(id uuid.UUID) (*models.Player, error)
+	GetByEmail(email string) (*models.Player, error)
 	GetWithGames(id uuid.UUID) (*models.Player, error)
 	Update(player *models.Player) error
 }
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+	"nails_game/internal/models"
+)
+
+type SeriesRepository interface {
+	Create(series *models.Series) error
+	// GetByID загружает серию вместе с её партиями
+	GetByID(id uuid.UUID) (*models.Series, error)
+	List() ([]models.Series, error)
+	// ListActive возвращает незавершённые серии, которые нужно запускать и продвигать
+	ListActive() ([]uuid.UUID, error)
+	// Update сохраняет серию и её партии
+	Update(series *models.Series) error
+}
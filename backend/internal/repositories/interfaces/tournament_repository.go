@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+type TournamentRepository interface {
+	Create(tournament *models.Tournament) error
+	// GetByID загружает турнир вместе с участниками и парами
+	GetByID(id uuid.UUID) (*models.Tournament, error)
+	List() ([]models.Tournament, error)
+	// ListActive возвращает незавершённые турниры указанных систем, которые нужно запускать и продвигать
+	ListActive(formats []enums.TournamentFormat) ([]uuid.UUID, error)
+	// Update сохраняет турнир, его участников и пары
+	Update(tournament *models.Tournament) error
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) error
+	GetByID(id uuid.UUID) (*models.Webhook, error)
+	// Delete удаляет вебхук вместе с его доставками
+	Delete(id uuid.UUID) error
+	// ListForGame возвращает глобальные вебхуки, вебхуки игроков партии
+	// и вебхуки турнира партии
+	ListForGame(game *models.Game) ([]models.Webhook, error)
+
+	GetDelivery(id uuid.UUID) (*models.WebhookDelivery, error)
+	// ClaimDue забирает не больше limit доставок, время попытки которых наступило, вместе с вебхуками
+	// и откладывает их следующую попытку до leaseUntil, чтобы другой экземпляр сервера не отправил
+	// их одновременно; если процесс упадёт, доставки снова станут доступны после leaseUntil
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error)
+	// ListDeliveries возвращает доставки вебхука с указанным состоянием, начиная с последних
+	ListDeliveries(webhookID uuid.UUID, status enums.DeliveryStatus) ([]models.WebhookDelivery, error)
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+}
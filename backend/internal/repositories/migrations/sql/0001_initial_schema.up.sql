@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS players (
+    id            uuid PRIMARY KEY,
+    created_at    timestamptz,
+    updated_at    timestamptz,
+    deleted_at    timestamptz,
+    name          text,
+    email         text CONSTRAINT uni_players_email UNIQUE,
+    password_hash text
+);
+
+CREATE INDEX IF NOT EXISTS idx_players_deleted_at ON players (deleted_at);
+
+CREATE TABLE IF NOT EXISTS games (
+    id                uuid PRIMARY KEY,
+    created_at        timestamptz,
+    updated_at        timestamptz,
+    deleted_at        timestamptz,
+    line              integer[],
+    status            bigint,
+    current_player_id uuid,
+    first_player_id   uuid,
+    second_player_id  uuid,
+    move_count        bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_deleted_at ON games (deleted_at);
+
+CREATE TABLE IF NOT EXISTS player_games (
+    player_id uuid REFERENCES players (id),
+    game_id   uuid REFERENCES games (id),
+    PRIMARY KEY (player_id, game_id)
+);
+
+CREATE TABLE IF NOT EXISTS moves (
+    id         uuid PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    game_id    uuid,
+    player_id  uuid,
+    position   bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_moves_deleted_at ON moves (deleted_at);
@@ -0,0 +1,3 @@
+ALTER TABLE games DROP COLUMN IF EXISTS version;
+DROP TABLE IF EXISTS game_snapshots;
+DROP TABLE IF EXISTS game_events;
@@ -0,0 +1,37 @@
+CREATE TABLE game_events (
+    id          bigserial PRIMARY KEY,
+    game_id     uuid        NOT NULL,
+    version     integer     NOT NULL,
+    type        text        NOT NULL,
+    payload     jsonb       NOT NULL,
+    occurred_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT uni_game_events_game_version UNIQUE (game_id, version)
+);
+
+CREATE TABLE game_snapshots (
+    game_id    uuid PRIMARY KEY,
+    version    integer     NOT NULL,
+    state      jsonb       NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE games ADD COLUMN version integer NOT NULL DEFAULT 0;
+
+-- Существующие партии переносятся в поток одним событием GameImported
+INSERT INTO game_events (game_id, version, type, payload, occurred_at)
+SELECT id,
+       1,
+       'GameImported',
+       json_build_object(
+           'line', COALESCE(array_to_json(line), '[]'::json),
+           'status', status,
+           'firstPlayerId', first_player_id,
+           'secondPlayerId', second_player_id,
+           'currentPlayerId', current_player_id,
+           'moveCount', move_count
+       ),
+       COALESCE(updated_at, now())
+FROM games
+WHERE deleted_at IS NULL;
+
+UPDATE games SET version = 1 WHERE deleted_at IS NULL;
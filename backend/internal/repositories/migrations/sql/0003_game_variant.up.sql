@@ -0,0 +1 @@
+ALTER TABLE games ADD COLUMN variant text NOT NULL DEFAULT 'standard';
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_games_turn_deadline;
+
+ALTER TABLE games
+    DROP COLUMN IF EXISTS termination,
+    DROP COLUMN IF EXISTS time_control_type,
+    DROP COLUMN IF EXISTS time_control_base_seconds,
+    DROP COLUMN IF EXISTS time_control_increment_seconds,
+    DROP COLUMN IF EXISTS time_control_move_seconds,
+    DROP COLUMN IF EXISTS time_control_days_per_move,
+    DROP COLUMN IF EXISTS first_player_clock_ms,
+    DROP COLUMN IF EXISTS second_player_clock_ms,
+    DROP COLUMN IF EXISTS turn_started_at,
+    DROP COLUMN IF EXISTS turn_deadline;
@@ -0,0 +1,15 @@
+ALTER TABLE games
+    ADD COLUMN termination                    text    NOT NULL DEFAULT '',
+    ADD COLUMN time_control_type              text    NOT NULL DEFAULT 'unlimited',
+    ADD COLUMN time_control_base_seconds      bigint  NOT NULL DEFAULT 0,
+    ADD COLUMN time_control_increment_seconds bigint  NOT NULL DEFAULT 0,
+    ADD COLUMN time_control_move_seconds      bigint  NOT NULL DEFAULT 0,
+    ADD COLUMN time_control_days_per_move     bigint  NOT NULL DEFAULT 0,
+    ADD COLUMN first_player_clock_ms          bigint  NOT NULL DEFAULT 0,
+    ADD COLUMN second_player_clock_ms         bigint  NOT NULL DEFAULT 0,
+    ADD COLUMN turn_started_at                timestamptz,
+    ADD COLUMN turn_deadline                  timestamptz;
+
+UPDATE games SET termination = 'normal' WHERE status <> 0;
+
+CREATE INDEX idx_games_turn_deadline ON games (turn_deadline) WHERE status = 0 AND turn_deadline IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE games ADD COLUMN draw_offered_by uuid;
@@ -0,0 +1,3 @@
+ALTER TABLE games
+    DROP COLUMN IF EXISTS takeback_requested_by,
+    DROP COLUMN IF EXISTS rated;
@@ -0,0 +1,3 @@
+ALTER TABLE games
+    ADD COLUMN rated boolean NOT NULL DEFAULT false,
+    ADD COLUMN takeback_requested_by uuid;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS board_presets;
+
+ALTER TABLE games DROP COLUMN IF EXISTS coordinates;
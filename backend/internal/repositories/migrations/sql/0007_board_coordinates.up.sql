@@ -0,0 +1,8 @@
+ALTER TABLE games ADD COLUMN coordinates integer[];
+
+CREATE TABLE board_presets (
+    name        text PRIMARY KEY,
+    coordinates integer[] NOT NULL,
+    seed        bigint,
+    created_at  timestamptz NOT NULL
+);
@@ -0,0 +1,3 @@
+ALTER TABLE games
+    DROP COLUMN IF EXISTS metric,
+    DROP COLUMN IF EXISTS width;
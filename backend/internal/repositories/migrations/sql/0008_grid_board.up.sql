@@ -0,0 +1,3 @@
+ALTER TABLE games
+    ADD COLUMN width  bigint NOT NULL DEFAULT 0,
+    ADD COLUMN metric text   NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+ALTER TABLE games
+    DROP COLUMN IF EXISTS ranking,
+    DROP COLUMN IF EXISTS scores,
+    DROP COLUMN IF EXISTS seats;
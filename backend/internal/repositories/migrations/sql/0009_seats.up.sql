@@ -0,0 +1,6 @@
+ALTER TABLE games
+    ADD COLUMN seats   jsonb,
+    ADD COLUMN scores  jsonb,
+    ADD COLUMN ranking jsonb;
+
+UPDATE games SET seats = jsonb_build_array(first_player_id, second_player_id);
@@ -0,0 +1,4 @@
+ALTER TABLE games
+    DROP COLUMN IF EXISTS turn_placements,
+    DROP COLUMN IF EXISTS turn_count,
+    DROP COLUMN IF EXISTS schedule;
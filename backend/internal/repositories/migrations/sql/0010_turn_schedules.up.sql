@@ -0,0 +1,6 @@
+ALTER TABLE games
+    ADD COLUMN schedule        jsonb,
+    ADD COLUMN turn_count      bigint NOT NULL DEFAULT 0,
+    ADD COLUMN turn_placements bigint NOT NULL DEFAULT 0;
+
+UPDATE games SET turn_count = move_count;
@@ -0,0 +1 @@
+ALTER TABLE games ADD COLUMN revealed jsonb;
@@ -0,0 +1,3 @@
+ALTER TABLE games
+    DROP COLUMN IF EXISTS commits,
+    DROP COLUMN IF EXISTS round;
@@ -0,0 +1,3 @@
+ALTER TABLE games
+    ADD COLUMN round   bigint NOT NULL DEFAULT 0,
+    ADD COLUMN commits jsonb;
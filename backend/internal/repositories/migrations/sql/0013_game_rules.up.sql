@@ -0,0 +1,10 @@
+ALTER TABLE games ADD COLUMN rules jsonb;
+
+UPDATE games SET rules = jsonb_build_object(
+    'topology', CASE variant WHEN 'circular' THEN 'ring' WHEN 'grid' THEN 'grid' ELSE 'line' END,
+    'turnOrder', CASE variant WHEN 'simultaneous' THEN 'simultaneous' ELSE 'sequential' END,
+    'scoring', 'longest_thread',
+    'endCondition', 'board_full',
+    'pieRule', COALESCE(variant = 'pie', false),
+    'hiddenNails', COALESCE(variant = 'fog', false)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS tournament_pairings;
+DROP TABLE IF EXISTS tournament_players;
+DROP TABLE IF EXISTS tournaments;
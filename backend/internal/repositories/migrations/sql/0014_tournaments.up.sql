@@ -0,0 +1,37 @@
+CREATE TABLE tournaments (
+    id            uuid PRIMARY KEY,
+    name          text NOT NULL,
+    format        text NOT NULL,
+    status        text NOT NULL,
+    organizer_id  uuid NOT NULL REFERENCES players (id),
+    game_settings jsonb NOT NULL,
+    rounds        bigint NOT NULL DEFAULT 0,
+    current_round bigint NOT NULL DEFAULT 0,
+    starts_at     timestamptz,
+    created_at    timestamptz NOT NULL,
+    finished_at   timestamptz
+);
+
+CREATE INDEX idx_tournaments_status ON tournaments (status);
+
+CREATE TABLE tournament_players (
+    tournament_id uuid REFERENCES tournaments (id) ON DELETE CASCADE,
+    player_id     uuid REFERENCES players (id),
+    seed          bigint NOT NULL,
+    registered_at timestamptz NOT NULL,
+    PRIMARY KEY (tournament_id, player_id)
+);
+
+CREATE TABLE tournament_pairings (
+    id               uuid PRIMARY KEY,
+    tournament_id    uuid NOT NULL REFERENCES tournaments (id) ON DELETE CASCADE,
+    round            bigint NOT NULL,
+    board            bigint NOT NULL,
+    first_player_id  uuid NOT NULL,
+    second_player_id uuid,
+    game_id          uuid,
+    result           text NOT NULL DEFAULT '',
+    replays          bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_tournament_pairings_tournament ON tournament_pairings (tournament_id, round);
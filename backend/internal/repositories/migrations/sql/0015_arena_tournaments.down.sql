@@ -0,0 +1,6 @@
+ALTER TABLE tournament_pairings DROP COLUMN IF EXISTS finished_at;
+
+ALTER TABLE tournament_players DROP COLUMN IF EXISTS paused;
+
+ALTER TABLE tournaments DROP COLUMN IF EXISTS ends_at;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS duration;
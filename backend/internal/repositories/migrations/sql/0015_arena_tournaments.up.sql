@@ -0,0 +1,6 @@
+ALTER TABLE tournaments ADD COLUMN duration bigint NOT NULL DEFAULT 0;
+ALTER TABLE tournaments ADD COLUMN ends_at timestamptz;
+
+ALTER TABLE tournament_players ADD COLUMN paused boolean NOT NULL DEFAULT false;
+
+ALTER TABLE tournament_pairings ADD COLUMN finished_at timestamptz;
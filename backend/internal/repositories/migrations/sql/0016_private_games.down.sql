@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_games_live;
+
+ALTER TABLE games DROP COLUMN IF EXISTS private;
@@ -0,0 +1,3 @@
+ALTER TABLE games ADD COLUMN private boolean NOT NULL DEFAULT false;
+
+CREATE INDEX idx_games_live ON games (created_at) WHERE status = 0 AND NOT private;
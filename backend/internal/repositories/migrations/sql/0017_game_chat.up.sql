@@ -0,0 +1,17 @@
+CREATE TABLE chat_messages (
+    id         uuid PRIMARY KEY,
+    game_id    uuid NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+    channel    text NOT NULL,
+    player_id  uuid NOT NULL,
+    text       text NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX idx_chat_messages_game ON chat_messages (game_id, created_at);
+
+CREATE TABLE chat_mutes (
+    game_id    uuid REFERENCES games (id) ON DELETE CASCADE,
+    player_id  uuid,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (game_id, player_id)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS series_games;
+DROP TABLE IF EXISTS series;
+
+ALTER TABLE games DROP COLUMN IF EXISTS rematch_game_id;
+ALTER TABLE games DROP COLUMN IF EXISTS rematch_offered_by;
+ALTER TABLE games DROP COLUMN IF EXISTS previous_game_id;
@@ -0,0 +1,28 @@
+ALTER TABLE games ADD COLUMN previous_game_id uuid REFERENCES games (id);
+ALTER TABLE games ADD COLUMN rematch_offered_by uuid;
+ALTER TABLE games ADD COLUMN rematch_game_id uuid;
+
+CREATE TABLE series (
+    id               uuid PRIMARY KEY,
+    first_player_id  uuid NOT NULL REFERENCES players (id),
+    second_player_id uuid NOT NULL REFERENCES players (id),
+    best_of          bigint NOT NULL,
+    status           text NOT NULL,
+    game_settings    jsonb NOT NULL,
+    starts_at        timestamptz,
+    winner_id        uuid,
+    created_at       timestamptz NOT NULL,
+    finished_at      timestamptz
+);
+
+CREATE INDEX idx_series_status ON series (status);
+
+CREATE TABLE series_games (
+    series_id       uuid REFERENCES series (id) ON DELETE CASCADE,
+    number          bigint NOT NULL,
+    game_id         uuid NOT NULL REFERENCES games (id),
+    first_player_id uuid NOT NULL,
+    result          text NOT NULL DEFAULT '',
+    finished_at     timestamptz,
+    PRIMARY KEY (series_id, number)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notifications;
+
+ALTER TABLE players DROP COLUMN IF EXISTS notify_no_reminders;
+ALTER TABLE players DROP COLUMN IF EXISTS notify_mode;
@@ -0,0 +1,16 @@
+ALTER TABLE players ADD COLUMN notify_mode text NOT NULL DEFAULT 'immediate';
+ALTER TABLE players ADD COLUMN notify_no_reminders boolean NOT NULL DEFAULT false;
+
+CREATE TABLE notifications (
+    id              uuid PRIMARY KEY,
+    player_id       uuid NOT NULL REFERENCES players (id),
+    game_id         uuid NOT NULL REFERENCES games (id),
+    kind            text NOT NULL,
+    turn_started_at timestamptz NOT NULL,
+    deadline        timestamptz,
+    created_at      timestamptz NOT NULL,
+    sent_at         timestamptz,
+    UNIQUE (game_id, player_id, kind, turn_started_at)
+);
+
+CREATE INDEX idx_notifications_pending ON notifications (created_at) WHERE sent_at IS NULL;
@@ -0,0 +1,30 @@
+CREATE TABLE webhooks (
+    id            uuid PRIMARY KEY,
+    url           text NOT NULL,
+    scope         text NOT NULL,
+    secret        text NOT NULL,
+    owner_id      uuid REFERENCES players (id),
+    tournament_id uuid REFERENCES tournaments (id) ON DELETE CASCADE,
+    events        jsonb,
+    created_at    timestamptz NOT NULL
+);
+
+CREATE INDEX idx_webhooks_owner ON webhooks (owner_id) WHERE scope = 'player';
+CREATE INDEX idx_webhooks_tournament ON webhooks (tournament_id) WHERE scope = 'tournament';
+
+CREATE TABLE webhook_deliveries (
+    id              uuid PRIMARY KEY,
+    webhook_id      uuid NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    game_id         uuid NOT NULL,
+    event           text NOT NULL,
+    payload         jsonb NOT NULL,
+    status          text NOT NULL,
+    attempts        bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error      text NOT NULL DEFAULT '',
+    created_at      timestamptz NOT NULL,
+    delivered_at    timestamptz
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, status, created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE games ADD COLUMN tournament_id uuid REFERENCES tournaments (id);
+
+UPDATE games SET tournament_id = p.tournament_id
+FROM tournament_pairings p
+WHERE p.game_id = games.id;
@@ -0,0 +1,115 @@
+package errors
+
+// Code - стабильный машиночитаемый код ошибки, на который могут опираться клиенты.
+// Коды никогда не переименовываются; новые коды только добавляются.
+type Code string
+
+const (
+	// CodeBadRequest - запрос не удалось разобрать (400)
+	CodeBadRequest Code = "BAD_REQUEST"
+	// CodeValidationFailed - параметры запроса не прошли проверку, подробности в details.fields (422)
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	// CodeInvalidPosition - позиция хода вне поля (422)
+	CodeInvalidPosition Code = "INVALID_POSITION"
+
+	// CodeGameNotFound - партия не найдена (404)
+	CodeGameNotFound Code = "GAME_NOT_FOUND"
+	// CodePlayerNotFound - игрок не найден (404)
+	CodePlayerNotFound Code = "PLAYER_NOT_FOUND"
+	// CodeBoardPresetNotFound - пресет поля не найден (404)
+	CodeBoardPresetNotFound Code = "BOARD_PRESET_NOT_FOUND"
+	// CodeTournamentNotFound - турнир не найден (404)
+	CodeTournamentNotFound Code = "TOURNAMENT_NOT_FOUND"
+	// CodeSeriesNotFound - серия партий не найдена (404)
+	CodeSeriesNotFound Code = "SERIES_NOT_FOUND"
+	// CodeWebhookNotFound - вебхук не найден (404)
+	CodeWebhookNotFound Code = "WEBHOOK_NOT_FOUND"
+	// CodeDeliveryNotFound - доставка события на вебхук не найдена (404)
+	CodeDeliveryNotFound Code = "DELIVERY_NOT_FOUND"
+	// CodeRouteNotFound - неизвестный адрес API (404)
+	CodeRouteNotFound Code = "ROUTE_NOT_FOUND"
+
+	// CodeInvalidToken - токен игрока повреждён, подписан другим ключом или истёк (401)
+	CodeInvalidToken Code = "INVALID_TOKEN"
+	// CodeInvalidCredentials - неверная почта или пароль (401)
+	CodeInvalidCredentials Code = "INVALID_CREDENTIALS"
+	// CodeAuthenticationRequired - действие или закрытая партия доступны только с токеном игрока (401)
+	CodeAuthenticationRequired Code = "AUTHENTICATION_REQUIRED"
+
+	// CodePlayerNotInGame - игрок не участвует в партии (403)
+	CodePlayerNotInGame Code = "PLAYER_NOT_IN_GAME"
+	// CodeNotOrganizer - действие доступно только организатору турнира (403)
+	CodeNotOrganizer Code = "NOT_ORGANIZER"
+	// CodeNotRegistered - игрок не зарегистрирован на турнир (403)
+	CodeNotRegistered Code = "NOT_REGISTERED"
+	// CodePlayerMismatch - игрок в запросе не совпадает с владельцем токена (403)
+	CodePlayerMismatch Code = "PLAYER_MISMATCH"
+	// CodeAdminKeyRequired - действие доступно только с ключом администратора (403)
+	CodeAdminKeyRequired Code = "ADMIN_KEY_REQUIRED"
+	// CodeNotWebhookOwner - вебхуком управляет только зарегистрировавший его игрок (403)
+	CodeNotWebhookOwner Code = "NOT_WEBHOOK_OWNER"
+	// CodeSpectatorsOnly - игроки пишут в чат зрителей только после окончания партии (403)
+	CodeSpectatorsOnly Code = "SPECTATORS_ONLY"
+
+	// CodeGameFinished - партия уже завершена (409)
+	CodeGameFinished Code = "GAME_FINISHED"
+	// CodeNotYourTurn - сейчас ход другого игрока (409)
+	CodeNotYourTurn Code = "NOT_YOUR_TURN"
+	// CodePositionTaken - позиция уже занята (409)
+	CodePositionTaken Code = "POSITION_TAKEN"
+	// CodePositionBlocked - позиция закрыта начальной расстановкой (409)
+	CodePositionBlocked Code = "POSITION_BLOCKED"
+	// CodeAlreadyCommitted - игрок уже выбрал позицию в текущем раунде варианта simultaneous (409)
+	CodeAlreadyCommitted Code = "ALREADY_COMMITTED"
+	// CodeBoardPresetExists - пресет с таким именем уже есть (409)
+	CodeBoardPresetExists Code = "BOARD_PRESET_EXISTS"
+	// CodeTwoPlayerOnly - действие доступно только в партиях на двоих (409)
+	CodeTwoPlayerOnly Code = "TWO_PLAYER_ONLY"
+	// CodeSwapNotAllowed - поменяться сторонами можно только в варианте pie вторым игроком после первого гвоздя (409)
+	CodeSwapNotAllowed Code = "SWAP_NOT_ALLOWED"
+	// CodeAbortNotAllowed - прервать партию можно только до первого хода каждого игрока (409)
+	CodeAbortNotAllowed Code = "ABORT_NOT_ALLOWED"
+	// CodeNoDrawOffer - соперник не предлагал ничью (409)
+	CodeNoDrawOffer Code = "NO_DRAW_OFFER"
+	// CodeDrawAlreadyOffered - игрок уже предложил ничью и ждёт ответа (409)
+	CodeDrawAlreadyOffered Code = "DRAW_ALREADY_OFFERED"
+	// CodeTakebacksDisabled - возврат ходов запрещён политикой для этой партии (409)
+	CodeTakebacksDisabled Code = "TAKEBACKS_DISABLED"
+	// CodeNoMoveToTakeBack - у игрока нет хода, который можно вернуть (409)
+	CodeNoMoveToTakeBack Code = "NO_MOVE_TO_TAKE_BACK"
+	// CodeTakebackAlreadyRequested - игрок уже попросил вернуть ход и ждёт ответа (409)
+	CodeTakebackAlreadyRequested Code = "TAKEBACK_ALREADY_REQUESTED"
+	// CodeNoTakebackRequest - соперник не просил вернуть ход (409)
+	CodeNoTakebackRequest Code = "NO_TAKEBACK_REQUEST"
+	// CodeTimeExpired - у игрока закончилось время, партия завершена (409)
+	CodeTimeExpired Code = "TIME_EXPIRED"
+	// CodeRegistrationClosed - регистрация на турнир закрыта (409)
+	CodeRegistrationClosed Code = "REGISTRATION_CLOSED"
+	// CodeAlreadyRegistered - игрок уже зарегистрирован на турнир (409)
+	CodeAlreadyRegistered Code = "ALREADY_REGISTERED"
+	// CodeTournamentStarted - турнир уже начался или завершён (409)
+	CodeTournamentStarted Code = "TOURNAMENT_STARTED"
+	// CodeNotEnoughPlayers - для старта турнира нужно хотя бы два участника (409)
+	CodeNotEnoughPlayers Code = "NOT_ENOUGH_PLAYERS"
+	// CodeTournamentFinished - турнир уже завершён (409)
+	CodeTournamentFinished Code = "TOURNAMENT_FINISHED"
+	// CodeArenaOnly - действие доступно только на арене (409)
+	CodeArenaOnly Code = "ARENA_ONLY"
+	// CodeGameNotFinished - действие доступно только после окончания партии (409)
+	CodeGameNotFinished Code = "GAME_NOT_FINISHED"
+	// CodeRematchAlreadyOffered - игрок уже предложил реванш и ждёт ответа (409)
+	CodeRematchAlreadyOffered Code = "REMATCH_ALREADY_OFFERED"
+	// CodeNoRematchOffer - соперник не предлагал реванш (409)
+	CodeNoRematchOffer Code = "NO_REMATCH_OFFER"
+	// CodeRematchExists - реванш этой партии уже создан (409)
+	CodeRematchExists Code = "REMATCH_EXISTS"
+	// CodeDeliveryNotDead - повторно отправить можно только недоставленное событие (409)
+	CodeDeliveryNotDead Code = "DELIVERY_NOT_DEAD"
+
+	// CodeRateLimited - превышен лимит запросов (429)
+	CodeRateLimited Code = "RATE_LIMITED"
+	// CodeChatRateLimited - игрок отправляет сообщения в чат слишком часто (429)
+	CodeChatRateLimited Code = "CHAT_RATE_LIMITED"
+	// CodeInternal - непредвиденная ошибка сервера (500)
+	CodeInternal Code = "INTERNAL_ERROR"
+)
@@ -0,0 +1,110 @@
+package errors
+
+import "strings"
+
+// DomainError - общая часть всех ошибок предметной области
+type DomainError struct {
+	Code    Code
+	Message string
+	Details map[string]interface{}
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) ErrorCode() Code {
+	return e.Code
+}
+
+func (e *DomainError) ErrorDetails() map[string]interface{} {
+	return e.Details
+}
+
+// CodedError реализуют все ошибки предметной области
+type CodedError interface {
+	error
+	ErrorCode() Code
+	ErrorDetails() map[string]interface{}
+}
+
+// NotFoundError - запрошенная сущность не существует
+type NotFoundError struct {
+	DomainError
+}
+
+func NewNotFoundError(code Code, message string) *NotFoundError {
+	return &NotFoundError{DomainError{Code: code, Message: message}}
+}
+
+// UnauthenticatedError - не удалось установить, кто выполняет запрос
+type UnauthenticatedError struct {
+	DomainError
+}
+
+func NewUnauthenticatedError(code Code, message string) *UnauthenticatedError {
+	return &UnauthenticatedError{DomainError{Code: code, Message: message}}
+}
+
+// UnauthorizedError - у игрока нет прав на операцию
+type UnauthorizedError struct {
+	DomainError
+}
+
+func NewUnauthorizedError(code Code, message string) *UnauthorizedError {
+	return &UnauthorizedError{DomainError{Code: code, Message: message}}
+}
+
+// InvalidOperationError - операция невозможна в текущем состоянии партии
+type InvalidOperationError struct {
+	DomainError
+}
+
+func NewInvalidOperationError(code Code, message string) *InvalidOperationError {
+	return &InvalidOperationError{DomainError{Code: code, Message: message}}
+}
+
+// RateLimitedError - игрок слишком часто повторяет операцию
+type RateLimitedError struct {
+	DomainError
+}
+
+func NewRateLimitedError(code Code, message string) *RateLimitedError {
+	return &RateLimitedError{DomainError{Code: code, Message: message}}
+}
+
+// FieldError описывает проблему с одним полем запроса
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError содержит все ошибки валидации запроса сразу
+type ValidationError struct {
+	DomainError
+	Fields []FieldError
+}
+
+func NewValidationError(code Code) *ValidationError {
+	return &ValidationError{DomainError: DomainError{Code: code}}
+}
+
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Fields) > 0
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) ErrorDetails() map[string]interface{} {
+	return map[string]interface{}{"fields": e.Fields}
+}
@@ -0,0 +1,25 @@
+package implemenatation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// NewArenaScheduler часто проверяет арены: освободившиеся игроки не должны ждать
+// новой пары так же долго, как тур обычного турнира
+func NewArenaScheduler(tournamentService services.TournamentService, interval time.Duration, logger *logrus.Logger) *PeriodicRunner {
+	return NewPeriodicRunner("arena", interval, func() error {
+		advanced, err := tournamentService.AdvanceArenas()
+		if advanced > 0 {
+			logger.WithField("arenas", advanced).Info("Advanced arenas")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to advance arenas: %w", err)
+		}
+		return nil
+	}, logger)
+}
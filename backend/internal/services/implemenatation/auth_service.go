@@ -0,0 +1,48 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"nails_game/internal/models"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// missingPlayerHash сравнивается с паролем неизвестной почты, чтобы ответ
+// занимал столько же времени и не выдавал, какие адреса зарегистрированы
+var missingPlayerHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("missing-player"), bcrypt.DefaultCost)
+	return hash
+})
+
+type authService struct {
+	playerRepo repositories.PlayerRepository
+	tokens     services.TokenService
+}
+
+func NewAuthService(playerRepo repositories.PlayerRepository, tokens services.TokenService) services.AuthService {
+	return &authService{playerRepo: playerRepo, tokens: tokens}
+}
+
+func (s *authService) Login(email, password string) (*models.Player, string, error) {
+	invalid := serviceErrors.NewUnauthenticatedError(serviceErrors.CodeInvalidCredentials, "invalid email or password")
+
+	player, err := s.playerRepo.GetByEmail(email)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrPlayerNotFound) {
+			return nil, "", fmt.Errorf("failed to load player: %w", err)
+		}
+		_ = bcrypt.CompareHashAndPassword(missingPlayerHash(), []byte(password))
+		return nil, "", invalid
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(player.PasswordHash), []byte(password)); err != nil {
+		return nil, "", invalid
+	}
+	return player, s.tokens.Issue(player.ID, uuid.Nil), nil
+}
@@ -0,0 +1,49 @@
+package implemenatation
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// maxCoordinate ограничивает координаты, чтобы длины нитей оставались разумными
+const maxCoordinate = 1_000_000
+
+// generateCoordinates строит раскладку по зерну: первая позиция в нуле,
+// шаг до следующей случайный от 1 до maxGap. Генератор PCG детерминирован,
+// поэтому одинаковые зерно и размер всегда дают одинаковое поле
+func generateCoordinates(seed int64, size, maxGap int) []int {
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+	coordinates := make([]int, size)
+	for i := 1; i < size; i++ {
+		coordinates[i] = coordinates[i-1] + 1 + rng.IntN(maxGap)
+	}
+	return coordinates
+}
+
+// validateCoordinates проверяет значения явно заданных координат позиций
+func validateCoordinates(validation *serviceErrors.ValidationError, field string, coordinates []int) {
+	for i, c := range coordinates {
+		if c < 0 || c > maxCoordinate {
+			validation.Add(field, fmt.Sprintf("coordinates must be between 0 and %d", maxCoordinate))
+			return
+		}
+		if i > 0 && c <= coordinates[i-1] {
+			validation.Add(field, "coordinates must be strictly increasing")
+			return
+		}
+	}
+}
+
+func validateLineSize(
+	validation *serviceErrors.ValidationError,
+	field string,
+	size int,
+	policy services.GameSettingsPolicy,
+) {
+	if size < policy.MinLineSize || size > policy.MaxLineSize {
+		validation.Add(field, fmt.Sprintf("must have between %d and %d positions", policy.MinLineSize, policy.MaxLineSize))
+	}
+}
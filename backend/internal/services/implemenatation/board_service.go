@@ -0,0 +1,97 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"nails_game/internal/models"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+var presetNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+type boardService struct {
+	presetRepo repositories.BoardPresetRepository
+	policy     services.GameSettingsPolicy
+
+	now func() time.Time
+}
+
+func NewBoardService(presetRepo repositories.BoardPresetRepository, policy services.GameSettingsPolicy) services.BoardService {
+	return &boardService{
+		presetRepo: presetRepo,
+		policy:     policy,
+		now:        time.Now,
+	}
+}
+
+func (s *boardService) CreatePreset(name string, layout models.BoardLayout, size int) (*models.BoardPreset, error) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+
+	if !presetNamePattern.MatchString(name) {
+		validation.Add("name", "must be 1-64 lowercase letters, digits, '-' or '_'")
+	}
+
+	preset := &models.BoardPreset{Name: name, Seed: layout.Seed}
+	switch {
+	case layout.Preset != "" || (layout.Coordinates != nil) == (layout.Seed != nil):
+		validation.Add("coordinates", "specify either coordinates or seed")
+	case layout.Coordinates != nil:
+		validateLineSize(validation, "coordinates", len(layout.Coordinates), s.policy)
+		validateCoordinates(validation, "coordinates", layout.Coordinates)
+		preset.Coordinates = layout.Coordinates
+	default:
+		validateLineSize(validation, "size", size, s.policy)
+		if !validation.HasErrors() {
+			preset.Coordinates = generateCoordinates(*layout.Seed, size, s.policy.MaxCoordinateGap)
+		}
+	}
+
+	if validation.HasErrors() {
+		return nil, validation
+	}
+
+	if _, err := s.presetRepo.GetByName(name); err == nil {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeBoardPresetExists, "board preset already exists")
+	} else if !errors.Is(err, repositories.ErrBoardPresetNotFound) {
+		return nil, fmt.Errorf("failed to load board preset: %w", err)
+	}
+
+	preset.CreatedAt = s.now().UTC()
+	if err := s.presetRepo.Create(preset); err != nil {
+		return nil, fmt.Errorf("failed to create board preset: %w", err)
+	}
+	return preset, nil
+}
+
+func (s *boardService) GetPreset(name string) (*models.BoardPreset, error) {
+	preset, err := s.presetRepo.GetByName(name)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBoardPresetNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeBoardPresetNotFound, "board preset not found")
+		}
+		return nil, fmt.Errorf("failed to load board preset: %w", err)
+	}
+	return preset, nil
+}
+
+func (s *boardService) ListPresets() ([]models.BoardPreset, error) {
+	presets, err := s.presetRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list board presets: %w", err)
+	}
+	return presets, nil
+}
+
+func (s *boardService) GenerateBoard(seed int64, size int) ([]int, error) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+	validateLineSize(validation, "size", size, s.policy)
+	if validation.HasErrors() {
+		return nil, validation
+	}
+	return generateCoordinates(seed, size, s.policy.MaxCoordinateGap), nil
+}
@@ -0,0 +1,50 @@
+package implemenatation
+
+import (
+	"strings"
+	"unicode"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// wordListFilter заменяет звёздочками слова из списка, без учёта регистра
+type wordListFilter struct {
+	words map[string]bool
+}
+
+func NewWordListFilter(words []string) services.ChatFilter {
+	f := &wordListFilter{words: make(map[string]bool, len(words))}
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			f.words[strings.ToLower(word)] = true
+		}
+	}
+	return f
+}
+
+func (f *wordListFilter) Filter(text string) (string, bool) {
+	if len(f.words) == 0 {
+		return text, true
+	}
+
+	var out strings.Builder
+	var word []rune
+	flush := func() {
+		if f.words[strings.ToLower(string(word))] {
+			out.WriteString(strings.Repeat("*", len(word)))
+		} else {
+			out.WriteString(string(word))
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		out.WriteRune(r)
+	}
+	flush()
+	return out.String(), true
+}
@@ -0,0 +1,20 @@
+package implemenatation
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// NewChatScheduler периодически забывает игроков, которые давно не писали в чат,
+// чтобы учёт ограничения частоты не рос
+func NewChatScheduler(chatService services.ChatService, interval time.Duration, logger *logrus.Logger) *PeriodicRunner {
+	return NewPeriodicRunner("chat", interval, func() error {
+		if forgotten := chatService.ForgetIdleSenders(); forgotten > 0 {
+			logger.WithField("players", forgotten).Debug("Forgot idle chat senders")
+		}
+		return nil
+	}, logger)
+}
@@ -0,0 +1,257 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+type chatService struct {
+	chatRepo    repositories.ChatRepository
+	playerRepo  repositories.PlayerRepository
+	gameService services.GameService
+	// feed - nil, если потоки партий не нужно будить
+	feed   services.GameFeed
+	filter services.ChatFilter
+	policy services.ChatPolicy
+	now    func() time.Time
+
+	// sent - время недавних сообщений каждого игрока для ограничения частоты
+	mutex sync.Mutex
+	sent  map[uuid.UUID][]time.Time
+}
+
+func NewChatService(
+	chatRepo repositories.ChatRepository,
+	playerRepo repositories.PlayerRepository,
+	gameService services.GameService,
+	feed services.GameFeed,
+	filter services.ChatFilter,
+	policy services.ChatPolicy,
+	now func() time.Time,
+) services.ChatService {
+	return &chatService{
+		chatRepo:    chatRepo,
+		playerRepo:  playerRepo,
+		gameService: gameService,
+		feed:        feed,
+		filter:      filter,
+		policy:      policy,
+		now:         now,
+		sent:        make(map[uuid.UUID][]time.Time),
+	}
+}
+
+func (s *chatService) Send(
+	gameID, playerID uuid.UUID,
+	channel enums.ChatChannel,
+	text string,
+) (*models.ChatMessage, error) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+	if !channel.IsKnown() {
+		validation.Add("channel", fmt.Sprintf("must be %s or %s", enums.PlayersChannel, enums.SpectatorsChannel))
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		validation.Add("text", "must not be empty")
+	} else if utf8.RuneCountInString(text) > s.policy.MaxMessageLength {
+		validation.Add("text", fmt.Sprintf("must be at most %d characters", s.policy.MaxMessageLength))
+	}
+	if validation.HasErrors() {
+		return nil, validation
+	}
+	if playerID == uuid.Nil {
+		return nil, serviceErrors.NewUnauthenticatedError(serviceErrors.CodeAuthenticationRequired, "player is required to chat")
+	}
+
+	view, err := s.gameService.GetGame(gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	game := view.Game
+	isPlayer := game.HasPlayer(playerID)
+	switch {
+	case channel == enums.PlayersChannel && !isPlayer:
+		return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerNotInGame, "player is not in this game")
+	case channel == enums.SpectatorsChannel && isPlayer && game.Status == enums.InProgress:
+		return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodeSpectatorsOnly,
+			"players can write to the spectator chat only after the game")
+	}
+	if !isPlayer {
+		if _, err := s.playerRepo.GetByID(playerID); err != nil {
+			if errors.Is(err, repositories.ErrPlayerNotFound) {
+				return nil, serviceErrors.NewNotFoundError(serviceErrors.CodePlayerNotFound, "player not found")
+			}
+			return nil, fmt.Errorf("failed to load player: %w", err)
+		}
+	}
+
+	text, ok := s.filter.Filter(text)
+	if !ok {
+		validation.Add("text", "message was rejected by the chat filter")
+		return nil, validation
+	}
+
+	now := s.now().UTC()
+	if !s.allow(playerID, now) {
+		return nil, serviceErrors.NewRateLimitedError(serviceErrors.CodeChatRateLimited,
+			fmt.Sprintf("at most %d messages per %s", s.policy.MaxMessages, s.policy.RateWindow))
+	}
+
+	message := &models.ChatMessage{
+		ID:        uuid.New(),
+		GameID:    gameID,
+		Channel:   channel,
+		PlayerID:  playerID,
+		Text:      text,
+		CreatedAt: now,
+	}
+	if err := s.chatRepo.Create(message); err != nil {
+		return nil, fmt.Errorf("failed to save chat message: %w", err)
+	}
+	s.notify(gameID)
+	return message, nil
+}
+
+// allow отмечает сообщение игрока, если он не превысил лимит за окно RateWindow
+func (s *chatService) allow(playerID uuid.UUID, now time.Time) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	recent := s.recentMessages(playerID, now)
+	if len(recent) >= s.policy.MaxMessages {
+		return false
+	}
+	s.sent[playerID] = append(recent, now)
+	return true
+}
+
+func (s *chatService) ForgetIdleSenders() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now().UTC()
+	forgotten := 0
+	for playerID := range s.sent {
+		if len(s.recentMessages(playerID, now)) == 0 {
+			forgotten++
+		}
+	}
+	return forgotten
+}
+
+// recentMessages оставляет в sent только сообщения игрока внутри окна RateWindow
+// и забывает игрока, если таких не осталось; вызывается под mutex
+func (s *chatService) recentMessages(playerID uuid.UUID, now time.Time) []time.Time {
+	times := s.sent[playerID]
+	recent := times[:0]
+	for _, at := range times {
+		if now.Sub(at) < s.policy.RateWindow {
+			recent = append(recent, at)
+		}
+	}
+	if len(recent) == 0 {
+		delete(s.sent, playerID)
+		return nil
+	}
+	s.sent[playerID] = recent
+	return recent
+}
+
+func (s *chatService) notify(gameID uuid.UUID) {
+	if s.feed != nil {
+		s.feed.Notify(gameID)
+	}
+}
+
+func (s *chatService) Messages(gameID, viewerID uuid.UUID) ([]models.ChatMessage, error) {
+	view, err := s.gameService.GetGame(gameID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.MessagesIn(view.Game, viewerID)
+}
+
+// MessagesIn показывает игрокам их чат, а зрителям - чат зрителей; после окончания партии
+// видны оба канала. Игрок, отключивший чат, не видит сообщений соперников
+func (s *chatService) MessagesIn(game *models.Game, viewerID uuid.UUID) ([]models.ChatMessage, error) {
+	messages, err := s.chatRepo.ListByGame(game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chat messages: %w", err)
+	}
+	muted, err := s.isMuted(game.ID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	isPlayer := game.HasPlayer(viewerID)
+	finished := game.Status != enums.InProgress
+	visible := make([]models.ChatMessage, 0, len(messages))
+	for _, message := range messages {
+		switch message.Channel {
+		case enums.PlayersChannel:
+			if !isPlayer && !finished {
+				continue
+			}
+			if muted && message.PlayerID != viewerID {
+				continue
+			}
+		case enums.SpectatorsChannel:
+			if isPlayer && !finished {
+				continue
+			}
+		}
+		visible = append(visible, message)
+	}
+	return visible, nil
+}
+
+func (s *chatService) isMuted(gameID, viewerID uuid.UUID) (bool, error) {
+	if viewerID == uuid.Nil {
+		return false, nil
+	}
+	mutes, err := s.chatRepo.ListMutes(gameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load chat mutes: %w", err)
+	}
+	for _, playerID := range mutes {
+		if playerID == viewerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *chatService) Mute(gameID, playerID uuid.UUID) error {
+	return s.setMuted(gameID, playerID, true)
+}
+
+func (s *chatService) Unmute(gameID, playerID uuid.UUID) error {
+	return s.setMuted(gameID, playerID, false)
+}
+
+func (s *chatService) setMuted(gameID, playerID uuid.UUID, muted bool) error {
+	view, err := s.gameService.GetGame(gameID, playerID)
+	if err != nil {
+		return err
+	}
+	if !view.Game.HasPlayer(playerID) {
+		return serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerNotInGame, "player is not in this game")
+	}
+	if err := s.chatRepo.SetMuted(gameID, playerID, muted); err != nil {
+		return fmt.Errorf("failed to update chat mute: %w", err)
+	}
+	s.notify(gameID)
+	return nil
+}
@@ -0,0 +1,25 @@
+package implemenatation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// NewClockScheduler периодически завершает партии с истёкшим временем,
+// даже если ни один из игроков не обращается к API
+func NewClockScheduler(gameService services.GameService, interval time.Duration, logger *logrus.Logger) *PeriodicRunner {
+	return NewPeriodicRunner("clock", interval, func() error {
+		flagged, err := gameService.FlagExpiredGames()
+		if flagged > 0 {
+			logger.WithField("games", flagged).Info("Finished games on time")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to flag expired games: %w", err)
+		}
+		return nil
+	}, logger)
+}
@@ -0,0 +1,160 @@
+package implemenatation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+// maxSeats - наибольшее число игроков в партии
+const maxSeats = 6
+
+func (s *gameService) Resign(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.applyAction(gameID, playerID, func(game *models.Game) error {
+		if err := requireTwoPlayers(game); err != nil {
+			return err
+		}
+
+		now := s.now().UTC()
+		status := enums.FirstPlayerWon
+		if playerID == game.FirstPlayerID {
+			status = enums.SecondPlayerWon
+		}
+
+		game.Raise(&models.PlayerResigned{PlayerID: playerID, ResignedAt: now})
+		game.Raise(&models.GameFinished{Status: status, Termination: enums.ResignTermination})
+		return nil
+	})
+}
+
+func (s *gameService) Abort(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.applyAction(gameID, playerID, func(game *models.Game) error {
+		if s.hasEveryPlayerMoved(game) {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeAbortNotAllowed,
+				"game can only be aborted before each player has moved")
+		}
+
+		game.Raise(&models.GameAborted{PlayerID: playerID, AbortedAt: s.now().UTC()})
+		game.Raise(&models.GameFinished{Status: enums.Aborted, Termination: enums.AbortTermination})
+		return nil
+	})
+}
+
+func (s *gameService) OfferDraw(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.applyAction(gameID, playerID, func(game *models.Game) error {
+		if err := requireTwoPlayers(game); err != nil {
+			return err
+		}
+
+		now := s.now().UTC()
+		if game.DrawOfferedBy != nil {
+			if *game.DrawOfferedBy == playerID {
+				return serviceErrors.NewInvalidOperationError(serviceErrors.CodeDrawAlreadyOffered,
+					"draw has already been offered")
+			}
+			// встречное предложение ничьей означает согласие
+			s.acceptDraw(game, playerID)
+			return nil
+		}
+
+		game.Raise(&models.DrawOffered{PlayerID: playerID, OfferedAt: now})
+		return nil
+	})
+}
+
+func (s *gameService) AcceptDraw(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.applyAction(gameID, playerID, func(game *models.Game) error {
+		if err := requireTwoPlayers(game); err != nil {
+			return err
+		}
+		if !hasDrawOfferFromOpponent(game, playerID) {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoDrawOffer, "opponent has not offered a draw")
+		}
+
+		s.acceptDraw(game, playerID)
+		return nil
+	})
+}
+
+func (s *gameService) DeclineDraw(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.applyAction(gameID, playerID, func(game *models.Game) error {
+		if err := requireTwoPlayers(game); err != nil {
+			return err
+		}
+		if !hasDrawOfferFromOpponent(game, playerID) {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoDrawOffer, "opponent has not offered a draw")
+		}
+
+		game.Raise(&models.DrawDeclined{PlayerID: playerID, DeclinedAt: s.now().UTC()})
+		return nil
+	})
+}
+
+// applyAction загружает активную партию игрока, применяет к ней действие и сохраняет её
+func (s *gameService) applyAction(gameID, playerID uuid.UUID, action func(game *models.Game) error) (*models.Game, error) {
+	return s.updateGame(gameID, playerID, requireInProgress, action)
+}
+
+func (s *gameService) updateGame(
+	gameID, playerID uuid.UUID,
+	precondition func(game *models.Game) error,
+	action func(game *models.Game) error,
+) (*models.Game, error) {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	game, err := s.loadGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := precondition(game); err != nil {
+		return nil, err
+	}
+
+	if !game.HasPlayer(playerID) {
+		return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerNotInGame, "player is not in this game")
+	}
+
+	if err := action(game); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveGame(game); err != nil {
+		return nil, fmt.Errorf("failed to update game: %w", err)
+	}
+	return game, nil
+}
+
+func requireInProgress(game *models.Game) error {
+	if game.Status != enums.InProgress {
+		return serviceErrors.NewInvalidOperationError(serviceErrors.CodeGameFinished, "game has already ended")
+	}
+	return nil
+}
+
+// requireTwoPlayers - сдача, ничья и возврат ходов определены только для партий на двоих
+func requireTwoPlayers(game *models.Game) error {
+	if game.IsMultiplayer() {
+		return serviceErrors.NewInvalidOperationError(serviceErrors.CodeTwoPlayerOnly,
+			"action is only available in two-player games")
+	}
+	return nil
+}
+
+func (s *gameService) acceptDraw(game *models.Game, playerID uuid.UUID) {
+	game.Raise(&models.DrawAccepted{PlayerID: playerID, AcceptedAt: s.now().UTC()})
+	game.Raise(&models.GameFinished{Status: enums.Draw, Termination: enums.AgreementTermination})
+}
+
+func (s *gameService) hasEveryPlayerMoved(game *models.Game) bool {
+	return game.TurnsPlayed() >= len(game.Players())
+}
+
+func hasDrawOfferFromOpponent(game *models.Game, playerID uuid.UUID) bool {
+	return game.DrawOfferedBy != nil && *game.DrawOfferedBy != playerID
+}
@@ -0,0 +1,60 @@
+package implemenatation
+
+import "math"
+
+// minCircularLineSize - на кольце меньшего размера все позиции соседние
+// и вариант вырождается
+const minCircularLineSize = 4
+
+// getCircularNailsSum считает минимальную длину нити для гвоздей на кольце
+// из lineSize позиций: каждый гвоздь должен быть привязан хотя бы к одному соседнему,
+// а расстояние между последним и первым гвоздём идёт через конец поля
+func getCircularNailsSum(nails []int, lineSize int) int {
+	n := len(nails)
+	if n < 2 {
+		// одиночный гвоздь привязать не к чему
+		return 0
+	}
+
+	gaps := make([]int, n-1)
+	for i := 1; i < n; i++ {
+		gaps[i-1] = nails[i] - nails[i-1]
+	}
+	wrap := lineSize - nails[n-1] + nails[0]
+
+	// либо отрезок через конец поля не натягивается и задача сводится к линейной,
+	// либо натягивается и крайние гвозди уже привязаны
+	withoutWrap := minThreadCover(gaps, false, false)
+	withWrap := wrap + minThreadCover(gaps, true, true)
+	return min(withoutWrap, withWrap)
+}
+
+// minThreadCover выбирает отрезки между соседними гвоздями минимальной суммарной длины
+// так, чтобы каждый гвоздь был концом хотя бы одного отрезка; firstTied и lastTied
+// отмечают крайние гвозди, уже привязанные снаружи
+func minThreadCover(gaps []int, firstTied, lastTied bool) int {
+	const inf = math.MaxInt / 2
+
+	// tied[c] - минимальная длина для уже пройденных гвоздей при условии,
+	// что текущий гвоздь привязан (c = 1) или ещё нет (c = 0)
+	var tied [2]int
+	if firstTied {
+		tied = [2]int{inf, 0}
+	} else {
+		tied = [2]int{0, inf}
+	}
+
+	for _, gap := range gaps {
+		next := [2]int{inf, inf}
+		// отрезок до следующего гвоздя не берём: текущий должен быть уже привязан
+		next[0] = tied[1]
+		// берём отрезок: привязаны оба гвоздя
+		next[1] = min(tied[0], tied[1]) + gap
+		tied = next
+	}
+
+	if lastTied {
+		return min(tied[0], tied[1])
+	}
+	return tied[1]
+}
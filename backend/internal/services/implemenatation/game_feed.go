@@ -0,0 +1,71 @@
+package implemenatation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	services "nails_game/internal/services/interfaces"
+)
+
+// gameFeed рассылает сигналы подписчикам в памяти процесса. Сигнал не несёт данных:
+// подписчик сам загружает то, что ему видно, поэтому пропущенный сигнал ничего не теряет
+type gameFeed struct {
+	// fogDelay - через сколько ходы партии fog открываются зрителям
+	fogDelay time.Duration
+
+	mutex       sync.Mutex
+	subscribers map[uuid.UUID]map[chan struct{}]struct{}
+}
+
+func NewGameFeed(fogDelay time.Duration) services.GameFeed {
+	return &gameFeed{
+		fogDelay:    fogDelay,
+		subscribers: make(map[uuid.UUID]map[chan struct{}]struct{}),
+	}
+}
+
+func (f *gameFeed) Subscribe(gameID uuid.UUID) (<-chan struct{}, func()) {
+	updates := make(chan struct{}, 1)
+	f.mutex.Lock()
+	if f.subscribers[gameID] == nil {
+		f.subscribers[gameID] = make(map[chan struct{}]struct{})
+	}
+	f.subscribers[gameID][updates] = struct{}{}
+	f.mutex.Unlock()
+
+	var once sync.Once
+	return updates, func() {
+		once.Do(func() {
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+			delete(f.subscribers[gameID], updates)
+			if len(f.subscribers[gameID]) == 0 {
+				delete(f.subscribers, gameID)
+			}
+		})
+	}
+}
+
+// Publish будит подписчиков сразу, а в партии fog - ещё раз, когда ход откроется зрителям
+func (f *gameFeed) Publish(game *models.Game, _ []models.GameEvent) {
+	f.Notify(game.ID)
+	if game.IsHidden() && f.fogDelay > 0 {
+		gameID := game.ID
+		time.AfterFunc(f.fogDelay, func() { f.Notify(gameID) })
+	}
+}
+
+func (f *gameFeed) Notify(gameID uuid.UUID) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for updates := range f.subscribers[gameID] {
+		// непрочитанный сигнал уже ждёт в канале
+		select {
+		case updates <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package implemenatation
+
+import (
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+// spectatorLine показывает зрителю партии в варианте fog всё поле, кроме гвоздей,
+// поставленных за последние FogSpectatorDelay
+func (s *gameService) spectatorLine(game *models.Game, events []models.RecordedGameEvent) []enums.PositionState {
+	line := append([]enums.PositionState(nil), game.Line...)
+	cutoff := s.now().UTC().Add(-s.policy.FogSpectatorDelay)
+	for _, recorded := range events {
+		if played, ok := recorded.Event.(*models.MovePlayed); ok && played.PlayedAt.After(cutoff) {
+			line[played.Position] = enums.Empty
+		}
+	}
+	return line
+}
+
+// withoutHiddenEvents скрывает из истории идущей партии в варианте fog расстановку
+// соперников: игрок видит только свои ходы и открытые гвозди, зритель - события
+// старше FogSpectatorDelay
+func (s *gameService) withoutHiddenEvents(events []models.RecordedGameEvent, viewerID uuid.UUID) []models.RecordedGameEvent {
+	hidden := false
+	participant := false
+	for _, recorded := range events {
+		switch e := recorded.Event.(type) {
+		case *models.GameCreated:
+			hidden = e.GameRules().HiddenNails
+			participant = viewerID != uuid.Nil && e.HasPlayer(viewerID)
+		case *models.GameFinished:
+			hidden = false
+		}
+	}
+	if !hidden {
+		return events
+	}
+
+	if participant {
+		visible := make([]models.RecordedGameEvent, 0, len(events))
+		for _, recorded := range events {
+			if played, ok := recorded.Event.(*models.MovePlayed); ok && played.PlayerID != viewerID {
+				continue
+			}
+			visible = append(visible, recorded)
+		}
+		return visible
+	}
+
+	cutoff := s.now().UTC().Add(-s.policy.FogSpectatorDelay)
+	visible := make([]models.RecordedGameEvent, 0, len(events))
+	for _, recorded := range events {
+		if recorded.OccurredAt.After(cutoff) {
+			break
+		}
+		visible = append(visible, recorded)
+	}
+	return visible
+}
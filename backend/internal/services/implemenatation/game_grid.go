@@ -0,0 +1,100 @@
+package implemenatation
+
+import (
+	"fmt"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+// minGridSide - меньшее поле вырождается в линию
+const minGridSide = 2
+
+// resolveGrid проверяет размеры двумерного поля и выводит из них число позиций.
+// Каждая сторона ограничена так, чтобы поле с другой стороной не меньше minGridSide
+// могло уложиться в maxLineSize; иначе произведение сторон может переполниться
+func resolveGrid(validation *serviceErrors.ValidationError, settings *models.GameSettings, maxLineSize int) {
+	grid := &settings.Grid
+	if grid.Metric == "" {
+		grid.Metric = enums.ManhattanMetric
+	}
+	if !grid.Metric.IsKnown() {
+		validation.Add("grid.metric", fmt.Sprintf("unknown metric %q", grid.Metric))
+	}
+	maxSide := max(maxLineSize/minGridSide, minGridSide)
+	if grid.Width < minGridSide || grid.Width > maxSide {
+		validation.Add("grid.width", fmt.Sprintf("must be between %d and %d", minGridSide, maxSide))
+	}
+	if grid.Height < minGridSide || grid.Height > maxSide {
+		validation.Add("grid.height", fmt.Sprintf("must be between %d and %d", minGridSide, maxSide))
+	}
+	if !settings.Board.IsEmpty() {
+		validation.Add("board", "irregular boards are not supported for the grid variant")
+	}
+	if validation.HasErrors() {
+		return
+	}
+
+	if settings.LineSize != 0 && settings.LineSize != grid.Size() {
+		validation.Add("line_size", "must match grid width times height")
+	}
+	settings.LineSize = grid.Size()
+}
+
+// resolveCell переводит клетку хода в позицию на двумерном поле
+func resolveCell(game *models.Game, move *models.Move) error {
+	grid, ok := game.Grid()
+	if !ok {
+		validation := serviceErrors.NewValidationError(serviceErrors.CodeInvalidPosition)
+		validation.Add("row", "cells are only supported for the grid variant")
+		return validation
+	}
+
+	position, ok := grid.Position(*move.Cell)
+	if !ok {
+		validation := serviceErrors.NewValidationError(serviceErrors.CodeInvalidPosition)
+		validation.Add("row", fmt.Sprintf("must be between 0 and %d", grid.Height-1))
+		validation.Add("col", fmt.Sprintf("must be between 0 and %d", grid.Width-1))
+		return validation
+	}
+	move.Position = position
+	return nil
+}
+
+// getGridThreadLength связывает каждый гвоздь игрока с ближайшим своим гвоздём
+// и возвращает суммарную длину нитей; общая нить двух гвоздей считается один раз
+func getGridThreadLength(game *models.Game, grid models.Grid, state enums.PositionState) float64 {
+	var nails []int
+	for i, pos := range game.Line {
+		if pos == state {
+			nails = append(nails, i)
+		}
+	}
+
+	type thread struct{ from, to int }
+	tied := make(map[thread]bool)
+	total := 0.0
+	for _, nail := range nails {
+		nearest, best := -1, 0.0
+		for _, other := range nails {
+			if other == nail {
+				continue
+			}
+			if d := grid.Distance(nail, other); nearest < 0 || d < best {
+				nearest, best = other, d
+			}
+		}
+		if nearest < 0 {
+			// одиночный гвоздь привязать не к чему
+			continue
+		}
+
+		t := thread{from: min(nail, nearest), to: max(nail, nearest)}
+		if !tied[t] {
+			tied[t] = true
+			total += best
+		}
+	}
+	return total
+}
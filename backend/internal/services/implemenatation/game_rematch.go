@@ -0,0 +1,157 @@
+package implemenatation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+func (s *gameService) OfferRematch(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.updateGame(gameID, playerID, requireRematchable, func(game *models.Game) error {
+		if game.RematchOfferedBy != nil {
+			if *game.RematchOfferedBy == playerID {
+				return serviceErrors.NewInvalidOperationError(serviceErrors.CodeRematchAlreadyOffered,
+					"rematch has already been offered")
+			}
+			// встречное предложение реванша означает согласие
+			_, err := s.acceptRematch(game, playerID)
+			return err
+		}
+
+		game.Raise(&models.RematchOffered{PlayerID: playerID, OfferedAt: s.now().UTC()})
+		return nil
+	})
+}
+
+func (s *gameService) AcceptRematch(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.updateGame(gameID, playerID, requireRematchable, func(game *models.Game) error {
+		if !hasRematchOfferFromOpponent(game, playerID) {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoRematchOffer, "opponent has not offered a rematch")
+		}
+		_, err := s.acceptRematch(game, playerID)
+		return err
+	})
+}
+
+func (s *gameService) DeclineRematch(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.updateGame(gameID, playerID, requireRematchable, func(game *models.Game) error {
+		if !hasRematchOfferFromOpponent(game, playerID) {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoRematchOffer, "opponent has not offered a rematch")
+		}
+		game.Raise(&models.RematchDeclined{PlayerID: playerID, DeclinedAt: s.now().UTC()})
+		return nil
+	})
+}
+
+func (s *gameService) CreateRematch(gameID uuid.UUID) (*models.Game, error) {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	game, err := s.loadGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.RematchGameID != nil {
+		return s.loadGame(*game.RematchGameID)
+	}
+	if err := requireRematchable(game); err != nil {
+		return nil, err
+	}
+
+	rematch, err := s.acceptRematch(game, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveGame(game); err != nil {
+		return nil, fmt.Errorf("failed to update game: %w", err)
+	}
+	return rematch, nil
+}
+
+// acceptRematch создаёт реванш с теми же правилами и сменой цвета и связывает с ним партию
+func (s *gameService) acceptRematch(game *models.Game, playerID uuid.UUID) (*models.Game, error) {
+	events, err := s.loadEvents(game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// у партий, перенесённых до event sourcing, события создания нет
+	rules := game.Rules
+	created := models.GameCreated{
+		LineSize:    len(game.Line),
+		Variant:     game.Variant,
+		Rules:       &rules,
+		TimeControl: game.TimeControl,
+		Rated:       game.Rated,
+		Private:     game.Private,
+	}
+	for _, recorded := range events {
+		if original, ok := recorded.Event.(*models.GameCreated); ok {
+			created = *original
+			break
+		}
+	}
+
+	now := s.now().UTC()
+	previousGameID := game.ID
+	created.FirstPlayerID = game.SecondPlayerID
+	created.SecondPlayerID = game.FirstPlayerID
+	created.Seats = []uuid.UUID{game.SecondPlayerID, game.FirstPlayerID}
+	created.CurrentPlayerID = game.SecondPlayerID
+	// фора остаётся за тем же игроком: его гвозди в расстановке меняют цвет вместе с ним
+	created.StartPosition = swapSeatStates(created.StartPosition)
+	created.PreviousGameID = &previousGameID
+	// реванш играется уже не в турнире
+	created.TournamentID = nil
+	created.CreatedAt = now
+
+	rematch := &models.Game{ID: uuid.New()}
+	rematch.Raise(&created)
+	if err := s.createGame(rematch); err != nil {
+		return nil, fmt.Errorf("failed to create rematch: %w", err)
+	}
+
+	game.Raise(&models.RematchAccepted{PlayerID: playerID, GameID: rematch.ID, AcceptedAt: now})
+	return rematch, nil
+}
+
+// swapSeatStates меняет местами гвозди первого и второго игрока в начальной расстановке
+func swapSeatStates(position []enums.PositionState) []enums.PositionState {
+	if len(position) == 0 {
+		return nil
+	}
+	swapped := make([]enums.PositionState, len(position))
+	for i, state := range position {
+		switch state {
+		case enums.FirstPlayer:
+			swapped[i] = enums.SecondPlayer
+		case enums.SecondPlayer:
+			swapped[i] = enums.FirstPlayer
+		default:
+			swapped[i] = state
+		}
+	}
+	return swapped
+}
+
+// requireRematchable - реванш возможен после окончания партии на двоих, если он ещё не создан
+func requireRematchable(game *models.Game) error {
+	if game.Status == enums.InProgress {
+		return serviceErrors.NewInvalidOperationError(serviceErrors.CodeGameNotFinished, "game is still in progress")
+	}
+	if err := requireTwoPlayers(game); err != nil {
+		return err
+	}
+	if game.RematchGameID != nil {
+		return serviceErrors.NewInvalidOperationError(serviceErrors.CodeRematchExists, "rematch has already been created")
+	}
+	return nil
+}
+
+func hasRematchOfferFromOpponent(game *models.Game, playerID uuid.UUID) bool {
+	return game.RematchOfferedBy != nil && *game.RematchOfferedBy != playerID
+}
@@ -7,58 +7,169 @@ import (
 	"nails_game/internal/models"
 	"nails_game/internal/models/enums"
 	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
 	services "nails_game/internal/services/interfaces"
+	"sort"
 	"sync"
+	"time"
 )
 
 type gameService struct {
 	gameRepo   repositories.GameRepository
 	playerRepo repositories.PlayerRepository
-	lineSize   int
+	boardRepo  repositories.BoardPresetRepository
+	policy     services.GameSettingsPolicy
+	// publishers получают сохранённые события партий, например потоки партий
+	publishers []services.GameEventPublisher
+	// outbox готовит доставки вебхуков, которые сохраняются вместе с событиями
+	outbox services.GameEventOutbox
 
+	now func() time.Time
+
+	// cacheMutex также сериализует все изменения партий в пределах процесса
 	cacheMutex sync.RWMutex
 	cache      map[string]services.CachedMoveResult
 }
 
+type Option func(*gameService)
+
+// WithClock подменяет источник текущего времени, используется в тестах
+func WithClock(now func() time.Time) Option {
+	return func(s *gameService) {
+		s.now = now
+	}
+}
+
+// WithEventPublisher передаёт сохранённые события партий подписчику, например потокам партий;
+// опцию можно передать несколько раз
+func WithEventPublisher(publisher services.GameEventPublisher) Option {
+	return func(s *gameService) {
+		s.publishers = append(s.publishers, publisher)
+	}
+}
+
+// WithEventOutbox сохраняет вместе с событиями партий доставки вебхуков, подготовленные outbox
+func WithEventOutbox(outbox services.GameEventOutbox) Option {
+	return func(s *gameService) {
+		s.outbox = outbox
+	}
+}
+
 func NewGameService(
 	gameRepo repositories.GameRepository,
 	playerRepo repositories.PlayerRepository,
-	lineSize int,
+	boardRepo repositories.BoardPresetRepository,
+	policy services.GameSettingsPolicy,
+	opts ...Option,
 ) services.GameService {
-	return &gameService{
+	s := &gameService{
 		gameRepo:   gameRepo,
 		playerRepo: playerRepo,
-		lineSize:   lineSize,
+		boardRepo:  boardRepo,
+		policy:     policy,
+		now:        time.Now,
 		cache:      make(map[string]services.CachedMoveResult),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func (s *gameService) CreateGame(lineSize int, firstPlayerID, secondPlayerID uuid.UUID) (*models.Game, error) {
-	if _, err := s.playerRepo.GetByID(firstPlayerID); err != nil {
-		return nil, fmt.Errorf("first player not found: %w", err)
-	}
-	if _, err := s.playerRepo.GetByID(secondPlayerID); err != nil {
-		return nil, fmt.Errorf("second player not found: %w", err)
-	}
+// CheckSettings проверяет параметры партии на двоих, не создавая её
+func (s *gameService) CheckSettings(settings models.GameSettings) error {
+	_, err := s.resolveSettings(settings, nil)
+	return err
+}
 
-	game := &models.Game{
-		ID:              uuid.New(),
-		Line:            make([]enums.PositionState, lineSize),
-		Status:          enums.InProgress,
-		CurrentPlayerID: firstPlayerID,
-		FirstPlayerID:   firstPlayerID,
-		SecondPlayerID:  secondPlayerID,
-		MoveCount:       0,
+func (s *gameService) CreateGame(settings models.GameSettings, playerIDs []uuid.UUID) (*models.Game, error) {
+	settings, err := s.resolveSettings(settings, playerIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.gameRepo.Create(game); err != nil {
+	game := &models.Game{ID: uuid.New()}
+	game.Raise(&models.GameCreated{
+		LineSize:        settings.LineSize,
+		Coordinates:     settings.Board.Coordinates,
+		Width:           settings.Grid.Width,
+		Metric:          settings.Grid.Metric,
+		BoardPreset:     settings.Board.Preset,
+		BoardSeed:       settings.Board.Seed,
+		Variant:         settings.Variant,
+		Rules:           &settings.Rules,
+		TimeControl:     settings.TimeControl,
+		Rated:           settings.Rated,
+		Private:         settings.Private,
+		FirstPlayerID:   playerIDs[0],
+		SecondPlayerID:  playerIDs[1],
+		Seats:           playerIDs,
+		Schedule:        settings.Schedule,
+		StartPosition:   settings.StartPosition,
+		CurrentPlayerID: playerIDs[0],
+		TournamentID:    settings.TournamentID,
+		CreatedAt:       s.now().UTC(),
+	})
+
+	if err := s.createGame(game); err != nil {
 		return nil, fmt.Errorf("failed to create game: %w", err)
 	}
 
 	return game, nil
 }
 
+// createGame сохраняет новую партию и после успешной записи передаёт её события подписчику
+func (s *gameService) createGame(game *models.Game) error {
+	events := game.PendingEvents()
+	deliveries, err := s.prepareDeliveries(game, events)
+	if err != nil {
+		return err
+	}
+	if err := s.gameRepo.Create(game, deliveries...); err != nil {
+		return err
+	}
+	s.publish(game, events)
+	return nil
+}
+
+// saveGame сохраняет новые события партии и после успешной записи передаёт их подписчику
+func (s *gameService) saveGame(game *models.Game) error {
+	events := game.PendingEvents()
+	deliveries, err := s.prepareDeliveries(game, events)
+	if err != nil {
+		return err
+	}
+	if err := s.gameRepo.Update(game, deliveries...); err != nil {
+		return err
+	}
+	s.publish(game, events)
+	return nil
+}
+
+func (s *gameService) prepareDeliveries(game *models.Game, events []models.GameEvent) ([]models.WebhookDelivery, error) {
+	if s.outbox == nil || len(events) == 0 {
+		return nil, nil
+	}
+	deliveries, err := s.outbox.Outbox(game, events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (s *gameService) publish(game *models.Game, events []models.GameEvent) {
+	if len(events) == 0 {
+		return
+	}
+	for _, publisher := range s.publishers {
+		publisher.Publish(game, events)
+	}
+}
+
 func (s *gameService) MakeMove(move models.Move) (*services.CachedMoveResult, error) {
+	if move.Type == "" {
+		move.Type = enums.PlaceMove
+	}
 	cacheKey := s.generateCacheKey(move)
 
 	s.cacheMutex.RLock()
@@ -78,42 +189,123 @@ func (s *gameService) MakeMove(move models.Move) (*services.CachedMoveResult, er
 		return &cached, nil
 	}
 
-	game, err := s.gameRepo.GetByID(move.GameID)
+	game, err := s.loadGame(move.GameID)
 	if err != nil {
-		return nil, fmt.Errorf("game not found: %w", err)
+		return nil, err
 	}
 
-	if move.Position < 0 || move.Position >= len(game.Line) {
-		return nil, errors.New("invalid move position")
+	switch move.Type {
+	case enums.PlaceMove:
+		if move.Cell != nil {
+			if err := resolveCell(game, &move); err != nil {
+				return nil, err
+			}
+		}
+		if move.Position < 0 || move.Position >= len(game.Line) {
+			validation := serviceErrors.NewValidationError(serviceErrors.CodeInvalidPosition)
+			validation.Add("position", fmt.Sprintf("must be between 0 and %d", len(game.Line)-1))
+			return nil, validation
+		}
+	case enums.SwapMove:
+	default:
+		validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+		validation.Add("type", fmt.Sprintf("must be %s or %s", enums.PlaceMove, enums.SwapMove))
+		return nil, validation
 	}
 
 	if game.Status != enums.InProgress {
-		return nil, errors.New("game has already ended")
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeGameFinished, "game has already ended")
+	}
+
+	if !game.HasPlayer(move.PlayerID) {
+		return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerNotInGame, "player is not in this game")
 	}
 
-	if game.FirstPlayerID != move.PlayerID && game.SecondPlayerID != move.PlayerID {
-		return nil, errors.New("player is not in this game")
+	if game.Rules.TurnOrder == enums.SimultaneousTurns {
+		return s.commitMove(game, move)
 	}
 
 	if game.CurrentPlayerID != move.PlayerID {
-		return nil, errors.New("not this player's turn")
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeNotYourTurn, "not this player's turn")
+	}
+
+	// в варианте fog попытка занять позицию соперника открывает её и стоит хода
+	reveal := move.Type == enums.PlaceMove && game.IsHiddenFrom(move.Position, move.PlayerID)
+
+	// ход переходит к следующему игроку, только когда исчерпана норма гвоздей на этот ход
+	nextPlayerID := move.PlayerID
+	turnEnds := move.Type == enums.SwapMove || reveal || game.PlacementsLeft() <= 1
+	if turnEnds {
+		nextPlayerID = s.getNextPlayerID(game)
+	}
+
+	now := s.now().UTC()
+	var clock time.Duration
+	if !game.TimeControl.IsUnlimited() {
+		remaining := game.RemainingTime(move.PlayerID, now)
+		if remaining <= 0 {
+			if err := s.flag(game, move.PlayerID, now); err != nil {
+				return nil, err
+			}
+			return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeTimeExpired, "player has run out of time")
+		}
+		clock = remaining
+		if turnEnds {
+			clock = game.TimeControl.AfterMove(remaining)
+		}
 	}
 
-	if game.Line[move.Position] != enums.Empty {
-		return nil, errors.New("position is already taken")
+	if move.Type == enums.SwapMove {
+		if !canSwapSides(game, move.PlayerID) {
+			return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeSwapNotAllowed,
+				"sides can only be swapped by the second player right after the first nail in the pie variant")
+		}
+	} else if game.Line[move.Position] == enums.Blocked {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodePositionBlocked, "position is blocked")
+	} else if game.Line[move.Position] != enums.Empty && !reveal {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodePositionTaken, "position is already taken")
 	}
 
-	if move.PlayerID == game.FirstPlayerID {
-		game.Line[move.Position] = enums.FirstPlayer
-	} else {
-		game.Line[move.Position] = enums.SecondPlayer
+	if hasDrawOfferFromOpponent(game, move.PlayerID) {
+		game.Raise(&models.DrawDeclined{PlayerID: move.PlayerID, Implicit: true, DeclinedAt: now})
+	}
+	if game.TakebackRequestedBy != nil && *game.TakebackRequestedBy != move.PlayerID {
+		game.Raise(&models.TakebackDeclined{PlayerID: move.PlayerID, Implicit: true, DeclinedAt: now})
 	}
 
-	game.MoveCount++
-	game.CurrentPlayerID = s.getNextPlayerID(game)
-	game.Status = s.checkGameStatus(game)
+	switch {
+	case move.Type == enums.SwapMove:
+		// смена сторон не меняет поле, поэтому завершить партию не может
+		game.Raise(&models.SidesSwapped{PlayerID: move.PlayerID, ClockMs: clock.Milliseconds(), SwappedAt: now})
+	case reveal:
+		game.Raise(&models.NailRevealed{
+			PlayerID:     move.PlayerID,
+			Position:     move.Position,
+			NextPlayerID: nextPlayerID,
+			ClockMs:      clock.Milliseconds(),
+			RevealedAt:   now,
+		})
+	default:
+		game.Raise(&models.MovePlayed{
+			PlayerID:     move.PlayerID,
+			Position:     move.Position,
+			State:        enums.SeatState(game.Seat(move.PlayerID)),
+			NextPlayerID: nextPlayerID,
+			ClockMs:      clock.Milliseconds(),
+			PlayedAt:     now,
+		})
+
+		if status, scores, ranking := s.checkGameStatus(game); status != enums.InProgress {
+			game.Raise(&models.GameFinished{
+				Status:      status,
+				Termination: enums.NormalTermination,
+				Scores:      scores,
+				Ranking:     ranking,
+			})
+		}
+	}
 
-	if err := s.gameRepo.Update(game); err != nil {
+	if err := s.saveGame(game); err != nil {
 		return nil, fmt.Errorf("failed to update game: %w", err)
 	}
 
@@ -136,18 +328,182 @@ func (s *gameService) MakeMove(move models.Move) (*services.CachedMoveResult, er
 	return &result, nil
 }
 
-func (s *gameService) GetGame(gameID uuid.UUID) (*models.Game, error) {
-	return s.gameRepo.GetByID(gameID)
+// ListVariants возвращает варианты, разрешённые политикой сервера
+func (s *gameService) ListVariants() []models.VariantDefinition {
+	var variants []models.VariantDefinition
+	for _, entry := range variantRegistry {
+		if containsVariant(s.policy.AllowedVariants, entry.definition.Name) {
+			variants = append(variants, entry.describe())
+		}
+	}
+	return variants
 }
 
-func (s *gameService) getNextPlayerID(game *models.Game) uuid.UUID {
-	if game.CurrentPlayerID == game.FirstPlayerID {
-		return game.SecondPlayerID
+func (s *gameService) GetGame(gameID, viewerID uuid.UUID) (*services.GameView, error) {
+	game, err := s.loadGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkViewer(game.Private, game.HasPlayer(viewerID), viewerID); err != nil {
+		return nil, err
+	}
+	if !game.IsHidden() || game.HasPlayer(viewerID) {
+		return &services.GameView{Game: game, Line: game.LineSeenBy(viewerID)}, nil
+	}
+
+	events, err := s.loadEvents(gameID)
+	if err != nil {
+		return nil, err
+	}
+	return &services.GameView{Game: game, Line: s.spectatorLine(game, events)}, nil
+}
+
+func (s *gameService) GetGameEvents(gameID, viewerID uuid.UUID) ([]models.RecordedGameEvent, error) {
+	events, err := s.loadEvents(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if created := gameCreated(events); created != nil {
+		if err := checkViewer(created.Private, created.HasPlayer(viewerID), viewerID); err != nil {
+			return nil, err
+		}
+	}
+	return withoutSealedCommits(s.withoutHiddenEvents(events, viewerID)), nil
+}
+
+func (s *gameService) ListLiveGames(filter models.LiveGameFilter) ([]models.Game, error) {
+	games, err := s.gameRepo.ListLive(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list live games: %w", err)
+	}
+	return games, nil
+}
+
+func (s *gameService) LoadGame(gameID uuid.UUID) (*models.Game, error) {
+	return s.loadGame(gameID)
+}
+
+// checkViewer пускает в закрытую партию только её игроков
+func checkViewer(private, player bool, viewerID uuid.UUID) error {
+	if !private {
+		return nil
+	}
+	if viewerID == uuid.Nil {
+		return serviceErrors.NewUnauthenticatedError(serviceErrors.CodeAuthenticationRequired, "private game requires a player token")
+	}
+	if !player {
+		return serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerNotInGame, "private game is visible only to its players")
+	}
+	return nil
+}
+
+// gameCreated возвращает событие создания партии или nil, если его нет
+func gameCreated(events []models.RecordedGameEvent) *models.GameCreated {
+	for _, recorded := range events {
+		if created, ok := recorded.Event.(*models.GameCreated); ok {
+			return created
+		}
+	}
+	return nil
+}
+
+// FlagExpiredGames завершает партии, в которых у игрока на ходу закончилось время
+func (s *gameService) FlagExpiredGames() (int, error) {
+	now := s.now().UTC()
+	gameIDs, err := s.gameRepo.ListExpired(now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired games: %w", err)
+	}
+
+	// ошибка одной партии не должна оставлять остальные без флажка
+	flagged := 0
+	var errs []error
+	for _, gameID := range gameIDs {
+		ok, err := s.flagExpired(gameID, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("game %s: %w", gameID, err))
+			continue
+		}
+		if ok {
+			flagged++
+		}
+	}
+	return flagged, errors.Join(errs...)
+}
+
+// flagExpired завершает партию, если время на ход всё ещё истекло; блокировка
+// держится только на время одной партии
+func (s *gameService) flagExpired(gameID uuid.UUID, now time.Time) (bool, error) {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	game, err := s.loadGame(gameID)
+	if err != nil {
+		return false, err
+	}
+	if game.Status != enums.InProgress || game.TurnDeadline == nil || game.TurnDeadline.After(now) {
+		return false, nil
+	}
+	if err := s.flag(game, game.CurrentPlayerID, now); err != nil {
+		return false, err
 	}
-	return game.FirstPlayerID
+	return true, nil
 }
 
-func (s *gameService) checkGameStatus(game *models.Game) enums.GameStatus {
+func (s *gameService) flag(game *models.Game, playerID uuid.UUID, now time.Time) error {
+	status := enums.FirstPlayerWon
+	if playerID == game.FirstPlayerID {
+		status = enums.SecondPlayerWon
+	}
+
+	game.Raise(&models.ClockFlagged{PlayerID: playerID, FlaggedAt: now})
+	game.Raise(&models.GameFinished{Status: status, Termination: enums.TimeoutTermination})
+
+	if err := s.saveGame(game); err != nil {
+		return fmt.Errorf("failed to update game: %w", err)
+	}
+	return nil
+}
+
+func (s *gameService) loadEvents(gameID uuid.UUID) ([]models.RecordedGameEvent, error) {
+	events, err := s.gameRepo.GetEvents(gameID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGameNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeGameNotFound, "game not found")
+		}
+		return nil, fmt.Errorf("failed to load game events: %w", err)
+	}
+	return events, nil
+}
+
+func (s *gameService) loadGame(gameID uuid.UUID) (*models.Game, error) {
+	game, err := s.gameRepo.GetByID(gameID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGameNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeGameNotFound, "game not found")
+		}
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+	return game, nil
+}
+
+// getNextPlayerID передаёт ход следующему месту по кругу
+func (s *gameService) getNextPlayerID(game *models.Game) uuid.UUID {
+	players := game.Players()
+	return players[(game.Seat(game.CurrentPlayerID)+1)%len(players)]
+}
+
+// canSwapSides - правило пирога: второй игрок вместо первого ответного хода
+// может забрать себе гвоздь соперника и право первого игрока
+func canSwapSides(game *models.Game, playerID uuid.UUID) bool {
+	return game.Rules.PieRule && game.MoveCount == 1 && playerID == game.SecondPlayerID
+}
+
+// checkGameStatus определяет итог партии после заполнения поля (закрытые позиции
+// считаются заполненными и в нити не входят): длину нитей каждого
+// места и игроков от победителя к последнему месту. Побеждает самая длинная нить
+// (по правилу shortest_thread - самая короткая), при равенстве выше стоит более позднее место
+func (s *gameService) checkGameStatus(game *models.Game) (enums.GameStatus, []float64, []uuid.UUID) {
 	allPosTaken := true
 	for _, pos := range game.Line {
 		if pos == enums.Empty {
@@ -157,26 +513,61 @@ func (s *gameService) checkGameStatus(game *models.Game) enums.GameStatus {
 	}
 
 	if !allPosTaken {
-		return enums.InProgress
+		return enums.InProgress, nil, nil
+	}
+
+	players := game.Players()
+	scores := make([]float64, len(players))
+	seats := make([]int, len(players))
+	for seat := range players {
+		scores[seat] = s.getThreadLength(game, enums.SeatState(seat))
+		seats[seat] = seat
 	}
 
-	firstPlayerSum := s.getNailsSum(game, enums.FirstPlayer)
-	secondPlayerSum := s.getNailsSum(game, enums.SecondPlayer)
+	sort.Slice(seats, func(i, j int) bool {
+		a, b := seats[i], seats[j]
+		if scores[a] != scores[b] {
+			if game.Rules.Scoring == enums.ShortestThreadWins {
+				return scores[a] < scores[b]
+			}
+			return scores[a] > scores[b]
+		}
+		return a > b
+	})
+	ranking := make([]uuid.UUID, len(seats))
+	for place, seat := range seats {
+		ranking[place] = players[seat]
+	}
+
+	switch {
+	case game.IsMultiplayer():
+		return enums.Finished, scores, ranking
+	case seats[0] == 0:
+		return enums.FirstPlayerWon, scores, ranking
+	default:
+		return enums.SecondPlayerWon, scores, ranking
+	}
+}
 
-	if firstPlayerSum > secondPlayerSum {
-		return enums.FirstPlayerWon
+func (s *gameService) getThreadLength(game *models.Game, state enums.PositionState) float64 {
+	if grid, ok := game.Grid(); ok {
+		return getGridThreadLength(game, grid, state)
 	}
-	return enums.SecondPlayerWon
+	return float64(s.getNailsSum(game, state))
 }
 
 func (s *gameService) getNailsSum(game *models.Game, state enums.PositionState) int {
 	var nails []int
 	for i, pos := range game.Line {
 		if pos == state {
-			nails = append(nails, i)
+			nails = append(nails, game.Coordinate(i))
 		}
 	}
 
+	if game.Rules.Topology == enums.RingTopology {
+		return getCircularNailsSum(nails, len(game.Line))
+	}
+
 	n := len(nails)
 	if n == 0 {
 		return 0
@@ -201,5 +592,8 @@ func (s *gameService) getNailsSum(game *models.Game, state enums.PositionState)
 }
 
 func (s *gameService) generateCacheKey(move models.Move) string {
-	return fmt.Sprintf("move:%s:%s:%d", move.GameID, move.PlayerID, move.Position)
+	if move.Cell != nil {
+		return fmt.Sprintf("move:%s:%s:%s:%d:%d", move.GameID, move.PlayerID, move.Type, move.Cell.Row, move.Cell.Col)
+	}
+	return fmt.Sprintf("move:%s:%s:%s:%d", move.GameID, move.PlayerID, move.Type, move.Position)
 }
@@ -0,0 +1,334 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+// resolveSettings подставляет значения по умолчанию и проверяет параметры партии
+// по политике сервера; все найденные проблемы возвращаются одной ошибкой.
+// Без playerIDs состав не проверяется, а партия считается партией на двоих
+func (s *gameService) resolveSettings(
+	settings models.GameSettings,
+	playerIDs []uuid.UUID,
+) (models.GameSettings, error) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+
+	if settings.Variant == "" {
+		settings.Variant = enums.StandardVariant
+	}
+	settings.Rules = defaultRules()
+	if entry, ok := findVariant(settings.Variant); ok && containsVariant(s.policy.AllowedVariants, settings.Variant) {
+		applyParameters(validation, entry, &settings)
+		settings.Rules = resolveRules(validation, entry.definition, &settings)
+	} else {
+		validation.Add("variant", fmt.Sprintf("variant %q is not allowed", settings.Variant))
+	}
+	rules := settings.Rules
+
+	coordinates, err := s.resolveBoard(validation, settings.Board)
+	if err != nil {
+		return settings, err
+	}
+	if coordinates != nil {
+		if settings.LineSize != 0 && settings.LineSize != len(coordinates) {
+			validation.Add("line_size", "must match the number of board coordinates")
+		}
+		settings.LineSize = len(coordinates)
+	}
+	settings.Schedule = resolveSchedule(validation, settings)
+	if rules.Topology == enums.GridTopology {
+		resolveGrid(validation, &settings, s.policy.MaxLineSize)
+	} else if settings.Grid != (models.Grid{}) {
+		validation.Add("grid", "is only supported for the grid variant")
+	}
+	if settings.LineSize == 0 {
+		settings.LineSize = len(settings.StartPosition)
+	}
+
+	if settings.LineSize == 0 {
+		settings.LineSize = s.policy.DefaultLineSize
+	}
+	if settings.TimeControl.Type == "" {
+		settings.TimeControl.Type = enums.UnlimitedTimeControl
+	}
+
+	if settings.LineSize < s.policy.MinLineSize || settings.LineSize > s.policy.MaxLineSize {
+		validation.Add("line_size", fmt.Sprintf("must be between %d and %d", s.policy.MinLineSize, s.policy.MaxLineSize))
+	} else if settings.Board.Seed != nil {
+		coordinates = generateCoordinates(*settings.Board.Seed, settings.LineSize, s.policy.MaxCoordinateGap)
+	}
+	settings.Board.Coordinates = coordinates
+	if rules.Topology == enums.RingTopology {
+		if settings.LineSize < minCircularLineSize {
+			validation.Add("line_size", fmt.Sprintf("must be at least %d for the circular variant", minCircularLineSize))
+		}
+		if !settings.Board.IsEmpty() {
+			validation.Add("board", "irregular boards are not supported for the circular variant")
+		}
+	}
+	if rules.TurnOrder == enums.SimultaneousTurns && settings.TimeControl.Type != enums.UnlimitedTimeControl {
+		validation.Add("timeControl.type", "the simultaneous variant must be unlimited")
+	}
+	if !containsTimeControl(s.policy.AllowedTimeControls, settings.TimeControl.Type) {
+		validation.Add("timeControl.type", fmt.Sprintf("time control %q is not allowed", settings.TimeControl.Type))
+	} else {
+		settings.TimeControl = validateTimeControl(validation, settings.TimeControl)
+	}
+
+	if settings.Private && !s.policy.AllowPrivateGames {
+		validation.Add("private", "private games require player tokens to be enabled on the server")
+	}
+
+	seats := 2
+	if playerIDs != nil {
+		if err := s.checkPlayers(validation, settings, playerIDs); err != nil {
+			return settings, err
+		}
+		seats = len(playerIDs)
+	}
+	settings.StartPosition = resolveStartPosition(validation, settings, seats)
+
+	if validation.HasErrors() {
+		return settings, validation
+	}
+	return settings, nil
+}
+
+// resolveRules берёт правила варианта и применяет к ним параметры запроса
+func resolveRules(
+	validation *serviceErrors.ValidationError,
+	definition models.VariantDefinition,
+	settings *models.GameSettings,
+) models.Rules {
+	rules := definition.Rules
+	if settings.Schedule == nil {
+		settings.Schedule = definition.Schedule
+	}
+	if settings.Scoring != "" {
+		if settings.Scoring.IsKnown() {
+			rules.Scoring = settings.Scoring
+		} else {
+			validation.Add("parameters.scoring", fmt.Sprintf("unknown scoring rule %q", settings.Scoring))
+		}
+	}
+	return rules
+}
+
+// maxNailsPerTurn и maxScheduleLength ограничивают расписание гвоздей за ход
+const (
+	maxNailsPerTurn   = 5
+	maxScheduleLength = 32
+)
+
+// resolveSchedule проверяет расписание гвоздей за ход; расписание из одних единиц
+// совпадает с обычной игрой и не сохраняется
+func resolveSchedule(validation *serviceErrors.ValidationError, settings models.GameSettings) []int {
+	if len(settings.Schedule) > maxScheduleLength {
+		validation.Add("schedule", fmt.Sprintf("must have at most %d entries", maxScheduleLength))
+		return nil
+	}
+
+	custom := false
+	for _, nails := range settings.Schedule {
+		if nails < 1 || nails > maxNailsPerTurn {
+			validation.Add("schedule", fmt.Sprintf("each turn must place between 1 and %d nails", maxNailsPerTurn))
+			return nil
+		}
+		custom = custom || nails > 1
+	}
+	if !custom {
+		return nil
+	}
+
+	if settings.Rules.PieRule || settings.Rules.TurnOrder == enums.SimultaneousTurns {
+		validation.Add("schedule", fmt.Sprintf("the %s variant needs one nail per turn", settings.Variant))
+	}
+	return settings.Schedule
+}
+
+// resolveStartPosition проверяет начальную расстановку: на каждой позиции свободно,
+// стоит гвоздь одного из игроков партии или позиция закрыта. Хотя бы одна позиция
+// должна остаться свободной; расстановка без гвоздей и закрытых позиций не сохраняется
+func resolveStartPosition(
+	validation *serviceErrors.ValidationError,
+	settings models.GameSettings,
+	players int,
+) []enums.PositionState {
+	if settings.StartPosition == nil {
+		return nil
+	}
+	if len(settings.StartPosition) != settings.LineSize {
+		validation.Add("startPosition", "must have one entry per position")
+		return nil
+	}
+
+	empty := 0
+	for i, state := range settings.StartPosition {
+		switch {
+		case state == enums.Empty:
+			empty++
+		case state == enums.Blocked:
+		case state < enums.SeatState(0) || state > enums.SeatState(players-1):
+			validation.Add(fmt.Sprintf("startPosition[%d]", i),
+				fmt.Sprintf("must be %d (blocked), %d (empty) or a seat from 1 to %d", enums.Blocked, enums.Empty, players))
+		}
+	}
+	if empty == 0 {
+		validation.Add("startPosition", "must leave at least one empty position")
+	}
+	if empty == len(settings.StartPosition) {
+		return nil
+	}
+	return settings.StartPosition
+}
+
+// resolveBoard возвращает координаты из пресета или заданные явно;
+// поле по зерну строится позже, когда известен размер
+func (s *gameService) resolveBoard(validation *serviceErrors.ValidationError, board models.BoardLayout) ([]int, error) {
+	specified := 0
+	for _, set := range []bool{board.Preset != "", board.Coordinates != nil, board.Seed != nil} {
+		if set {
+			specified++
+		}
+	}
+	if specified > 1 {
+		validation.Add("board", "specify only one of preset, coordinates or seed")
+		return nil, nil
+	}
+
+	switch {
+	case board.Preset != "":
+		preset, err := s.boardRepo.GetByName(board.Preset)
+		if err != nil {
+			if errors.Is(err, repositories.ErrBoardPresetNotFound) {
+				validation.Add("board.preset", "preset not found")
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to load board preset: %w", err)
+		}
+		return preset.Coordinates, nil
+	case board.Coordinates != nil:
+		validateCoordinates(validation, "board.coordinates", board.Coordinates)
+		return board.Coordinates, nil
+	}
+	return nil, nil
+}
+
+// checkPlayers проверяет состав партии: двое игроков приходят как firstPlayerId
+// и secondPlayerId, от трёх до maxSeats - списком playerIds
+func (s *gameService) checkPlayers(
+	validation *serviceErrors.ValidationError,
+	settings models.GameSettings,
+	playerIDs []uuid.UUID,
+) error {
+	if len(playerIDs) == 2 {
+		if err := s.checkPlayerExists(validation, "firstPlayerId", playerIDs[0]); err != nil {
+			return err
+		}
+		if err := s.checkPlayerExists(validation, "secondPlayerId", playerIDs[1]); err != nil {
+			return err
+		}
+		if playerIDs[0] == playerIDs[1] {
+			validation.Add("secondPlayerId", "a player cannot play against themselves")
+		}
+		return nil
+	}
+
+	if len(playerIDs) < 2 || len(playerIDs) > maxSeats {
+		validation.Add("playerIds", fmt.Sprintf("must contain between 2 and %d players", maxSeats))
+		return nil
+	}
+
+	seated := make(map[uuid.UUID]bool, len(playerIDs))
+	for i, playerID := range playerIDs {
+		field := fmt.Sprintf("playerIds[%d]", i)
+		if err := s.checkPlayerExists(validation, field, playerID); err != nil {
+			return err
+		}
+		if seated[playerID] && playerID != uuid.Nil {
+			validation.Add(field, "player is already seated")
+		}
+		seated[playerID] = true
+	}
+
+	// часы, ничьи и правило пирога рассчитаны на двоих
+	if settings.TimeControl.Type != enums.UnlimitedTimeControl {
+		validation.Add("timeControl.type", "games with more than two players must be unlimited")
+	}
+	if settings.Rules.PieRule {
+		validation.Add("variant", "the pie rule needs exactly two players")
+	}
+	if settings.LineSize < 2*len(playerIDs) {
+		validation.Add("line_size", "must give each player at least two positions")
+	}
+	return nil
+}
+
+func (s *gameService) checkPlayerExists(validation *serviceErrors.ValidationError, field string, playerID uuid.UUID) error {
+	if playerID == uuid.Nil {
+		validation.Add(field, "is required")
+		return nil
+	}
+
+	if _, err := s.playerRepo.GetByID(playerID); err != nil {
+		if errors.Is(err, repositories.ErrPlayerNotFound) {
+			validation.Add(field, "player not found")
+			return nil
+		}
+		return fmt.Errorf("failed to load player: %w", err)
+	}
+	return nil
+}
+
+// validateTimeControl проверяет параметры выбранного контроля времени
+// и обнуляет параметры, не относящиеся к нему
+func validateTimeControl(validation *serviceErrors.ValidationError, tc models.TimeControl) models.TimeControl {
+	switch tc.Type {
+	case enums.FischerTimeControl:
+		if tc.BaseSeconds <= 0 {
+			validation.Add("timeControl.baseSeconds", "must be positive")
+		}
+		if tc.IncrementSeconds < 0 {
+			validation.Add("timeControl.incrementSeconds", "must not be negative")
+		}
+		return models.TimeControl{Type: tc.Type, BaseSeconds: tc.BaseSeconds, IncrementSeconds: tc.IncrementSeconds}
+	case enums.PerMoveTimeControl:
+		if tc.MoveSeconds <= 0 {
+			validation.Add("timeControl.moveSeconds", "must be positive")
+		}
+		return models.TimeControl{Type: tc.Type, MoveSeconds: tc.MoveSeconds}
+	case enums.CorrespondenceTimeControl:
+		if tc.DaysPerMove <= 0 {
+			validation.Add("timeControl.daysPerMove", "must be positive")
+		}
+		return models.TimeControl{Type: tc.Type, DaysPerMove: tc.DaysPerMove}
+	default:
+		return models.TimeControl{Type: enums.UnlimitedTimeControl}
+	}
+}
+
+func containsVariant(allowed []enums.Variant, variant enums.Variant) bool {
+	for _, v := range allowed {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTimeControl(allowed []enums.TimeControlType, timeControl enums.TimeControlType) bool {
+	for _, tc := range allowed {
+		if tc == timeControl {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package implemenatation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// commitMove принимает тайный выбор позиции в варианте simultaneous. Когда выбрали
+// все игроки, раунд открывается: гвозди ставятся одновременно, а позиции, выбранные
+// несколькими игроками, закрываются - так каждый раунд занимает хотя бы одну позицию
+// и партия всегда доходит до заполненного поля
+func (s *gameService) commitMove(game *models.Game, move models.Move) (*services.CachedMoveResult, error) {
+	if move.Type != enums.PlaceMove {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeSwapNotAllowed,
+			"sides can only be swapped in the pie variant")
+	}
+	if game.HasCommitted(move.PlayerID) {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeAlreadyCommitted,
+			"player has already chosen a position this round")
+	}
+	switch game.Line[move.Position] {
+	case enums.Empty:
+	case enums.Blocked:
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodePositionBlocked, "position is blocked")
+	default:
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodePositionTaken, "position is already taken")
+	}
+
+	now := s.now().UTC()
+	if hasDrawOfferFromOpponent(game, move.PlayerID) {
+		game.Raise(&models.DrawDeclined{PlayerID: move.PlayerID, Implicit: true, DeclinedAt: now})
+	}
+	game.Raise(&models.MoveCommitted{PlayerID: move.PlayerID, Round: game.Round, Position: move.Position, CommittedAt: now})
+
+	if positions, ok := roundPositions(game); ok {
+		game.Raise(&models.RoundRevealed{
+			Round:      game.Round,
+			Positions:  positions,
+			Blocked:    collisions(positions),
+			RevealedAt: now,
+		})
+
+		if status, scores, ranking := s.checkGameStatus(game); status != enums.InProgress {
+			game.Raise(&models.GameFinished{
+				Status:      status,
+				Termination: enums.NormalTermination,
+				Scores:      scores,
+				Ranking:     ranking,
+			})
+		}
+	}
+
+	if err := s.saveGame(game); err != nil {
+		return nil, fmt.Errorf("failed to update game: %w", err)
+	}
+	return &services.CachedMoveResult{Game: game, ETag: uuid.New().String()}, nil
+}
+
+// roundPositions возвращает выбор всех мест, если раунд можно открыть
+func roundPositions(game *models.Game) ([]int, bool) {
+	for _, playerID := range game.Players() {
+		if !game.HasCommitted(playerID) {
+			return nil, false
+		}
+	}
+	return append([]int(nil), game.Commits...), true
+}
+
+// collisions возвращает позиции, выбранные несколькими местами
+func collisions(positions []int) []int {
+	chosen := make(map[int]int, len(positions))
+	var blocked []int
+	for _, position := range positions {
+		chosen[position]++
+		if chosen[position] == 2 {
+			blocked = append(blocked, position)
+		}
+	}
+	return blocked
+}
+
+// withoutSealedCommits убирает из истории выбор позиций в ещё не открытом раунде
+func withoutSealedCommits(events []models.RecordedGameEvent) []models.RecordedGameEvent {
+	sealedFrom := len(events)
+	for i := len(events) - 1; i >= 0; i-- {
+		if _, ok := events[i].Event.(*models.RoundRevealed); ok {
+			break
+		}
+		if _, ok := events[i].Event.(*models.MoveCommitted); ok {
+			sealedFrom = i
+		}
+	}
+	if sealedFrom == len(events) {
+		return events
+	}
+
+	visible := append([]models.RecordedGameEvent(nil), events[:sealedFrom]...)
+	for _, recorded := range events[sealedFrom:] {
+		if _, ok := recorded.Event.(*models.MoveCommitted); !ok {
+			visible = append(visible, recorded)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,142 @@
+package implemenatation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+func (s *gameService) RequestTakeback(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.updateGame(gameID, playerID, requireTakebackable, func(game *models.Game) error {
+		if err := requireTwoPlayers(game); err != nil {
+			return err
+		}
+		if game.Rated && !s.policy.AllowTakebacksInRated {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeTakebacksDisabled,
+				"takebacks are not allowed in rated games")
+		}
+		// возврат ходов не восстанавливает часы, поэтому в партиях с контролем времени его нет
+		if !game.TimeControl.IsUnlimited() {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeTakebacksDisabled,
+				"takebacks are not available in timed games")
+		}
+		if len(game.Schedule) > 0 {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeTakebacksDisabled,
+				"takebacks are not available with a turn schedule")
+		}
+		if game.Rules.HiddenNails || game.Rules.TurnOrder == enums.SimultaneousTurns {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeTakebacksDisabled,
+				fmt.Sprintf("takebacks are not available in the %s variant", game.Variant))
+		}
+		if game.TakebackRequestedBy != nil {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeTakebackAlreadyRequested,
+				"a takeback has already been requested")
+		}
+
+		positions, err := s.takebackPositions(game, playerID)
+		if err != nil {
+			return err
+		}
+		if len(positions) == 0 {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoMoveToTakeBack,
+				"player has no move to take back")
+		}
+
+		game.Raise(&models.TakebackRequested{PlayerID: playerID, RequestedAt: s.now().UTC()})
+		return nil
+	})
+}
+
+func (s *gameService) AcceptTakeback(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.updateGame(gameID, playerID, requireTakebackable, func(game *models.Game) error {
+		if err := requireTwoPlayers(game); err != nil {
+			return err
+		}
+		if !hasTakebackRequestFromOpponent(game, playerID) {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoTakebackRequest,
+				"opponent has not requested a takeback")
+		}
+
+		requesterID := *game.TakebackRequestedBy
+		positions, err := s.takebackPositions(game, requesterID)
+		if err != nil {
+			return err
+		}
+		if len(positions) == 0 {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoMoveToTakeBack,
+				"player has no move to take back")
+		}
+
+		game.Raise(&models.MovesTakenBack{
+			AcceptedBy:      playerID,
+			Positions:       positions,
+			CurrentPlayerID: requesterID,
+			TakenBackAt:     s.now().UTC(),
+		})
+		return nil
+	})
+}
+
+func (s *gameService) DeclineTakeback(gameID, playerID uuid.UUID) (*models.Game, error) {
+	return s.updateGame(gameID, playerID, requireTakebackable, func(game *models.Game) error {
+		if err := requireTwoPlayers(game); err != nil {
+			return err
+		}
+		if !hasTakebackRequestFromOpponent(game, playerID) {
+			return serviceErrors.NewInvalidOperationError(serviceErrors.CodeNoTakebackRequest,
+				"opponent has not requested a takeback")
+		}
+
+		game.Raise(&models.TakebackDeclined{PlayerID: playerID, DeclinedAt: s.now().UTC()})
+		return nil
+	})
+}
+
+// takebackPositions восстанавливает по истории партии действующие ходы и возвращает
+// позиции, которые нужно освободить, чтобы отменить последний ход игрока
+// вместе со всеми ходами соперника после него; порядок - от последнего хода
+func (s *gameService) takebackPositions(game *models.Game, playerID uuid.UUID) ([]int, error) {
+	events, err := s.gameRepo.GetEvents(game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game history: %w", err)
+	}
+
+	var moves []*models.MovePlayed
+	for _, recorded := range events {
+		switch e := recorded.Event.(type) {
+		case *models.MovePlayed:
+			moves = append(moves, e)
+		case *models.SidesSwapped:
+			// после смены сторон ходы до неё вернуть уже нельзя
+			moves = nil
+		case *models.MovesTakenBack:
+			moves = moves[:len(moves)-len(e.Positions)]
+		}
+	}
+
+	var positions []int
+	for i := len(moves) - 1; i >= 0; i-- {
+		positions = append(positions, moves[i].Position)
+		if moves[i].PlayerID == playerID {
+			return positions, nil
+		}
+	}
+	return nil, nil
+}
+
+// requireTakebackable допускает возврат ходов только в идущей партии: итог
+// завершённой партии уже учтён турнирами, сериями и вебхуками
+func requireTakebackable(game *models.Game) error {
+	if game.Status == enums.InProgress {
+		return nil
+	}
+	return serviceErrors.NewInvalidOperationError(serviceErrors.CodeGameFinished, "game has already ended")
+}
+
+func hasTakebackRequestFromOpponent(game *models.Game, playerID uuid.UUID) bool {
+	return game.TakebackRequestedBy != nil && *game.TakebackRequestedBy != playerID
+}
@@ -0,0 +1,25 @@
+package implemenatation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// NewNotificationScheduler периодически сообщает игрокам заочных партий об их ходе
+// и напоминает об истекающем времени
+func NewNotificationScheduler(notificationService services.NotificationService, interval time.Duration, logger *logrus.Logger) *PeriodicRunner {
+	return NewPeriodicRunner("notification", interval, func() error {
+		sent, err := notificationService.NotifyPlayers()
+		if sent > 0 {
+			logger.WithField("emails", sent).Info("Sent notifications")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to notify players: %w", err)
+		}
+		return nil
+	}, logger)
+}
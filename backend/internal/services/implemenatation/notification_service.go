@@ -0,0 +1,274 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// deadlineLayout - как время окончания хода выглядит в письмах
+const deadlineLayout = "2006-01-02 15:04 MST"
+
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	gameRepo         repositories.GameRepository
+	playerRepo       repositories.PlayerRepository
+	// notifier - nil, если отправка писем не настроена
+	notifier services.Notifier
+	policy   services.NotificationPolicy
+	now      func() time.Time
+}
+
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	gameRepo repositories.GameRepository,
+	playerRepo repositories.PlayerRepository,
+	notifier services.Notifier,
+	policy services.NotificationPolicy,
+	now func() time.Time,
+) services.NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		gameRepo:         gameRepo,
+		playerRepo:       playerRepo,
+		notifier:         notifier,
+		policy:           policy,
+		now:              now,
+	}
+}
+
+func (s *notificationService) Preferences(playerID uuid.UUID) (*models.NotificationPreferences, error) {
+	player, err := s.loadPlayer(playerID)
+	if err != nil {
+		return nil, err
+	}
+	preferences := player.Notifications
+	if preferences.Mode == "" {
+		preferences.Mode = enums.ImmediateNotifications
+	}
+	return &preferences, nil
+}
+
+func (s *notificationService) UpdatePreferences(
+	playerID uuid.UUID,
+	preferences models.NotificationPreferences,
+) (*models.NotificationPreferences, error) {
+	if !preferences.Mode.IsKnown() {
+		validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+		validation.Add("mode", fmt.Sprintf("unknown notification mode %q", preferences.Mode))
+		return nil, validation
+	}
+
+	player, err := s.loadPlayer(playerID)
+	if err != nil {
+		return nil, err
+	}
+	player.Notifications = preferences
+	if err := s.playerRepo.Update(player); err != nil {
+		return nil, fmt.Errorf("failed to update player: %w", err)
+	}
+	return &player.Notifications, nil
+}
+
+func (s *notificationService) NotifyPlayers() (int, error) {
+	if s.notifier == nil {
+		return 0, nil
+	}
+	now := s.now().UTC()
+
+	games, err := s.gameRepo.ListInProgress(enums.CorrespondenceTimeControl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list correspondence games: %w", err)
+	}
+	awaiting := make(map[uuid.UUID]*models.Game, len(games))
+	for i := range games {
+		game := &games[i]
+		if game.TurnStartedAt == nil {
+			continue
+		}
+		awaiting[game.ID] = game
+		for _, playerID := range playersToMove(game) {
+			if err := s.record(game, playerID, enums.TurnNotification, now); err != nil {
+				return 0, err
+			}
+			if game.TurnDeadline != nil && !now.Before(game.TurnDeadline.Add(-s.policy.ReminderBefore)) {
+				if err := s.record(game, playerID, enums.ReminderNotification, now); err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+
+	pending, err := s.notificationRepo.ListPending()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	// уведомления игрока разбираются вместе, чтобы собрать их в сводку
+	var players []uuid.UUID
+	byPlayer := make(map[uuid.UUID][]models.Notification)
+	for _, notification := range pending {
+		if _, ok := byPlayer[notification.PlayerID]; !ok {
+			players = append(players, notification.PlayerID)
+		}
+		byPlayer[notification.PlayerID] = append(byPlayer[notification.PlayerID], notification)
+	}
+
+	sent := 0
+	var errs []error
+	for _, playerID := range players {
+		delivered, err := s.deliver(playerID, byPlayer[playerID], awaiting, now)
+		sent += delivered
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (s *notificationService) record(game *models.Game, playerID uuid.UUID, kind enums.NotificationKind, now time.Time) error {
+	err := s.notificationRepo.Add(&models.Notification{
+		ID:            uuid.New(),
+		PlayerID:      playerID,
+		GameID:        game.ID,
+		Kind:          kind,
+		TurnStartedAt: *game.TurnStartedAt,
+		Deadline:      game.TurnDeadline,
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+	return nil
+}
+
+// deliver отправляет игроку письма по его настройкам. Уведомления о ходе, который
+// уже сделан, и ненужные игроку отмечаются отправленными без письма; неотправленные
+// из-за ошибки остаются в очереди до следующей проверки
+func (s *notificationService) deliver(
+	playerID uuid.UUID,
+	notifications []models.Notification,
+	awaiting map[uuid.UUID]*models.Game,
+	now time.Time,
+) (int, error) {
+	player, err := s.playerRepo.GetByID(playerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load player: %w", err)
+	}
+	preferences := player.Notifications
+
+	sent := 0
+	var done []uuid.UUID
+	var digest []models.Notification
+	var sendErr error
+	for _, notification := range notifications {
+		game := awaiting[notification.GameID]
+		stale := game == nil || !game.TurnStartedAt.Equal(notification.TurnStartedAt) ||
+			!slices.Contains(playersToMove(game), playerID)
+		muted := player.Email == "" || preferences.Mode == enums.NoNotifications ||
+			(notification.Kind == enums.ReminderNotification && preferences.NoReminders)
+		switch {
+		case stale || muted:
+			done = append(done, notification.ID)
+		case notification.Kind == enums.TurnNotification && preferences.Mode == enums.DigestNotifications:
+			digest = append(digest, notification)
+		default:
+			// напоминания приходят сразу и в режиме сводки, иначе они опоздают
+			if err := s.notifier.Send(notificationMessage(player.Email, notification)); err != nil {
+				sendErr = err
+				continue
+			}
+			done = append(done, notification.ID)
+			sent++
+		}
+	}
+
+	// сводка уходит, когда самое старое уведомление в ней ждёт дольше периода сводки
+	if len(digest) > 0 && !now.Before(digest[0].CreatedAt.Add(s.policy.DigestInterval)) {
+		if err := s.notifier.Send(digestMessage(player.Email, digest)); err != nil {
+			sendErr = err
+		} else {
+			for _, notification := range digest {
+				done = append(done, notification.ID)
+			}
+			sent++
+		}
+	}
+
+	if err := s.notificationRepo.MarkSent(done, now); err != nil {
+		return sent, fmt.Errorf("failed to mark notifications sent: %w", err)
+	}
+	return sent, sendErr
+}
+
+func (s *notificationService) loadPlayer(playerID uuid.UUID) (*models.Player, error) {
+	player, err := s.playerRepo.GetByID(playerID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPlayerNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodePlayerNotFound, "player not found")
+		}
+		return nil, fmt.Errorf("failed to load player: %w", err)
+	}
+	return player, nil
+}
+
+// playersToMove возвращает игроков, чьего хода ждёт партия
+func playersToMove(game *models.Game) []uuid.UUID {
+	if game.Rules.TurnOrder != enums.SimultaneousTurns {
+		return []uuid.UUID{game.CurrentPlayerID}
+	}
+	var players []uuid.UUID
+	for _, playerID := range game.Players() {
+		if !game.HasCommitted(playerID) {
+			players = append(players, playerID)
+		}
+	}
+	return players
+}
+
+func notificationMessage(to string, notification models.Notification) services.Message {
+	if notification.Kind == enums.ReminderNotification {
+		return services.Message{
+			To:      to,
+			Subject: fmt.Sprintf("Time is running out in game %s", notification.GameID),
+			Body: fmt.Sprintf("Your time to move in game %s runs out at %s.\n",
+				notification.GameID, notification.Deadline.Format(deadlineLayout)),
+		}
+	}
+
+	body := fmt.Sprintf("It is your move in game %s.\n", notification.GameID)
+	if notification.Deadline != nil {
+		body += fmt.Sprintf("You have until %s to move.\n", notification.Deadline.Format(deadlineLayout))
+	}
+	return services.Message{
+		To:      to,
+		Subject: fmt.Sprintf("Your move in game %s", notification.GameID),
+		Body:    body,
+	}
+}
+
+func digestMessage(to string, notifications []models.Notification) services.Message {
+	var body strings.Builder
+	body.WriteString("It is your move in these games:\n")
+	for _, notification := range notifications {
+		fmt.Fprintf(&body, "- %s", notification.GameID)
+		if notification.Deadline != nil {
+			fmt.Fprintf(&body, ", until %s", notification.Deadline.Format(deadlineLayout))
+		}
+		body.WriteString("\n")
+	}
+	return services.Message{
+		To:      to,
+		Subject: fmt.Sprintf("Your move in %d games", len(notifications)),
+		Body:    body.String(),
+	}
+}
@@ -0,0 +1,42 @@
+package implemenatation
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PeriodicRunner вызывает задачу через равные промежутки времени, пока не отменён контекст;
+// ошибка задачи пишется в журнал и не останавливает следующие запуски
+type PeriodicRunner struct {
+	name     string
+	interval time.Duration
+	job      func() error
+	logger   *logrus.Logger
+}
+
+func NewPeriodicRunner(name string, interval time.Duration, job func() error, logger *logrus.Logger) *PeriodicRunner {
+	return &PeriodicRunner{
+		name:     name,
+		interval: interval,
+		job:      job,
+		logger:   logger,
+	}
+}
+
+func (r *PeriodicRunner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.job(); err != nil {
+				r.logger.WithError(err).WithField("job", r.name).Error("Periodic job failed")
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package implemenatation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// NewSeriesScheduler периодически начинает серии по расписанию
+// и создаёт следующие партии серий после завершения текущих
+func NewSeriesScheduler(seriesService services.SeriesService, interval time.Duration, logger *logrus.Logger) *PeriodicRunner {
+	return NewPeriodicRunner("series", interval, func() error {
+		advanced, err := seriesService.AdvanceSeries()
+		if advanced > 0 {
+			logger.WithField("series", advanced).Info("Advanced series")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to advance series: %w", err)
+		}
+		return nil
+	}, logger)
+}
@@ -0,0 +1,269 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// maxSeriesGames ограничивает длину серии
+const maxSeriesGames = 15
+
+type seriesService struct {
+	seriesRepo  repositories.SeriesRepository
+	playerRepo  repositories.PlayerRepository
+	gameService services.GameService
+
+	// mutex не даёт планировщику и запросам менять серию одновременно
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+func NewSeriesService(
+	seriesRepo repositories.SeriesRepository,
+	playerRepo repositories.PlayerRepository,
+	gameService services.GameService,
+	now func() time.Time,
+) services.SeriesService {
+	return &seriesService{
+		seriesRepo:  seriesRepo,
+		playerRepo:  playerRepo,
+		gameService: gameService,
+		now:         now,
+	}
+}
+
+func (s *seriesService) CreateSeries(settings models.SeriesSettings) (*models.Series, error) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+	now := s.now().UTC()
+
+	if settings.BestOf < 1 || settings.BestOf > maxSeriesGames {
+		validation.Add("bestOf", fmt.Sprintf("must be between 1 and %d", maxSeriesGames))
+	}
+	if settings.StartsAt != nil && !settings.StartsAt.After(now) {
+		validation.Add("startsAt", "must be in the future")
+	}
+	players := []struct {
+		field string
+		id    uuid.UUID
+	}{
+		{"firstPlayerId", settings.FirstPlayerID},
+		{"secondPlayerId", settings.SecondPlayerID},
+	}
+	for _, player := range players {
+		field, playerID := player.field, player.id
+		if playerID == uuid.Nil {
+			validation.Add(field, "is required")
+			continue
+		}
+		if _, err := s.playerRepo.GetByID(playerID); err != nil {
+			if !errors.Is(err, repositories.ErrPlayerNotFound) {
+				return nil, fmt.Errorf("failed to load player: %w", err)
+			}
+			validation.Add(field, "player not found")
+		}
+	}
+	if settings.FirstPlayerID != uuid.Nil && settings.FirstPlayerID == settings.SecondPlayerID {
+		validation.Add("secondPlayerId", "must differ from firstPlayerId")
+	}
+
+	if err := s.gameService.CheckSettings(settings.Game); err != nil {
+		var invalid *serviceErrors.ValidationError
+		if !errors.As(err, &invalid) {
+			return nil, err
+		}
+		for _, field := range invalid.Fields {
+			validation.Add("game."+field.Field, field.Message)
+		}
+	}
+
+	if validation.HasErrors() {
+		return nil, validation
+	}
+
+	series := &models.Series{
+		ID:             uuid.New(),
+		FirstPlayerID:  settings.FirstPlayerID,
+		SecondPlayerID: settings.SecondPlayerID,
+		BestOf:         settings.BestOf,
+		Status:         enums.ScheduledSeries,
+		GameSettings:   settings.Game,
+		StartsAt:       settings.StartsAt,
+		CreatedAt:      now,
+	}
+	if settings.StartsAt == nil {
+		if err := s.start(series); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.seriesRepo.Create(series); err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+	return series, nil
+}
+
+func (s *seriesService) GetSeries(seriesID uuid.UUID) (*models.Series, error) {
+	return s.loadSeries(seriesID)
+}
+
+func (s *seriesService) ListSeries() ([]models.Series, error) {
+	series, err := s.seriesRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	return series, nil
+}
+
+func (s *seriesService) AdvanceSeries() (int, error) {
+	seriesIDs, err := s.seriesRepo.ListActive()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active series: %w", err)
+	}
+
+	// сломанная серия не должна останавливать остальные
+	advanced := 0
+	var errs []error
+	for _, seriesID := range seriesIDs {
+		changed, err := s.advanceOne(seriesID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("series %s: %w", seriesID, err))
+			continue
+		}
+		if changed {
+			advanced++
+		}
+	}
+	return advanced, errors.Join(errs...)
+}
+
+func (s *seriesService) advanceOne(seriesID uuid.UUID) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	series, err := s.loadSeries(seriesID)
+	if err != nil {
+		return false, err
+	}
+	changed, err := s.advance(series)
+	if err != nil || !changed {
+		return false, err
+	}
+	if err := s.seriesRepo.Update(series); err != nil {
+		return false, fmt.Errorf("failed to update series: %w", err)
+	}
+	return true, nil
+}
+
+// advance начинает серию по расписанию или записывает результат текущей партии;
+// после неё создаётся реванш или серия завершается
+func (s *seriesService) advance(series *models.Series) (bool, error) {
+	now := s.now().UTC()
+
+	if series.Status == enums.ScheduledSeries {
+		if series.StartsAt != nil && now.Before(*series.StartsAt) {
+			return false, nil
+		}
+		return true, s.start(series)
+	}
+
+	last := series.LastGame()
+	game, err := s.gameService.LoadGame(last.GameID)
+	if err != nil {
+		return false, err
+	}
+	if game.Status == enums.InProgress {
+		return false, nil
+	}
+
+	last.Result = pairingResult(game, last.FirstPlayerID)
+	if game.Status == enums.Aborted {
+		last.Result = enums.AbortedResult
+	}
+	last.FinishedAt = &now
+
+	// прерванная партия переигрывается не больше maxPairingReplays раз подряд, как в турнирах;
+	// затем серия заканчивается с текущим счётом
+	if isSeriesOver(series) || abortedInARow(series) > maxPairingReplays {
+		series.Status = enums.FinishedSeries
+		series.FinishedAt = &now
+		series.WinnerID = seriesWinner(series)
+		return true, nil
+	}
+
+	// следующая партия, в том числе переигровка прерванной, - реванш со сменой цвета
+	rematch, err := s.gameService.CreateRematch(last.GameID)
+	if err != nil {
+		return true, fmt.Errorf("failed to create series game: %w", err)
+	}
+	s.addGame(series, rematch)
+	return true, nil
+}
+
+func (s *seriesService) start(series *models.Series) error {
+	game, err := s.gameService.CreateGame(series.GameSettings, []uuid.UUID{series.FirstPlayerID, series.SecondPlayerID})
+	if err != nil {
+		return fmt.Errorf("failed to create series game: %w", err)
+	}
+	series.Status = enums.RunningSeries
+	s.addGame(series, game)
+	return nil
+}
+
+func (s *seriesService) addGame(series *models.Series, game *models.Game) {
+	series.Games = append(series.Games, models.SeriesGame{
+		SeriesID:      series.ID,
+		Number:        len(series.Games) + 1,
+		GameID:        game.ID,
+		FirstPlayerID: game.FirstPlayerID,
+	})
+}
+
+func (s *seriesService) loadSeries(seriesID uuid.UUID) (*models.Series, error) {
+	series, err := s.seriesRepo.GetByID(seriesID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSeriesNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeSeriesNotFound, "series not found")
+		}
+		return nil, fmt.Errorf("failed to load series: %w", err)
+	}
+	return series, nil
+}
+
+// isSeriesOver проверяет, сыграны ли все партии серии или набрал ли кто-то больше половины очков
+func isSeriesOver(series *models.Series) bool {
+	if series.Played() >= series.BestOf {
+		return true
+	}
+	half := float64(series.BestOf) / 2
+	return series.Score(series.FirstPlayerID) > half || series.Score(series.SecondPlayerID) > half
+}
+
+// abortedInARow возвращает число прерванных партий в конце серии
+func abortedInARow(series *models.Series) int {
+	count := 0
+	for i := len(series.Games) - 1; i >= 0 && series.Games[i].Result == enums.AbortedResult; i-- {
+		count++
+	}
+	return count
+}
+
+// seriesWinner возвращает игрока с большим счётом или nil при равенстве
+func seriesWinner(series *models.Series) *uuid.UUID {
+	first, second := series.Score(series.FirstPlayerID), series.Score(series.SecondPlayerID)
+	switch {
+	case first > second:
+		return &series.FirstPlayerID
+	case second > first:
+		return &series.SecondPlayerID
+	}
+	return nil
+}
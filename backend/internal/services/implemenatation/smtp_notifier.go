@@ -0,0 +1,49 @@
+package implemenatation
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+type smtpNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+	now  func() time.Time
+}
+
+// NewSMTPNotifier отправляет письма через SMTP-сервер; без имени пользователя
+// письма отправляются без аутентификации
+func NewSMTPNotifier(host, port, username, password, from string, now func() time.Time) services.Notifier {
+	notifier := &smtpNotifier{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+		now:  now,
+	}
+	if username != "" {
+		notifier.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return notifier
+}
+
+func (n *smtpNotifier) Send(message services.Message) error {
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", n.from)
+	fmt.Fprintf(&body, "To: %s\r\n", message.To)
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&body, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	if err := smtp.SendMail(n.addr, n.auth, n.from, []string{message.To}, []byte(body.String())); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", message.To, err)
+	}
+	return nil
+}
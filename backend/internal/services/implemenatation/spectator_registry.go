@@ -0,0 +1,43 @@
+package implemenatation
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// spectatorRegistry хранит число зрителей в памяти процесса
+type spectatorRegistry struct {
+	mutex  sync.Mutex
+	counts map[uuid.UUID]int
+}
+
+func NewSpectatorRegistry() services.SpectatorRegistry {
+	return &spectatorRegistry{counts: make(map[uuid.UUID]int)}
+}
+
+func (r *spectatorRegistry) Watch(gameID uuid.UUID) func() {
+	r.mutex.Lock()
+	r.counts[gameID]++
+	r.mutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+			r.counts[gameID]--
+			if r.counts[gameID] <= 0 {
+				delete(r.counts, gameID)
+			}
+		})
+	}
+}
+
+func (r *spectatorRegistry) Count(gameID uuid.UUID) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.counts[gameID]
+}
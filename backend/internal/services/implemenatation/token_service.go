@@ -0,0 +1,75 @@
+package implemenatation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// tokenPayloadSize - ID игрока, ID партии и срок действия
+const tokenPayloadSize = 40
+
+// tokenService подписывает токены HMAC-SHA256: токен состоит из ID игрока, ID партии
+// (нулевого у токена входа) и срока действия, закодированных в base64url, и подписи через точку
+type tokenService struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewTokenService(secret string, ttl time.Duration, now func() time.Time) services.TokenService {
+	return &tokenService{secret: []byte(secret), ttl: ttl, now: now}
+}
+
+func (s *tokenService) Issue(playerID, gameID uuid.UUID) string {
+	payload := make([]byte, 0, tokenPayloadSize)
+	payload = append(payload, playerID[:]...)
+	payload = append(payload, gameID[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(s.now().Add(s.ttl).Unix()))
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(s.sign(payload))
+}
+
+func (s *tokenService) Verify(token string, gameID uuid.UUID) (uuid.UUID, error) {
+	invalid := serviceErrors.NewUnauthenticatedError(serviceErrors.CodeInvalidToken, "invalid or expired token")
+
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, invalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != tokenPayloadSize {
+		return uuid.Nil, invalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(payload)) {
+		return uuid.Nil, invalid
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[32:])), 0)
+	if !s.now().Before(expiresAt) {
+		return uuid.Nil, invalid
+	}
+	// токен, выданный при создании партии, не даёт действовать от имени игрока в других партиях
+	tokenGameID, _ := uuid.FromBytes(payload[16:32])
+	if tokenGameID != uuid.Nil && tokenGameID != gameID {
+		return uuid.Nil, serviceErrors.NewUnauthenticatedError(serviceErrors.CodeInvalidToken, "token is issued for another game")
+	}
+	playerID, _ := uuid.FromBytes(payload[:16])
+	return playerID, nil
+}
+
+func (s *tokenService) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
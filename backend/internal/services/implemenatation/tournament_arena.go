@@ -0,0 +1,250 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+// очки арены: победа - 2, ничья - 1; после двух побед подряд очки удваиваются до первой не-победы
+const (
+	arenaWinPoints  = 2
+	arenaDrawPoints = 1
+	arenaFireStreak = 2
+)
+
+func (s *tournamentService) AdvanceArenas() (int, error) {
+	tournamentIDs, err := s.tournamentRepo.ListActive([]enums.TournamentFormat{enums.ArenaFormat})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active arenas: %w", err)
+	}
+
+	advanced := 0
+	var errs []error
+	for _, tournamentID := range tournamentIDs {
+		changed, err := s.advanceTournament(tournamentID, s.advanceArena)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("arena %s: %w", tournamentID, err))
+			continue
+		}
+		if changed {
+			advanced++
+		}
+	}
+	return advanced, errors.Join(errs...)
+}
+
+func (s *tournamentService) Leave(tournamentID, playerID uuid.UUID) (*models.Tournament, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tournament, err := s.loadTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Format != enums.ArenaFormat {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeArenaOnly, "only arena players can leave")
+	}
+	if tournament.Status == enums.FinishedTournament {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeTournamentFinished, "tournament is finished")
+	}
+	player := tournament.Player(playerID)
+	if player == nil {
+		return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodeNotRegistered, "player is not registered")
+	}
+
+	player.Paused = true
+	if err := s.tournamentRepo.Update(tournament); err != nil {
+		return nil, fmt.Errorf("failed to update tournament: %w", err)
+	}
+	return tournament, nil
+}
+
+// advanceArena запускает арену по расписанию, записывает результаты сыгранных партий
+// и сводит освободившихся игроков. После конца арены записываются партии, закончившиеся
+// с прошлой проверки, а недоигранные не засчитываются
+func (s *tournamentService) advanceArena(tournament *models.Tournament) (bool, error) {
+	now := s.now().UTC()
+
+	if tournament.Status == enums.RegistrationTournament {
+		return s.startOnSchedule(tournament, now)
+	}
+
+	changed := false
+	for i := range tournament.Pairings {
+		pairing := &tournament.Pairings[i]
+		if pairing.Result != enums.PendingResult {
+			continue
+		}
+		if pairing.GameID == nil {
+			if err := s.createGame(tournament, pairing); err != nil {
+				return changed, err
+			}
+			changed = true
+			continue
+		}
+		game, err := s.gameService.LoadGame(*pairing.GameID)
+		if err != nil {
+			return changed, err
+		}
+		if game.Status == enums.InProgress {
+			continue
+		}
+
+		changed = true
+		pairing.Result = pairingResult(game, pairing.FirstPlayerID)
+		if game.Status == enums.Aborted {
+			pairing.Result = enums.AbortedResult
+		}
+		pairing.FinishedAt = &now
+	}
+
+	if !now.Before(*tournament.EndsAt) {
+		tournament.Status = enums.FinishedTournament
+		tournament.FinishedAt = &now
+		return true, nil
+	}
+
+	round := tournament.CurrentRound
+	if err := s.pairArena(tournament); err != nil {
+		return true, err
+	}
+	return changed || tournament.CurrentRound != round, nil
+}
+
+// pairArena начинает партии для ожидающих игроков, если их хотя бы двое
+func (s *tournamentService) pairArena(tournament *models.Tournament) error {
+	if len(waitingPlayers(tournament)) < 2 {
+		return nil
+	}
+	return s.nextRound(tournament)
+}
+
+// waitingPlayers возвращает игроков арены, которые не играют и не покинули турнир
+func waitingPlayers(tournament *models.Tournament) map[uuid.UUID]bool {
+	waiting := make(map[uuid.UUID]bool, len(tournament.Players))
+	for _, p := range tournament.Players {
+		if !p.Paused {
+			waiting[p.PlayerID] = true
+		}
+	}
+	for _, pairing := range tournament.Pairings {
+		if pairing.Result == enums.PendingResult && !pairing.IsBye() {
+			delete(waiting, pairing.FirstPlayerID)
+			delete(waiting, *pairing.SecondPlayerID)
+		}
+	}
+	return waiting
+}
+
+// pairWaiting сводит ожидающих игроков арены соседями по таблице, по возможности
+// не с последним соперником; при нечётном числе один игрок, начиная с последнего
+// по таблице, ждёт следующей волны
+func pairWaiting(tournament *models.Tournament) [][2]uuid.UUID {
+	waiting := waitingPlayers(tournament)
+	var ranked []uuid.UUID
+	for _, standing := range arenaStandings(tournament) {
+		if waiting[standing.PlayerID] {
+			ranked = append(ranked, standing.PlayerID)
+		}
+	}
+
+	last := make(map[uuid.UUID]uuid.UUID)
+	for _, pairing := range arenaGames(tournament) {
+		last[pairing.FirstPlayerID] = *pairing.SecondPlayerID
+		last[*pairing.SecondPlayerID] = pairing.FirstPlayerID
+	}
+	met := make(map[[2]uuid.UUID]bool)
+	for playerID, opponentID := range last {
+		met[[2]uuid.UUID{playerID, opponentID}] = true
+		met[[2]uuid.UUID{opponentID, playerID}] = true
+	}
+
+	steps := maxPairingSteps
+	if len(ranked)%2 == 0 {
+		if pairs, ok := pairUnmet(ranked, met, &steps); ok {
+			return pairs
+		}
+	} else {
+		for i := len(ranked) - 1; i >= 0 && steps > 0; i-- {
+			rest := append(ranked[:i:i], ranked[i+1:]...)
+			if pairs, ok := pairUnmet(rest, met, &steps); ok {
+				return pairs
+			}
+		}
+		ranked = ranked[:len(ranked)-1]
+	}
+	return pairGreedy(ranked, met)
+}
+
+// arenaGames возвращает сыгранные партии арены в порядке завершения
+func arenaGames(tournament *models.Tournament) []models.TournamentPairing {
+	var games []models.TournamentPairing
+	for _, pairing := range tournament.Pairings {
+		if pairing.FinishedAt != nil && pairing.Result != enums.AbortedResult {
+			games = append(games, pairing)
+		}
+	}
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].FinishedAt.Before(*games[j].FinishedAt)
+	})
+	return games
+}
+
+// arenaStandings считает таблицу арены: очки с учётом серий побед, затем число побед, номер игрока
+func arenaStandings(tournament *models.Tournament) []models.Standing {
+	rows := make(map[uuid.UUID]*models.Standing, len(tournament.Players))
+	table := make([]models.Standing, len(tournament.Players))
+	streaks := make(map[uuid.UUID]int, len(tournament.Players))
+	for i, p := range tournament.Players {
+		table[i] = models.Standing{PlayerID: p.PlayerID, Seed: p.Seed}
+		rows[p.PlayerID] = &table[i]
+	}
+
+	for _, pairing := range arenaGames(tournament) {
+		for _, playerID := range []uuid.UUID{pairing.FirstPlayerID, *pairing.SecondPlayerID} {
+			row, ok := rows[playerID]
+			if !ok {
+				continue
+			}
+			multiplier := 1.0
+			if streaks[playerID] >= arenaFireStreak {
+				multiplier = 2
+			}
+			switch pairing.Score(playerID) {
+			case 1:
+				row.Wins++
+				row.Points += arenaWinPoints * multiplier
+				streaks[playerID]++
+			case 0.5:
+				row.Draws++
+				row.Points += arenaDrawPoints * multiplier
+				streaks[playerID] = 0
+			default:
+				row.Losses++
+				streaks[playerID] = 0
+			}
+		}
+	}
+	for i := range table {
+		table[i].OnFire = streaks[table[i].PlayerID] >= arenaFireStreak
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		switch {
+		case a.Points != b.Points:
+			return a.Points > b.Points
+		case a.Wins != b.Wins:
+			return a.Wins > b.Wins
+		}
+		return a.Seed < b.Seed
+	})
+	return table
+}
@@ -0,0 +1,243 @@
+package implemenatation
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+// maxPairingSteps ограничивает перебор пар швейцарского тура и арены: число вариантов растёт
+// экспоненциально с числом игроков, а регистрация в турнир не ограничена
+const maxPairingSteps = 10000
+
+// pairRound составляет пары тура; uuid.Nil вторым игроком означает пропуск тура.
+// Первым в паре ходит тот, кто реже ходил первым, при равенстве - игрок с лучшим номером
+func pairRound(tournament *models.Tournament, round int) [][2]uuid.UUID {
+	var pairs [][2]uuid.UUID
+	switch tournament.Format {
+	case enums.RoundRobinFormat:
+		pairs = pairRoundRobin(tournament, round)
+	case enums.SwissFormat:
+		pairs = pairSwiss(tournament)
+	case enums.ArenaFormat:
+		pairs = pairWaiting(tournament)
+	default:
+		pairs = pairElimination(tournament)
+	}
+
+	firsts := make(map[uuid.UUID]int)
+	for _, pairing := range tournament.Pairings {
+		if !pairing.IsBye() {
+			firsts[pairing.FirstPlayerID]++
+		}
+	}
+	seeds := seedsOf(tournament)
+	for i, pair := range pairs {
+		if pair[1] == uuid.Nil {
+			continue
+		}
+		first, second := firsts[pair[0]], firsts[pair[1]]
+		if second < first || (second == first && seeds[pair[1]] < seeds[pair[0]]) {
+			pairs[i] = [2]uuid.UUID{pair[1], pair[0]}
+		}
+	}
+	return pairs
+}
+
+// pairRoundRobin - круговая система по методу поворота: первый номер стоит на месте,
+// остальные сдвигаются на одну позицию каждый тур
+func pairRoundRobin(tournament *models.Tournament, round int) [][2]uuid.UUID {
+	players := make([]uuid.UUID, 0, len(tournament.Players)+1)
+	for _, p := range tournament.Players {
+		players = append(players, p.PlayerID)
+	}
+	if len(players)%2 == 1 {
+		players = append(players, uuid.Nil)
+	}
+
+	n := len(players)
+	order := make([]uuid.UUID, n)
+	order[0] = players[0]
+	for i := 1; i < n; i++ {
+		order[i] = players[1+(i-1+round-1)%(n-1)]
+	}
+
+	var pairs [][2]uuid.UUID
+	for i := 0; i < n/2; i++ {
+		first, second := order[i], order[n-1-i]
+		if first == uuid.Nil {
+			first, second = second, first
+		}
+		pairs = append(pairs, [2]uuid.UUID{first, second})
+	}
+	return pairs
+}
+
+// pairSwiss - швейцарская система: игроки идут по таблице, каждый получает в соперники
+// ближайшего по таблице игрока, с которым ещё не встречался. Пропуск тура достаётся
+// самому слабому игроку, который его ещё не получал
+func pairSwiss(tournament *models.Tournament) [][2]uuid.UUID {
+	table := standings(tournament)
+	met := make(map[[2]uuid.UUID]bool)
+	byes := make(map[uuid.UUID]bool)
+	for _, pairing := range tournament.Pairings {
+		if pairing.IsBye() {
+			byes[pairing.FirstPlayerID] = true
+			continue
+		}
+		met[[2]uuid.UUID{pairing.FirstPlayerID, *pairing.SecondPlayerID}] = true
+		met[[2]uuid.UUID{*pairing.SecondPlayerID, pairing.FirstPlayerID}] = true
+	}
+
+	ranked := make([]uuid.UUID, 0, len(table))
+	for _, standing := range table {
+		ranked = append(ranked, standing.PlayerID)
+	}
+
+	var bye uuid.UUID
+	if len(ranked)%2 == 1 {
+		index := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !byes[ranked[i]] {
+				index = i
+				break
+			}
+		}
+		bye = ranked[index]
+		ranked = append(ranked[:index:index], ranked[index+1:]...)
+	}
+
+	steps := maxPairingSteps
+	pairs, ok := pairUnmet(ranked, met, &steps)
+	if !ok {
+		// встречи без повторов не нашлось или перебор слишком долгий
+		pairs = pairGreedy(ranked, met)
+	}
+	if bye != uuid.Nil {
+		pairs = append(pairs, [2]uuid.UUID{bye, uuid.Nil})
+	}
+	return pairs
+}
+
+// pairUnmet перебором с возвратом разбивает игроков на пары без повторных встреч;
+// steps - сколько вариантов ещё можно проверить, после этого перебор сдаётся
+func pairUnmet(players []uuid.UUID, met map[[2]uuid.UUID]bool, steps *int) ([][2]uuid.UUID, bool) {
+	if len(players) == 0 {
+		return nil, true
+	}
+
+	first := players[0]
+	for i := 1; i < len(players); i++ {
+		if met[[2]uuid.UUID{first, players[i]}] {
+			continue
+		}
+		if *steps <= 0 {
+			return nil, false
+		}
+		*steps--
+		rest := make([]uuid.UUID, 0, len(players)-2)
+		rest = append(rest, players[1:i]...)
+		rest = append(rest, players[i+1:]...)
+		if pairs, ok := pairUnmet(rest, met, steps); ok {
+			return append([][2]uuid.UUID{{first, players[i]}}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+// pairGreedy сводит игроков по таблице с ближайшим свободным соперником, с которым они
+// ещё не встречались, а если такого нет - просто с ближайшим свободным
+func pairGreedy(players []uuid.UUID, met map[[2]uuid.UUID]bool) [][2]uuid.UUID {
+	paired := make([]bool, len(players))
+	var pairs [][2]uuid.UUID
+	for i, first := range players {
+		if paired[i] {
+			continue
+		}
+		opponent := -1
+		for j := i + 1; j < len(players); j++ {
+			if paired[j] {
+				continue
+			}
+			if opponent < 0 {
+				opponent = j
+			}
+			if !met[[2]uuid.UUID{first, players[j]}] {
+				opponent = j
+				break
+			}
+		}
+		if opponent < 0 {
+			break
+		}
+		paired[i], paired[opponent] = true, true
+		pairs = append(pairs, [2]uuid.UUID{first, players[opponent]})
+	}
+	return pairs
+}
+
+// pairElimination - турнир на выбывание: оставшиеся игроки группируются по числу поражений,
+// в группе лучший номер играет с худшим. При нечётной группе пропуск получает лучший номер
+// из тех, кто пропускал меньше; если в каждой группе по одному игроку, они играют между собой
+func pairElimination(tournament *models.Tournament) [][2]uuid.UUID {
+	byes := make(map[uuid.UUID]int)
+	for _, pairing := range tournament.Pairings {
+		if pairing.IsBye() {
+			byes[pairing.FirstPlayerID]++
+		}
+	}
+
+	groups := make(map[int][]models.Standing)
+	var losses []int
+	for _, standing := range standings(tournament) {
+		if standing.Eliminated {
+			continue
+		}
+		if groups[standing.Losses] == nil {
+			losses = append(losses, standing.Losses)
+		}
+		groups[standing.Losses] = append(groups[standing.Losses], standing)
+	}
+	sort.Ints(losses)
+
+	if len(losses) > 1 {
+		singles := true
+		for _, group := range groups {
+			singles = singles && len(group) == 1
+		}
+		if singles {
+			return [][2]uuid.UUID{{groups[losses[0]][0].PlayerID, groups[losses[1]][0].PlayerID}}
+		}
+	}
+
+	var pairs [][2]uuid.UUID
+	for _, lost := range losses {
+		group := groups[lost]
+		sort.Slice(group, func(i, j int) bool { return group[i].Seed < group[j].Seed })
+		if len(group)%2 == 1 {
+			index := 0
+			for i := range group {
+				if byes[group[i].PlayerID] < byes[group[index].PlayerID] {
+					index = i
+				}
+			}
+			pairs = append(pairs, [2]uuid.UUID{group[index].PlayerID, uuid.Nil})
+			group = append(group[:index:index], group[index+1:]...)
+		}
+		for i := 0; i < len(group)/2; i++ {
+			pairs = append(pairs, [2]uuid.UUID{group[i].PlayerID, group[len(group)-1-i].PlayerID})
+		}
+	}
+	return pairs
+}
+
+func seedsOf(tournament *models.Tournament) map[uuid.UUID]int {
+	seeds := make(map[uuid.UUID]int, len(tournament.Players))
+	for _, p := range tournament.Players {
+		seeds[p.PlayerID] = p.Seed
+	}
+	return seeds
+}
@@ -0,0 +1,25 @@
+package implemenatation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// NewTournamentScheduler периодически запускает турниры по расписанию
+// и переходит к следующему туру, когда партии текущего сыграны
+func NewTournamentScheduler(tournamentService services.TournamentService, interval time.Duration, logger *logrus.Logger) *PeriodicRunner {
+	return NewPeriodicRunner("tournament", interval, func() error {
+		advanced, err := tournamentService.AdvanceTournaments()
+		if advanced > 0 {
+			logger.WithField("tournaments", advanced).Info("Advanced tournaments")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to advance tournaments: %w", err)
+		}
+		return nil
+	}, logger)
+}
@@ -0,0 +1,474 @@
+package implemenatation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+// maxTournamentNameLength, maxSwissRounds и длительность арены ограничивают параметры турнира
+const (
+	maxTournamentNameLength = 128
+	maxSwissRounds          = 30
+	minArenaDuration        = 10 * time.Minute
+	maxArenaDuration        = 24 * time.Hour
+)
+
+// maxPairingReplays ограничивает число переигровок одной пары; дальше итог решает номер участника
+const maxPairingReplays = 3
+
+type tournamentService struct {
+	tournamentRepo repositories.TournamentRepository
+	playerRepo     repositories.PlayerRepository
+	gameService    services.GameService
+
+	// mutex не даёт планировщику и запросам игроков менять турнир одновременно
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+func NewTournamentService(
+	tournamentRepo repositories.TournamentRepository,
+	playerRepo repositories.PlayerRepository,
+	gameService services.GameService,
+	now func() time.Time,
+) services.TournamentService {
+	return &tournamentService{
+		tournamentRepo: tournamentRepo,
+		playerRepo:     playerRepo,
+		gameService:    gameService,
+		now:            now,
+	}
+}
+
+func (s *tournamentService) CreateTournament(
+	settings models.TournamentSettings,
+	organizerID uuid.UUID,
+) (*models.Tournament, error) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+	now := s.now().UTC()
+
+	name := strings.TrimSpace(settings.Name)
+	if name == "" || len(name) > maxTournamentNameLength {
+		validation.Add("name", fmt.Sprintf("must be between 1 and %d characters", maxTournamentNameLength))
+	}
+	switch {
+	case !settings.Format.IsKnown():
+		validation.Add("format", fmt.Sprintf("unknown tournament format %q", settings.Format))
+	case settings.Format == enums.SwissFormat:
+		if settings.Rounds < 1 || settings.Rounds > maxSwissRounds {
+			validation.Add("rounds", fmt.Sprintf("must be between 1 and %d", maxSwissRounds))
+		}
+	case settings.Rounds != 0:
+		validation.Add("rounds", "is only set for the swiss format")
+	}
+	if settings.Format == enums.ArenaFormat {
+		if settings.Duration < minArenaDuration || settings.Duration > maxArenaDuration {
+			validation.Add("durationMinutes", fmt.Sprintf("must be between %d and %d",
+				int(minArenaDuration.Minutes()), int(maxArenaDuration.Minutes())))
+		}
+		// брошенная партия арены должна закончиться по времени, иначе игроки не освободятся
+		switch settings.Game.TimeControl.Type {
+		case enums.FischerTimeControl, enums.PerMoveTimeControl:
+		default:
+			validation.Add("game.timeControl.type", "arena games need a fischer or per_move clock")
+		}
+	} else if settings.Duration != 0 {
+		validation.Add("durationMinutes", "is only set for the arena format")
+	}
+	if settings.StartsAt != nil && !settings.StartsAt.After(now) {
+		validation.Add("startsAt", "must be in the future")
+	}
+
+	if organizerID == uuid.Nil {
+		validation.Add("organizerId", "is required")
+	} else if _, err := s.playerRepo.GetByID(organizerID); err != nil {
+		if !errors.Is(err, repositories.ErrPlayerNotFound) {
+			return nil, fmt.Errorf("failed to load player: %w", err)
+		}
+		validation.Add("organizerId", "player not found")
+	}
+
+	if err := s.gameService.CheckSettings(settings.Game); err != nil {
+		var invalid *serviceErrors.ValidationError
+		if !errors.As(err, &invalid) {
+			return nil, err
+		}
+		for _, field := range invalid.Fields {
+			validation.Add("game."+field.Field, field.Message)
+		}
+	}
+
+	if validation.HasErrors() {
+		return nil, validation
+	}
+
+	// сохраняются параметры из запроса: пресет и зерно поля разрешаются заново для каждой партии
+	if settings.Game.Variant == "" {
+		settings.Game.Variant = enums.StandardVariant
+	}
+	if settings.Game.TimeControl.Type == "" {
+		settings.Game.TimeControl.Type = enums.UnlimitedTimeControl
+	}
+	tournament := &models.Tournament{
+		ID:           uuid.New(),
+		Name:         name,
+		Format:       settings.Format,
+		Status:       enums.RegistrationTournament,
+		OrganizerID:  organizerID,
+		GameSettings: settings.Game,
+		Rounds:       settings.Rounds,
+		StartsAt:     settings.StartsAt,
+		Duration:     settings.Duration,
+		CreatedAt:    now,
+	}
+	if err := s.tournamentRepo.Create(tournament); err != nil {
+		return nil, fmt.Errorf("failed to create tournament: %w", err)
+	}
+	return tournament, nil
+}
+
+func (s *tournamentService) GetTournament(tournamentID uuid.UUID) (*models.Tournament, error) {
+	return s.loadTournament(tournamentID)
+}
+
+func (s *tournamentService) ListTournaments() ([]models.Tournament, error) {
+	tournaments, err := s.tournamentRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tournaments: %w", err)
+	}
+	return tournaments, nil
+}
+
+func (s *tournamentService) Register(tournamentID, playerID uuid.UUID) (*models.Tournament, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tournament, err := s.loadTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	// на арену можно прийти и после старта, пока она идёт
+	open := tournament.Status == enums.RegistrationTournament ||
+		(tournament.Format == enums.ArenaFormat && tournament.Status == enums.RunningTournament)
+	if !open {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeRegistrationClosed, "registration is closed")
+	}
+	if _, err := s.playerRepo.GetByID(playerID); err != nil {
+		if errors.Is(err, repositories.ErrPlayerNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodePlayerNotFound, "player not found")
+		}
+		return nil, fmt.Errorf("failed to load player: %w", err)
+	}
+	if player := tournament.Player(playerID); player != nil {
+		if !player.Paused {
+			return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeAlreadyRegistered, "player is already registered")
+		}
+		player.Paused = false
+		if err := s.tournamentRepo.Update(tournament); err != nil {
+			return nil, fmt.Errorf("failed to update tournament: %w", err)
+		}
+		return tournament, nil
+	}
+
+	tournament.Players = append(tournament.Players, models.TournamentPlayer{
+		TournamentID: tournament.ID,
+		PlayerID:     playerID,
+		Seed:         len(tournament.Players) + 1,
+		RegisteredAt: s.now().UTC(),
+	})
+	if err := s.tournamentRepo.Update(tournament); err != nil {
+		return nil, fmt.Errorf("failed to update tournament: %w", err)
+	}
+	return tournament, nil
+}
+
+func (s *tournamentService) Start(tournamentID, playerID uuid.UUID) (*models.Tournament, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tournament, err := s.loadTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.OrganizerID != playerID {
+		return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodeNotOrganizer, "only the organizer can start the tournament")
+	}
+	if tournament.Status != enums.RegistrationTournament {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeTournamentStarted, "tournament has already started")
+	}
+	if tournament.Format != enums.ArenaFormat && len(tournament.Players) < 2 {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeNotEnoughPlayers, "at least two players must register")
+	}
+
+	// турнир сохраняется, даже если не все партии тура создались: недостающие создаст планировщик
+	startErr := s.start(tournament, s.now().UTC())
+	if err := s.tournamentRepo.Update(tournament); err != nil {
+		return nil, errors.Join(startErr, fmt.Errorf("failed to update tournament: %w", err))
+	}
+	if startErr != nil {
+		return nil, startErr
+	}
+	return tournament, nil
+}
+
+func (s *tournamentService) AdvanceTournaments() (int, error) {
+	tournamentIDs, err := s.tournamentRepo.ListActive(enums.FixedFormats)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active tournaments: %w", err)
+	}
+
+	// сломанный турнир не должен останавливать остальные
+	advanced := 0
+	var errs []error
+	for _, tournamentID := range tournamentIDs {
+		changed, err := s.advanceTournament(tournamentID, s.advance)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tournament %s: %w", tournamentID, err))
+			continue
+		}
+		if changed {
+			advanced++
+		}
+	}
+	return advanced, errors.Join(errs...)
+}
+
+// advanceTournament продвигает один турнир под блокировкой и сохраняет его, если он изменился
+func (s *tournamentService) advanceTournament(tournamentID uuid.UUID, step func(*models.Tournament) (bool, error)) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tournament, err := s.loadTournament(tournamentID)
+	if err != nil {
+		return false, err
+	}
+	// изменения сохраняются и после ошибки шага, чтобы уже созданные партии не создавались снова
+	changed, err := step(tournament)
+	if !changed {
+		return false, err
+	}
+	if updateErr := s.tournamentRepo.Update(tournament); updateErr != nil {
+		return false, errors.Join(err, fmt.Errorf("failed to update tournament: %w", updateErr))
+	}
+	return true, err
+}
+
+func (s *tournamentService) Standings(tournamentID uuid.UUID) ([]models.Standing, error) {
+	tournament, err := s.loadTournament(tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	return standings(tournament), nil
+}
+
+// advance запускает турнир по расписанию или записывает результаты текущего тура;
+// когда все партии тура сыграны, начинается следующий тур или турнир завершается
+func (s *tournamentService) advance(tournament *models.Tournament) (bool, error) {
+	now := s.now().UTC()
+
+	if tournament.Status == enums.RegistrationTournament {
+		return s.startOnSchedule(tournament, now)
+	}
+
+	changed := false
+	complete := true
+	for _, pairing := range tournament.RoundPairings(tournament.CurrentRound) {
+		if pairing.Result != enums.PendingResult {
+			continue
+		}
+		if pairing.GameID == nil {
+			complete = false
+			if err := s.createGame(tournament, pairing); err != nil {
+				return changed, err
+			}
+			changed = true
+			continue
+		}
+		game, err := s.gameService.LoadGame(*pairing.GameID)
+		if err != nil {
+			return changed, err
+		}
+		if game.Status == enums.InProgress {
+			complete = false
+			continue
+		}
+
+		changed = true
+		result := pairingResult(game, pairing.FirstPlayerID)
+		// прерванная партия, как и ничья на выбывание, переигрывается со сменой очерёдности
+		replay := game.Status == enums.Aborted ||
+			(result == enums.DrawResult && tournament.Format.IsElimination())
+		if replay && pairing.Replays < maxPairingReplays {
+			pairing.FirstPlayerID, *pairing.SecondPlayerID = *pairing.SecondPlayerID, pairing.FirstPlayerID
+			pairing.Replays++
+			pairing.GameID = nil
+			if err := s.createGame(tournament, pairing); err != nil {
+				return changed, err
+			}
+			complete = false
+			continue
+		}
+		if replay {
+			result = replaysExhaustedResult(tournament, pairing)
+		}
+		pairing.Result = result
+		pairing.FinishedAt = &now
+	}
+	if !complete {
+		return changed, nil
+	}
+
+	if isTournamentOver(tournament) {
+		tournament.Status = enums.FinishedTournament
+		tournament.FinishedAt = &now
+		return true, nil
+	}
+	return true, s.nextRound(tournament)
+}
+
+// startOnSchedule запускает турнир, время старта которого наступило
+func (s *tournamentService) startOnSchedule(tournament *models.Tournament, now time.Time) (bool, error) {
+	if tournament.StartsAt == nil || now.Before(*tournament.StartsAt) {
+		return false, nil
+	}
+	// без соперников турнир с турами не может начаться и завершается без них
+	if tournament.Format != enums.ArenaFormat && len(tournament.Players) < 2 {
+		tournament.Status = enums.FinishedTournament
+		tournament.FinishedAt = &now
+		return true, nil
+	}
+	return true, s.start(tournament, now)
+}
+
+func (s *tournamentService) start(tournament *models.Tournament, now time.Time) error {
+	tournament.Status = enums.RunningTournament
+	players := len(tournament.Players)
+	switch tournament.Format {
+	case enums.ArenaFormat:
+		endsAt := now.Add(tournament.Duration)
+		tournament.EndsAt = &endsAt
+		return s.pairArena(tournament)
+	case enums.RoundRobinFormat:
+		tournament.Rounds = players - 1 + players%2
+	case enums.SwissFormat:
+		tournament.Rounds = min(tournament.Rounds, players-1+players%2)
+	}
+	return s.nextRound(tournament)
+}
+
+// nextRound сводит пары следующего тура и создаёт их партии. Пары добавляются в турнир
+// до создания партий: если партия не создалась, пара остаётся без неё, и следующая
+// проверка турнира создаст только недостающие партии
+func (s *tournamentService) nextRound(tournament *models.Tournament) error {
+	round := tournament.CurrentRound + 1
+	first := len(tournament.Pairings)
+	for board, pair := range pairRound(tournament, round) {
+		pairing := models.TournamentPairing{
+			ID:            uuid.New(),
+			TournamentID:  tournament.ID,
+			Round:         round,
+			Board:         board + 1,
+			FirstPlayerID: pair[0],
+		}
+		if pair[1] == uuid.Nil {
+			pairing.Result = enums.ByeResult
+		} else {
+			second := pair[1]
+			pairing.SecondPlayerID = &second
+		}
+		tournament.Pairings = append(tournament.Pairings, pairing)
+	}
+	tournament.CurrentRound = round
+
+	for i := first; i < len(tournament.Pairings); i++ {
+		pairing := &tournament.Pairings[i]
+		if pairing.IsBye() {
+			continue
+		}
+		if err := s.createGame(tournament, pairing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *tournamentService) createGame(tournament *models.Tournament, pairing *models.TournamentPairing) error {
+	// турнир передаётся в партию, чтобы вебхуки турнира получили и её создание
+	settings := tournament.GameSettings
+	settings.TournamentID = &tournament.ID
+	game, err := s.gameService.CreateGame(settings, []uuid.UUID{pairing.FirstPlayerID, *pairing.SecondPlayerID})
+	if err != nil {
+		return fmt.Errorf("failed to create tournament game: %w", err)
+	}
+	pairing.GameID = &game.ID
+	return nil
+}
+
+func (s *tournamentService) loadTournament(tournamentID uuid.UUID) (*models.Tournament, error) {
+	tournament, err := s.tournamentRepo.GetByID(tournamentID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTournamentNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeTournamentNotFound, "tournament not found")
+		}
+		return nil, fmt.Errorf("failed to load tournament: %w", err)
+	}
+	return tournament, nil
+}
+
+// pairingResult переводит итог партии в итог пары с первым игроком firstPlayerID;
+// прерванная партия считается ничьей. Победитель ищется по игрокам партии,
+// потому что в варианте pie они могли поменяться местами
+func pairingResult(game *models.Game, firstPlayerID uuid.UUID) enums.PairingResult {
+	var winner uuid.UUID
+	switch game.Status {
+	case enums.FirstPlayerWon:
+		winner = game.FirstPlayerID
+	case enums.SecondPlayerWon:
+		winner = game.SecondPlayerID
+	default:
+		return enums.DrawResult
+	}
+	if winner == firstPlayerID {
+		return enums.FirstPlayerWin
+	}
+	return enums.SecondPlayerWin
+}
+
+// replaysExhaustedResult - итог пары, исчерпавшей переигровки: на выбывание проходит
+// участник с меньшим номером, в остальных форматах засчитывается ничья
+func replaysExhaustedResult(tournament *models.Tournament, pairing *models.TournamentPairing) enums.PairingResult {
+	if !tournament.Format.IsElimination() {
+		return enums.DrawResult
+	}
+	first, second := tournament.Player(pairing.FirstPlayerID), tournament.Player(*pairing.SecondPlayerID)
+	if first != nil && second != nil && second.Seed < first.Seed {
+		return enums.SecondPlayerWin
+	}
+	return enums.FirstPlayerWin
+}
+
+// isTournamentOver проверяет, сыграны ли все туры или остался ли один участник турнира на выбывание
+func isTournamentOver(tournament *models.Tournament) bool {
+	if !tournament.Format.IsElimination() {
+		return tournament.CurrentRound >= tournament.Rounds
+	}
+
+	remaining := 0
+	for _, standing := range standings(tournament) {
+		if !standing.Eliminated {
+			remaining++
+		}
+	}
+	return remaining <= 1
+}
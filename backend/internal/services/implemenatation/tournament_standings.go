@@ -0,0 +1,89 @@
+package implemenatation
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+// standings считает турнирную таблицу по сыгранным парам. Порядок: оставшиеся в турнире
+// на выбывание, очки, коэффициент Бухгольца, коэффициент Зоннеборна-Бергера, номер игрока
+func standings(tournament *models.Tournament) []models.Standing {
+	if tournament.Format == enums.ArenaFormat {
+		return arenaStandings(tournament)
+	}
+
+	rows := make(map[uuid.UUID]*models.Standing, len(tournament.Players))
+	table := make([]models.Standing, len(tournament.Players))
+	for i, p := range tournament.Players {
+		table[i] = models.Standing{PlayerID: p.PlayerID, Seed: p.Seed}
+		rows[p.PlayerID] = &table[i]
+	}
+
+	for _, pairing := range tournament.Pairings {
+		if pairing.Result == enums.PendingResult {
+			continue
+		}
+		for _, playerID := range []uuid.UUID{pairing.FirstPlayerID, pairing.Opponent(pairing.FirstPlayerID)} {
+			row, ok := rows[playerID]
+			if !ok {
+				continue
+			}
+			score := pairing.Score(playerID)
+			row.Points += score
+			switch {
+			case pairing.IsBye():
+			case score == 1:
+				row.Wins++
+			case score == 0.5:
+				row.Draws++
+			default:
+				row.Losses++
+			}
+		}
+	}
+
+	for _, pairing := range tournament.Pairings {
+		if pairing.Result == enums.PendingResult || pairing.IsBye() {
+			continue
+		}
+		first, second := rows[pairing.FirstPlayerID], rows[*pairing.SecondPlayerID]
+		if first == nil || second == nil {
+			continue
+		}
+		first.Buchholz += second.Points
+		second.Buchholz += first.Points
+		first.SonnebornBerger += pairing.Score(first.PlayerID) * second.Points
+		second.SonnebornBerger += pairing.Score(second.PlayerID) * first.Points
+	}
+
+	maxLosses := 0
+	switch tournament.Format {
+	case enums.SingleEliminationFormat:
+		maxLosses = 1
+	case enums.DoubleEliminationFormat:
+		maxLosses = 2
+	}
+	for i := range table {
+		table[i].Eliminated = maxLosses > 0 && table[i].Losses >= maxLosses
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		switch {
+		case a.Eliminated != b.Eliminated:
+			return !a.Eliminated
+		case a.Points != b.Points:
+			return a.Points > b.Points
+		case a.Buchholz != b.Buchholz:
+			return a.Buchholz > b.Buchholz
+		case a.SonnebornBerger != b.SonnebornBerger:
+			return a.SonnebornBerger > b.SonnebornBerger
+		}
+		return a.Seed < b.Seed
+	})
+	return table
+}
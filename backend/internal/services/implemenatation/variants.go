@@ -0,0 +1,196 @@
+package implemenatation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+// variantParameter описывает, как параметр варианта из запроса переносится в настройки партии
+type variantParameter struct {
+	models.VariantParameter
+	apply func(raw []byte, settings *models.GameSettings) error
+	isSet func(settings models.GameSettings) bool
+}
+
+var (
+	lineSizeParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "lineSize", Type: "integer", Description: "число позиций на поле"},
+		apply:            decodeInto(func(s *models.GameSettings) any { return &s.LineSize }),
+		isSet:            func(s models.GameSettings) bool { return s.LineSize != 0 },
+	}
+	boardParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "board", Type: "object",
+			Description: "нерегулярное поле: preset, coordinates или seed"},
+		apply: decodeInto(func(s *models.GameSettings) any { return &s.Board }),
+		isSet: func(s models.GameSettings) bool { return !s.Board.IsEmpty() },
+	}
+	widthParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "width", Type: "integer", Description: "ширина поля, не меньше 2"},
+		apply:            decodeInto(func(s *models.GameSettings) any { return &s.Grid.Width }),
+		isSet:            func(s models.GameSettings) bool { return s.Grid.Width != 0 },
+	}
+	heightParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "height", Type: "integer", Description: "высота поля, не меньше 2"},
+		apply:            decodeInto(func(s *models.GameSettings) any { return &s.Grid.Height }),
+		isSet:            func(s models.GameSettings) bool { return s.Grid.Height != 0 },
+	}
+	metricParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "metric", Type: "string", Description: "расстояние между клетками",
+			Values: []string{string(enums.ManhattanMetric), string(enums.EuclideanMetric)}},
+		apply: decodeInto(func(s *models.GameSettings) any { return &s.Grid.Metric }),
+		isSet: func(s models.GameSettings) bool { return s.Grid.Metric != "" },
+	}
+	scheduleParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "schedule", Type: "integer[]",
+			Description: "число гвоздей за ход, последний элемент повторяется"},
+		apply: decodeInto(func(s *models.GameSettings) any { return &s.Schedule }),
+		isSet: func(s models.GameSettings) bool { return s.Schedule != nil },
+	}
+	startPositionParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "startPosition", Type: "integer[]",
+			Description: "начальная расстановка: 0 - свободно, -1 - закрыто, номер места - гвоздь игрока"},
+		apply: decodeInto(func(s *models.GameSettings) any { return &s.StartPosition }),
+		isSet: func(s models.GameSettings) bool { return s.StartPosition != nil },
+	}
+	scoringParameter = variantParameter{
+		VariantParameter: models.VariantParameter{Name: "scoring", Type: "string", Description: "какая нить побеждает",
+			Values: []string{string(enums.LongestThreadWins), string(enums.ShortestThreadWins)}},
+		apply: decodeInto(func(s *models.GameSettings) any { return &s.Scoring }),
+		isSet: func(s models.GameSettings) bool { return s.Scoring != "" },
+	}
+)
+
+func decodeInto(field func(settings *models.GameSettings) any) func([]byte, *models.GameSettings) error {
+	return func(raw []byte, settings *models.GameSettings) error {
+		return json.Unmarshal(raw, field(settings))
+	}
+}
+
+// variantEntry связывает описание варианта с его параметрами
+type variantEntry struct {
+	definition models.VariantDefinition
+	parameters []variantParameter
+}
+
+func defaultRules() models.Rules {
+	return models.Rules{
+		Topology:     enums.LineTopology,
+		TurnOrder:    enums.SequentialTurns,
+		Scoring:      enums.LongestThreadWins,
+		EndCondition: enums.BoardFullEnd,
+	}
+}
+
+func withRules(change func(rules *models.Rules)) models.Rules {
+	rules := defaultRules()
+	change(&rules)
+	return rules
+}
+
+// variantRegistry - все варианты, которые умеет сервер; доступные игрокам
+// дополнительно ограничиваются политикой
+var variantRegistry = []variantEntry{
+	{
+		definition: models.VariantDefinition{Name: enums.StandardVariant, Description: "гвозди на линии, побеждает самая длинная нить",
+			Rules: defaultRules()},
+		parameters: []variantParameter{lineSizeParameter, boardParameter, scheduleParameter, startPositionParameter, scoringParameter},
+	},
+	{
+		definition: models.VariantDefinition{Name: enums.PieVariant, Description: "второй игрок может забрать первый гвоздь соперника",
+			Rules: withRules(func(r *models.Rules) { r.PieRule = true })},
+		parameters: []variantParameter{lineSizeParameter, boardParameter, startPositionParameter, scoringParameter},
+	},
+	{
+		definition: models.VariantDefinition{Name: enums.CircularVariant, Description: "поле замкнуто в кольцо",
+			Rules: withRules(func(r *models.Rules) { r.Topology = enums.RingTopology })},
+		parameters: []variantParameter{lineSizeParameter, scheduleParameter, startPositionParameter, scoringParameter},
+	},
+	{
+		definition: models.VariantDefinition{Name: enums.GridVariant, Description: "двумерное поле, гвоздь связывается с ближайшим своим",
+			Rules: withRules(func(r *models.Rules) { r.Topology = enums.GridTopology })},
+		parameters: []variantParameter{widthParameter, heightParameter, metricParameter, scheduleParameter, startPositionParameter, scoringParameter},
+	},
+	{
+		definition: models.VariantDefinition{Name: enums.FogVariant, Description: "гвозди соперников скрыты, пока на них не наткнёшься",
+			Rules: withRules(func(r *models.Rules) { r.HiddenNails = true })},
+		parameters: []variantParameter{lineSizeParameter, boardParameter, scheduleParameter, startPositionParameter, scoringParameter},
+	},
+	{
+		definition: models.VariantDefinition{Name: enums.SimultaneousVariant, Description: "игроки выбирают позиции тайно и одновременно",
+			Rules: withRules(func(r *models.Rules) { r.TurnOrder = enums.SimultaneousTurns })},
+		parameters: []variantParameter{lineSizeParameter, boardParameter, startPositionParameter, scoringParameter},
+	},
+	{
+		definition: models.VariantDefinition{Name: enums.HandicapVariant, Description: "партия с заданной начальной расстановкой: фора или задача",
+			Rules: defaultRules()},
+		parameters: []variantParameter{lineSizeParameter, boardParameter, scheduleParameter, requiredParameter(startPositionParameter), scoringParameter},
+	},
+	{
+		definition: models.VariantDefinition{Name: enums.MisereVariant, Description: "побеждает самая короткая нить",
+			Rules: withRules(func(r *models.Rules) { r.Scoring = enums.ShortestThreadWins })},
+		parameters: []variantParameter{lineSizeParameter, boardParameter, scheduleParameter, startPositionParameter},
+	},
+}
+
+func requiredParameter(parameter variantParameter) variantParameter {
+	parameter.Required = true
+	return parameter
+}
+
+func findVariant(name enums.Variant) (variantEntry, bool) {
+	for _, entry := range variantRegistry {
+		if entry.definition.Name == name {
+			return entry, true
+		}
+	}
+	return variantEntry{}, false
+}
+
+func (e variantEntry) parameter(name string) (variantParameter, bool) {
+	for _, parameter := range e.parameters {
+		if parameter.Name == name {
+			return parameter, true
+		}
+	}
+	return variantParameter{}, false
+}
+
+// describe возвращает описание варианта вместе со схемой его параметров
+func (e variantEntry) describe() models.VariantDefinition {
+	definition := e.definition
+	definition.Parameters = make([]models.VariantParameter, len(e.parameters))
+	for i, parameter := range e.parameters {
+		definition.Parameters[i] = parameter.VariantParameter
+	}
+	return definition
+}
+
+// applyParameters переносит параметры варианта в настройки, проверяя их по схеме варианта.
+// Параметры перекрывают одноимённые поля запроса
+func applyParameters(validation *serviceErrors.ValidationError, entry variantEntry, settings *models.GameSettings) {
+	for name, value := range settings.Parameters {
+		field := "parameters." + name
+		parameter, ok := entry.parameter(name)
+		if !ok {
+			validation.Add(field, fmt.Sprintf("is not supported by the %s variant", entry.definition.Name))
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err == nil {
+			err = parameter.apply(raw, settings)
+		}
+		if err != nil {
+			validation.Add(field, fmt.Sprintf("must be of type %s", parameter.Type))
+		}
+	}
+
+	for _, parameter := range entry.parameters {
+		if parameter.Required && !parameter.isSet(*settings) {
+			validation.Add("parameters."+parameter.Name, fmt.Sprintf("is required for the %s variant", entry.definition.Name))
+		}
+	}
+}
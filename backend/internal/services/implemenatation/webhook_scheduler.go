@@ -0,0 +1,24 @@
+package implemenatation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	services "nails_game/internal/services/interfaces"
+)
+
+// NewWebhookScheduler периодически отправляет накопившиеся события на вебхуки
+func NewWebhookScheduler(webhookService services.WebhookService, interval time.Duration, logger *logrus.Logger) *PeriodicRunner {
+	return NewPeriodicRunner("webhook", interval, func() error {
+		delivered, err := webhookService.DeliverWebhooks()
+		if delivered > 0 {
+			logger.WithField("deliveries", delivered).Debug("Delivered webhooks")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to deliver webhooks: %w", err)
+		}
+		return nil
+	}, logger)
+}
@@ -0,0 +1,434 @@
+package implemenatation
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/interfaces"
+)
+
+const (
+	// webhookBatchSize - сколько доставок отправляется за один проход
+	webhookBatchSize = 100
+	// webhookSecretBytes - длина ключа подписи до кодирования в hex
+	webhookSecretBytes = 32
+	// webhookLeaseMargin - сколько сверх тайм-аута запроса доставка остаётся за экземпляром,
+	// который её забрал
+	webhookLeaseMargin = time.Minute
+
+	webhookEventHeader     = "X-Nails-Event"
+	webhookDeliveryHeader  = "X-Nails-Delivery"
+	webhookSignatureHeader = "X-Nails-Signature"
+)
+
+type webhookService struct {
+	webhookRepo    repositories.WebhookRepository
+	tournamentRepo repositories.TournamentRepository
+	client         *http.Client
+	policy         services.WebhookPolicy
+	now            func() time.Time
+}
+
+func NewWebhookService(
+	webhookRepo repositories.WebhookRepository,
+	tournamentRepo repositories.TournamentRepository,
+	policy services.WebhookPolicy,
+	now func() time.Time,
+) services.WebhookService {
+	return &webhookService{
+		webhookRepo:    webhookRepo,
+		tournamentRepo: tournamentRepo,
+		client:         newWebhookClient(policy.Timeout, policy.AllowPrivateTargets),
+		policy:         policy,
+		now:            now,
+	}
+}
+
+func (s *webhookService) Register(actor services.WebhookActor, settings models.WebhookSettings) (*models.Webhook, error) {
+	if err := validateWebhook(actor, settings, s.policy.AllowPrivateTargets); err != nil {
+		return nil, err
+	}
+
+	webhook := &models.Webhook{
+		ID:        uuid.New(),
+		URL:       settings.URL,
+		Scope:     settings.Scope,
+		Events:    settings.Events,
+		CreatedAt: s.now().UTC(),
+	}
+	switch settings.Scope {
+	case enums.GlobalWebhook:
+		if !actor.Admin {
+			return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodeAdminKeyRequired, "only an administrator can register a global webhook")
+		}
+	case enums.PlayerWebhook:
+		webhook.OwnerID = &actor.PlayerID
+	case enums.TournamentWebhook:
+		tournament, err := s.tournamentRepo.GetByID(*settings.TournamentID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrTournamentNotFound) {
+				return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeTournamentNotFound, "tournament not found")
+			}
+			return nil, fmt.Errorf("failed to load tournament: %w", err)
+		}
+		if !actor.Admin && tournament.OrganizerID != actor.PlayerID {
+			return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodeNotOrganizer, "only the organizer can register a tournament webhook")
+		}
+		webhook.TournamentID = &tournament.ID
+		if actor.PlayerID != uuid.Nil {
+			webhook.OwnerID = &actor.PlayerID
+		}
+	}
+
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	webhook.Secret = hex.EncodeToString(secret)
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) Delete(actor services.WebhookActor, webhookID uuid.UUID) error {
+	if _, err := s.loadWebhook(actor, webhookID); err != nil {
+		return err
+	}
+	if err := s.webhookRepo.Delete(webhookID); err != nil {
+		if errors.Is(err, repositories.ErrWebhookNotFound) {
+			return serviceErrors.NewNotFoundError(serviceErrors.CodeWebhookNotFound, "webhook not found")
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookService) Deliveries(
+	actor services.WebhookActor,
+	webhookID uuid.UUID,
+	status enums.DeliveryStatus,
+) ([]models.WebhookDelivery, error) {
+	if !status.IsKnown() {
+		validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+		validation.Add("status", fmt.Sprintf("unknown delivery status %q", status))
+		return nil, validation
+	}
+	if _, err := s.loadWebhook(actor, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepo.ListDeliveries(webhookID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (s *webhookService) RetryDelivery(actor services.WebhookActor, webhookID, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	if _, err := s.loadWebhook(actor, webhookID); err != nil {
+		return nil, err
+	}
+	delivery, err := s.webhookRepo.GetDelivery(deliveryID)
+	if err != nil && !errors.Is(err, repositories.ErrDeliveryNotFound) {
+		return nil, fmt.Errorf("failed to load webhook delivery: %w", err)
+	}
+	if delivery == nil || delivery.WebhookID != webhookID {
+		return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeDeliveryNotFound, "webhook delivery not found")
+	}
+	if delivery.Status != enums.DeadDelivery {
+		return nil, serviceErrors.NewInvalidOperationError(serviceErrors.CodeDeliveryNotDead, "only dead deliveries can be retried")
+	}
+
+	delivery.Status = enums.PendingDelivery
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = s.now().UTC()
+	delivery.LastError = ""
+	if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+		return nil, fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// Outbox готовит доставки событий партии, которые сохраняются вместе с ней; сами запросы
+// отправляет DeliverWebhooks, поэтому медленный вебхук не задерживает ход
+func (s *webhookService) Outbox(game *models.Game, events []models.GameEvent) ([]models.WebhookDelivery, error) {
+	now := s.now().UTC()
+	payloads := webhookPayloads(game, events, now)
+	if len(payloads) == 0 {
+		return nil, nil
+	}
+
+	webhooks, err := s.webhookRepo.ListForGame(game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	bodies := make([][]byte, len(payloads))
+	for i, payload := range payloads {
+		if bodies[i], err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+		}
+	}
+
+	var deliveries []models.WebhookDelivery
+	for _, webhook := range webhooks {
+		// закрытые партии видят только вебхуки их игроков
+		if game.Private && webhook.Scope != enums.PlayerWebhook {
+			continue
+		}
+		for i, payload := range payloads {
+			if !webhook.Subscribed(payload.Event) {
+				continue
+			}
+			deliveries = append(deliveries, models.WebhookDelivery{
+				ID:            uuid.New(),
+				WebhookID:     webhook.ID,
+				GameID:        game.ID,
+				Event:         payload.Event,
+				Payload:       bodies[i],
+				Status:        enums.PendingDelivery,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			})
+		}
+	}
+	return deliveries, nil
+}
+
+func (s *webhookService) DeliverWebhooks() (int, error) {
+	now := s.now().UTC()
+	due, err := s.webhookRepo.ClaimDue(now, now.Add(s.policy.Timeout+webhookLeaseMargin), webhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	delivered := make([]bool, len(due))
+	errs := make([]error, len(due))
+	for i := range due {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			delivered[i], errs[i] = s.deliver(&due[i])
+		}(i)
+	}
+	wg.Wait()
+
+	count := 0
+	for _, ok := range delivered {
+		if ok {
+			count++
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// deliver делает одну попытку доставки; ошибка вебхука не возвращается,
+// а откладывает следующую попытку или переводит доставку в недоставленные
+func (s *webhookService) deliver(delivery *models.WebhookDelivery) (bool, error) {
+	sendErr := s.send(delivery)
+	now := s.now().UTC()
+
+	delivery.Attempts++
+	if sendErr == nil {
+		delivery.Status = enums.DeliveredStatus
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = sendErr.Error()
+		if delivery.Attempts >= s.policy.MaxAttempts {
+			delivery.Status = enums.DeadDelivery
+		} else {
+			delivery.NextAttemptAt = now.Add(s.retryDelay(delivery.Attempts))
+		}
+	}
+
+	if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+		return false, fmt.Errorf("failed to update webhook delivery %s: %w", delivery.ID, err)
+	}
+	return sendErr == nil, nil
+}
+
+func (s *webhookService) send(delivery *models.WebhookDelivery) error {
+	if delivery.Webhook == nil {
+		return errors.New("webhook not loaded")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, string(delivery.Event))
+	req.Header.Set(webhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(webhookSignatureHeader, signPayload(delivery.Webhook.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// retryDelay - пауза после attempts неудачных попыток: RetryBase, 2*RetryBase, ... не дольше RetryMax
+func (s *webhookService) retryDelay(attempts int) time.Duration {
+	delay := s.policy.RetryBase
+	for i := 1; i < attempts && delay < s.policy.RetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, s.policy.RetryMax)
+}
+
+func (s *webhookService) loadWebhook(actor services.WebhookActor, webhookID uuid.UUID) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(webhookID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWebhookNotFound) {
+			return nil, serviceErrors.NewNotFoundError(serviceErrors.CodeWebhookNotFound, "webhook not found")
+		}
+		return nil, fmt.Errorf("failed to load webhook: %w", err)
+	}
+	if actor.Admin {
+		return webhook, nil
+	}
+	if webhook.OwnerID == nil || *webhook.OwnerID != actor.PlayerID {
+		return nil, serviceErrors.NewUnauthorizedError(serviceErrors.CodeNotWebhookOwner, "only the owner can manage the webhook")
+	}
+	return webhook, nil
+}
+
+func validateWebhook(actor services.WebhookActor, settings models.WebhookSettings, allowPrivate bool) error {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+	target, err := url.Parse(settings.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		validation.Add("url", "must be an absolute http or https URL")
+	} else if !allowPrivate {
+		if err := checkWebhookHost(target.Hostname()); err != nil {
+			validation.Add("url", err.Error())
+		}
+	}
+	if !settings.Scope.IsKnown() {
+		validation.Add("scope", fmt.Sprintf("unknown webhook scope %q", settings.Scope))
+	}
+	if settings.Scope == enums.PlayerWebhook && actor.PlayerID == uuid.Nil {
+		validation.Add("playerId", "is required for a player webhook")
+	}
+	if settings.Scope == enums.TournamentWebhook && settings.TournamentID == nil {
+		validation.Add("tournamentId", "is required for a tournament webhook")
+	}
+	for _, event := range settings.Events {
+		if !event.IsKnown() {
+			validation.Add("events", fmt.Sprintf("unknown webhook event %q", event))
+		}
+	}
+	if len(validation.Fields) > 0 {
+		return validation
+	}
+	return nil
+}
+
+// signPayload возвращает значение заголовка подписи: HMAC-SHA256 тела запроса в hex
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookPayloads переводит события партии в события вебхуков; остальные события
+// (предложения ничьей, возвраты ходов и т.п.) на вебхуки не отправляются
+func webhookPayloads(game *models.Game, events []models.GameEvent, now time.Time) []models.WebhookPayload {
+	var payloads []models.WebhookPayload
+	add := func(event enums.WebhookEvent, data interface{}) {
+		payloads = append(payloads, models.WebhookPayload{
+			ID:         uuid.New(),
+			Event:      event,
+			GameID:     game.ID,
+			OccurredAt: now,
+			Data:       data,
+		})
+	}
+	// в партии со скрытыми гвоздями позиции не раскрываются, пока она идёт
+	position := func(position int) *int {
+		if game.IsHidden() {
+			return nil
+		}
+		return &position
+	}
+
+	for _, event := range events {
+		switch e := event.(type) {
+		case *models.GameCreated, *models.GameImported:
+			add(enums.GameCreatedWebhook, models.GameCreatedData{
+				Variant:        game.Variant,
+				Rated:          game.Rated,
+				PlayerIDs:      game.Players(),
+				PreviousGameID: game.PreviousGameID,
+			})
+		case *models.MovePlayed:
+			add(enums.MovePlayedWebhook, models.MovePlayedData{
+				PlayerID:     e.PlayerID,
+				Position:     position(e.Position),
+				NextPlayerID: &e.NextPlayerID,
+			})
+		case *models.NailRevealed:
+			add(enums.MovePlayedWebhook, models.MovePlayedData{
+				PlayerID:     e.PlayerID,
+				Position:     position(e.Position),
+				NextPlayerID: &e.NextPlayerID,
+			})
+		case *models.RoundRevealed:
+			players := game.Players()
+			for seat, revealed := range e.Positions {
+				if seat >= len(players) {
+					continue
+				}
+				add(enums.MovePlayedWebhook, models.MovePlayedData{
+					PlayerID: players[seat],
+					Position: position(revealed),
+					Round:    e.Round,
+				})
+			}
+		case *models.GameFinished:
+			add(enums.GameFinishedWebhook, models.GameFinishedData{
+				Status:      e.Status.String(),
+				Termination: e.Termination,
+				WinnerID:    gameWinner(game),
+				Ranking:     e.Ranking,
+				Scores:      e.Scores,
+			})
+		}
+	}
+	return payloads
+}
+
+// gameWinner возвращает победителя партии на двоих или nil
+func gameWinner(game *models.Game) *uuid.UUID {
+	switch game.Status {
+	case enums.FirstPlayerWon:
+		return &game.FirstPlayerID
+	case enums.SecondPlayerWon:
+		return &game.SecondPlayerID
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package implemenatation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// webhookLookupTimeout ограничивает разрешение имени вебхука при регистрации
+const webhookLookupTimeout = 5 * time.Second
+
+var (
+	errPrivateAddress  = errors.New("must not point to a loopback, link-local or private address")
+	errPrivateResolved = errors.New("must not resolve to a loopback, link-local or private address")
+)
+
+// isPrivateAddress - адрес, на который вебхук не должен уходить без allow_private_targets:
+// loopback, link-local (в том числе метаданные облака), внутренние сети, пустой и групповой
+func isPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast()
+}
+
+// checkWebhookHost проверяет адрес вебхука при регистрации. Имена разрешаются сразу,
+// чтобы сообщить об ошибке заранее; окончательная проверка - при соединении
+func checkWebhookHost(host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if isPrivateAddress(ip) {
+			return errPrivateAddress
+		}
+		return nil
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errPrivateAddress
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookLookupTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		// имя может появиться позже; соединение всё равно проверит адрес
+		return nil
+	}
+	for _, addr := range addrs {
+		if isPrivateAddress(addr.IP) {
+			return errPrivateResolved
+		}
+	}
+	return nil
+}
+
+// newWebhookClient возвращает клиент для доставок; без allowPrivate он отказывается
+// соединяться с внутренними адресами, даже если имя вебхука стало указывать на них после регистрации
+func newWebhookClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	if allowPrivate {
+		return &http.Client{Timeout: timeout}
+	}
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateAddress(ip) {
+				return fmt.Errorf("webhook target %s is not a public address", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// через прокси соединение шло бы к прокси, а не к проверенному адресу
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "nails_game/internal/models"
+
+// AuthService проверяет пароль игрока и выдаёт токен входа, действующий во всех партиях
+type AuthService interface {
+	Login(email, password string) (*models.Player, string, error)
+}
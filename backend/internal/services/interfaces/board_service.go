@@ -0,0 +1,13 @@
+package interfaces
+
+import "nails_game/internal/models"
+
+// BoardService управляет раскладками нерегулярных полей
+type BoardService interface {
+	// CreatePreset сохраняет пресет из явных координат или из зерна и размера поля
+	CreatePreset(name string, layout models.BoardLayout, size int) (*models.BoardPreset, error)
+	GetPreset(name string) (*models.BoardPreset, error)
+	ListPresets() ([]models.BoardPreset, error)
+	// GenerateBoard строит координаты поля по зерну, не сохраняя их
+	GenerateBoard(seed int64, size int) ([]int, error)
+}
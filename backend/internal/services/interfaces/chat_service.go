@@ -0,0 +1,39 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+type ChatService interface {
+	// Send отправляет сообщение playerID в канал партии
+	Send(gameID, playerID uuid.UUID, channel enums.ChatChannel, text string) (*models.ChatMessage, error)
+	// Messages возвращает сообщения партии, видимые viewerID; uuid.Nil - зритель без токена
+	Messages(gameID, viewerID uuid.UUID) ([]models.ChatMessage, error)
+	// MessagesIn - то же для уже загруженной партии, например в потоке партии
+	MessagesIn(game *models.Game, viewerID uuid.UUID) ([]models.ChatMessage, error)
+	// Mute скрывает от игрока сообщения соперников в чате игроков, Unmute возвращает их
+	Mute(gameID, playerID uuid.UUID) error
+	Unmute(gameID, playerID uuid.UUID) error
+	// ForgetIdleSenders забывает игроков, у которых не осталось сообщений в окне ограничения
+	// частоты, и возвращает их число; вызывается периодически, чтобы учёт не рос
+	ForgetIdleSenders() int
+}
+
+// ChatFilter - подключаемая проверка сообщений чата перед сохранением
+type ChatFilter interface {
+	// Filter возвращает текст для сохранения; false отклоняет сообщение целиком
+	Filter(text string) (string, bool)
+}
+
+// ChatPolicy - ограничения чата
+type ChatPolicy struct {
+	MaxMessageLength int
+	// MaxMessages - сколько сообщений игрок может отправить за RateWindow во все чаты
+	MaxMessages int
+	RateWindow  time.Duration
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "nails_game/internal/models"
+
+// GameEventOutbox готовит доставки вебхуков для событий партии до её сохранения;
+// репозиторий партий записывает их в одной транзакции с событиями, поэтому падение
+// процесса сразу после хода не теряет событий
+type GameEventOutbox interface {
+	Outbox(game *models.Game, events []models.GameEvent) ([]models.WebhookDelivery, error)
+}
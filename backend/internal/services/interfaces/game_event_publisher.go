@@ -0,0 +1,9 @@
+package interfaces
+
+import "nails_game/internal/models"
+
+// GameEventPublisher получает события партии после того, как они сохранены;
+// вызывается под блокировкой изменений партий и не должен надолго её задерживать
+type GameEventPublisher interface {
+	Publish(game *models.Game, events []models.GameEvent)
+}
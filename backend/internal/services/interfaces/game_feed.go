@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/google/uuid"
+
+// GameFeed будит открытые потоки партий, когда в партии появились события или сообщения чата
+type GameFeed interface {
+	GameEventPublisher
+	// Subscribe возвращает канал сигналов об изменениях партии; возвращённая функция отписывает
+	Subscribe(gameID uuid.UUID) (<-chan struct{}, func())
+	// Notify будит подписчиков партии, например после сообщения в чат
+	Notify(gameID uuid.UUID)
+}
@@ -7,7 +7,35 @@ import (
 )
 
 type GameService interface {
-	CreateGame(lineSize int, firstPlayerID, secondPlayerID uuid.UUID) (*models.Game, error)
+	// CreateGame создаёт партию; игроки ходят в порядке playerIDs
+	CreateGame(settings models.GameSettings, playerIDs []uuid.UUID) (*models.Game, error)
+	// CheckSettings проверяет параметры партии на двоих, например для турнира
+	CheckSettings(settings models.GameSettings) error
 	MakeMove(move models.Move) (*CachedMoveResult, error)
-	GetGame(gameID uuid.UUID) (*models.Game, error)
+	// ListVariants возвращает доступные варианты со схемами их параметров
+	ListVariants() []models.VariantDefinition
+	// GetGame возвращает партию глазами viewerID; uuid.Nil - зритель без токена
+	GetGame(gameID, viewerID uuid.UUID) (*GameView, error)
+	GetGameEvents(gameID, viewerID uuid.UUID) ([]models.RecordedGameEvent, error)
+	// LoadGame возвращает партию без проверки зрителя; для турниров и серий, а не для ответов API
+	LoadGame(gameID uuid.UUID) (*models.Game, error)
+	// ListLiveGames возвращает идущие партии, открытые для зрителей
+	ListLiveGames(filter models.LiveGameFilter) ([]models.Game, error)
+	// FlagExpiredGames завершает партии с истекшим временем на ход; ошибка одной
+	// партии не останавливает остальные, все ошибки возвращаются вместе
+	FlagExpiredGames() (int, error)
+	Resign(gameID, playerID uuid.UUID) (*models.Game, error)
+	Abort(gameID, playerID uuid.UUID) (*models.Game, error)
+	OfferDraw(gameID, playerID uuid.UUID) (*models.Game, error)
+	AcceptDraw(gameID, playerID uuid.UUID) (*models.Game, error)
+	DeclineDraw(gameID, playerID uuid.UUID) (*models.Game, error)
+	RequestTakeback(gameID, playerID uuid.UUID) (*models.Game, error)
+	AcceptTakeback(gameID, playerID uuid.UUID) (*models.Game, error)
+	DeclineTakeback(gameID, playerID uuid.UUID) (*models.Game, error)
+	OfferRematch(gameID, playerID uuid.UUID) (*models.Game, error)
+	AcceptRematch(gameID, playerID uuid.UUID) (*models.Game, error)
+	DeclineRematch(gameID, playerID uuid.UUID) (*models.Game, error)
+	// CreateRematch создаёт следующую партию тех же игроков со сменой цвета без предложения;
+	// если реванш уже создан, возвращает его
+	CreateRematch(gameID uuid.UUID) (*models.Game, error)
 }
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"time"
+
+	"nails_game/internal/models/enums"
+)
+
+// GameSettingsPolicy - серверные ограничения на параметры создаваемых партий
+type GameSettingsPolicy struct {
+	MinLineSize         int
+	MaxLineSize         int
+	DefaultLineSize     int
+	AllowedVariants     []enums.Variant
+	AllowedTimeControls []enums.TimeControlType
+	// MaxCoordinateGap - наибольший шаг между соседними позициями поля, построенного по зерну
+	MaxCoordinateGap int
+	// AllowTakebacksInRated разрешает возврат ходов в рейтинговых партиях
+	AllowTakebacksInRated bool
+	// FogSpectatorDelay - на сколько зрители партии в варианте fog отстают от игры
+	FogSpectatorDelay time.Duration
+	// AllowPrivateGames разрешает закрытые партии; без токенов игроков закрыть партию от зрителей нельзя
+	AllowPrivateGames bool
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+// GameView - партия глазами одного зрителя: в варианте fog Line содержит
+// только видимые ему гвозди, для остальных вариантов совпадает с Game.Line
+type GameView struct {
+	Game *models.Game
+	Line []enums.PositionState
+}
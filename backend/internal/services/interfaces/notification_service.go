@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+)
+
+// NotificationPolicy - когда напоминать о ходе и как часто присылать сводки
+type NotificationPolicy struct {
+	// ReminderBefore - за сколько до истечения времени на ход приходит напоминание
+	ReminderBefore time.Duration
+	// DigestInterval - не чаще одной сводки за этот период
+	DigestInterval time.Duration
+}
+
+// NotificationService сообщает игрокам заочных партий, что наступил их ход
+// и что время на ход истекает
+type NotificationService interface {
+	Preferences(playerID uuid.UUID) (*models.NotificationPreferences, error)
+	UpdatePreferences(playerID uuid.UUID, preferences models.NotificationPreferences) (*models.NotificationPreferences, error)
+	// NotifyPlayers записывает уведомления по идущим заочным партиям и отправляет
+	// накопившиеся письма; возвращает число отправленных писем
+	NotifyPlayers() (int, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+// Message - письмо игроку
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier доставляет письма игрокам
+type Notifier interface {
+	Send(message Message) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+)
+
+// SeriesService проводит серии партий двух игроков: создаёт первую партию,
+// после каждой завершённой партии создаёт реванш и подводит итог серии
+type SeriesService interface {
+	CreateSeries(settings models.SeriesSettings) (*models.Series, error)
+	GetSeries(seriesID uuid.UUID) (*models.Series, error)
+	ListSeries() ([]models.Series, error)
+	// AdvanceSeries начинает серии по расписанию, записывает результаты завершённых партий
+	// и создаёт следующие; ошибка одной серии не останавливает остальные.
+	// Возвращает число изменённых серий и объединённые ошибки
+	AdvanceSeries() (int, error)
+}
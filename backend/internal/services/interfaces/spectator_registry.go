@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/google/uuid"
+
+// SpectatorRegistry считает зрителей, которые сейчас следят за партиями
+type SpectatorRegistry interface {
+	// Watch отмечает нового зрителя партии; возвращённая функция снимает отметку
+	Watch(gameID uuid.UUID) func()
+	Count(gameID uuid.UUID) int
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/google/uuid"
+
+// TokenService выдаёт и проверяет подписанные токены игроков
+type TokenService interface {
+	// Issue выдаёт токен игрока; токен с gameID действует только в этой партии,
+	// токен с uuid.Nil выдаётся при входе по паролю и действует везде
+	Issue(playerID, gameID uuid.UUID) string
+	// Verify возвращает игрока, которому выдан токен; gameID - партия запроса
+	// или uuid.Nil, если запрос не относится к партии
+	Verify(token string, gameID uuid.UUID) (uuid.UUID, error)
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+)
+
+// TournamentService проводит турниры: регистрирует участников, составляет пары
+// каждого тура, создаёт партии и подводит итоги
+type TournamentService interface {
+	CreateTournament(settings models.TournamentSettings, organizerID uuid.UUID) (*models.Tournament, error)
+	GetTournament(tournamentID uuid.UUID) (*models.Tournament, error)
+	ListTournaments() ([]models.Tournament, error)
+	// Register регистрирует игрока; на арену можно прийти и после старта или вернуться после Leave
+	Register(tournamentID, playerID uuid.UUID) (*models.Tournament, error)
+	// Leave снимает игрока арены с новых пар, пока он не зарегистрируется снова
+	Leave(tournamentID, playerID uuid.UUID) (*models.Tournament, error)
+	// Start запускает турнир досрочно; доступно только организатору
+	Start(tournamentID, playerID uuid.UUID) (*models.Tournament, error)
+	// AdvanceTournaments запускает турниры по расписанию, записывает результаты
+	// завершённых партий и начинает следующие туры; ошибка одного турнира не останавливает
+	// остальные. Возвращает число изменённых турниров и объединённые ошибки
+	AdvanceTournaments() (int, error)
+	// AdvanceArenas записывает результаты партий арен и сводит ожидающих игроков;
+	// ошибка одной арены не останавливает остальные
+	AdvanceArenas() (int, error)
+	Standings(tournamentID uuid.UUID) ([]models.Standing, error)
+}
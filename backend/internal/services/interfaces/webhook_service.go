@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+// WebhookPolicy - как отправляются события на вебхуки
+type WebhookPolicy struct {
+	// MaxAttempts - после стольких неудачных попыток доставка становится недоставленной
+	MaxAttempts int
+	// RetryBase - пауза перед второй попыткой, каждая следующая вдвое дольше, но не дольше RetryMax
+	RetryBase time.Duration
+	RetryMax  time.Duration
+	// Timeout - сколько ждать ответа вебхука
+	Timeout time.Duration
+	// AllowPrivateTargets разрешает адреса loopback, link-local и внутренних сетей
+	AllowPrivateTargets bool
+}
+
+// WebhookActor - кто управляет вебхуками: игрок по токену или администратор по ключу
+type WebhookActor struct {
+	PlayerID uuid.UUID
+	Admin    bool
+}
+
+// WebhookService отправляет события партий на зарегистрированные адреса
+// подписанными запросами с повторами при ошибках
+type WebhookService interface {
+	GameEventOutbox
+
+	// Register регистрирует вебхук и возвращает его вместе с ключом подписи
+	Register(actor WebhookActor, settings models.WebhookSettings) (*models.Webhook, error)
+	Delete(actor WebhookActor, webhookID uuid.UUID) error
+	// Deliveries возвращает доставки вебхука с указанным состоянием
+	Deliveries(actor WebhookActor, webhookID uuid.UUID, status enums.DeliveryStatus) ([]models.WebhookDelivery, error)
+	// RetryDelivery возвращает недоставленное событие в очередь отправки
+	RetryDelivery(actor WebhookActor, webhookID, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	// DeliverWebhooks отправляет доставки, время попытки которых наступило;
+	// возвращает число успешно доставленных
+	DeliverWebhooks() (int, error)
+}
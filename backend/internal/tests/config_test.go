@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/config"
+)
+
+func TestConfig_LayersOverrideEachOther(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: 9000\ngame:\n  default_line_size: 11\ndatabase:\n  dbname: from_file\n"), 0o600))
+
+	t.Setenv("POSTGRES_DB", "from_env")
+	t.Setenv("LINE_SIZE", "12")
+
+	cfg, command, err := config.Load([]string{"--config", path, "--line-size", "13", "migrate", "up"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "9000", cfg.Server.Port)
+	assert.Equal(t, "from_env", cfg.Database.DBName)
+	assert.Equal(t, 13, cfg.Game.DefaultLineSize)
+	assert.Equal(t, []string{"migrate", "up"}, command)
+}
+
+func TestConfig_SeedsPlayersOnlyWhenAsked(t *testing.T) {
+	cfg, _, err := config.Load(nil)
+	require.NoError(t, err)
+	assert.False(t, cfg.Database.SeedPlayers)
+
+	t.Setenv("SEED_PLAYERS", "true")
+	cfg, _, err = config.Load(nil)
+	require.NoError(t, err)
+	assert.True(t, cfg.Database.SeedPlayers)
+}
+
+func TestConfig_BoolFlagWithoutValue(t *testing.T) {
+	cfg, command, err := config.Load([]string{"--seed-players", "--webhooks-allow-private-targets=false", "serve"})
+
+	require.NoError(t, err)
+	assert.True(t, cfg.Database.SeedPlayers)
+	assert.False(t, cfg.Webhooks.AllowPrivateTargets)
+	assert.Equal(t, []string{"serve"}, command)
+}
+
+func TestConfig_ValidationListsEveryProblem(t *testing.T) {
+	_, _, err := config.Load([]string{"--port", "0", "--line-size", "-1", "--db-sslmode", "sometimes"})
+
+	var problems config.ValidationErrors
+	require.True(t, errors.As(err, &problems))
+	assert.Len(t, problems, 3)
+}
+
+func TestConfig_FogVariantRequiresTokenSecret(t *testing.T) {
+	cfg := config.Default()
+	cfg.Game.AllowedVariants = append(cfg.Game.AllowedVariants, "fog")
+
+	var problems config.ValidationErrors
+	require.ErrorAs(t, cfg.Validate(), &problems)
+	assert.Equal(t, config.ValidationErrors{"auth.token_secret: is required when the fog variant is allowed"}, problems)
+
+	cfg.Auth.TokenSecret = "token-secret"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_MaxBodySizeMustParse(t *testing.T) {
+	cfg := config.Default()
+	cfg.Limits.MaxBodySize = "10 parsecs"
+
+	var problems config.ValidationErrors
+	require.ErrorAs(t, cfg.Validate(), &problems)
+	assert.Equal(t, config.ValidationErrors{`limits.max_body_size: "10 parsecs" is not a valid size, e.g. 512K or 1M`}, problems)
+
+	cfg.Limits.MaxBodySize = "512K"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_PrintRedactsSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Password = "super-secret"
+	cfg.Auth.TokenSecret = "token-secret"
+
+	var out bytes.Buffer
+	require.NoError(t, config.Print(&out, cfg))
+
+	assert.NotContains(t, out.String(), "super-secret")
+	assert.NotContains(t, out.String(), "token-secret")
+	assert.Contains(t, out.String(), "dbname: nails_db")
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/controllers"
+	"nails_game/internal/models/dtos"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+func handleError(t *testing.T, err error) (int, dtos.ErrorResponse) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/game", nil)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	ctx.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+
+	controllers.NewErrorHandler(logrus.New())(err, ctx)
+
+	var resp dtos.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestErrorHandler_MapsDomainErrors(t *testing.T) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+	validation.Add("line_size", "must be positive")
+
+	cases := []struct {
+		err    error
+		status int
+		code   serviceErrors.Code
+	}{
+		{serviceErrors.NewNotFoundError(serviceErrors.CodeGameNotFound, "game not found"), http.StatusNotFound, serviceErrors.CodeGameNotFound},
+		{serviceErrors.NewUnauthenticatedError(serviceErrors.CodeInvalidToken, "expired"), http.StatusUnauthorized, serviceErrors.CodeInvalidToken},
+		{serviceErrors.NewUnauthorizedError(serviceErrors.CodePlayerNotInGame, "no"), http.StatusForbidden, serviceErrors.CodePlayerNotInGame},
+		{serviceErrors.NewInvalidOperationError(serviceErrors.CodeNotYourTurn, "wait"), http.StatusConflict, serviceErrors.CodeNotYourTurn},
+		{fmt.Errorf("wrapped: %w", validation), http.StatusUnprocessableEntity, serviceErrors.CodeValidationFailed},
+		{serviceErrors.NewRateLimitedError(serviceErrors.CodeChatRateLimited, "slow down"), http.StatusTooManyRequests, serviceErrors.CodeChatRateLimited},
+		{echo.NewHTTPError(http.StatusBadRequest, "invalid game ID"), http.StatusBadRequest, serviceErrors.CodeBadRequest},
+		{errors.New("database is down"), http.StatusInternalServerError, serviceErrors.CodeInternal},
+	}
+
+	for _, tc := range cases {
+		status, resp := handleError(t, tc.err)
+
+		assert.Equal(t, tc.status, status)
+		assert.Equal(t, string(tc.code), resp.Code)
+		assert.Equal(t, "req-1", resp.RequestID)
+	}
+}
+
+func TestErrorHandler_HidesInternalErrorMessage(t *testing.T) {
+	_, resp := handleError(t, errors.New("pq: password authentication failed"))
+
+	assert.NotContains(t, resp.Message, "password")
+}
+
+func TestErrorHandler_ValidationDetailsListFields(t *testing.T) {
+	validation := serviceErrors.NewValidationError(serviceErrors.CodeValidationFailed)
+	validation.Add("line_size", "must be positive")
+	validation.Add("variant", "unknown")
+
+	_, resp := handleError(t, validation)
+
+	fields, ok := resp.Details["fields"].([]interface{})
+	require.True(t, ok)
+	assert.Len(t, fields, 2)
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+func newActionTestService(game *models.Game) (serviceInterfaces.GameService, *mocks.MockGameRepository) {
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	return services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy()), mockGameRepo
+}
+
+func TestGameService_Resign(t *testing.T) {
+	game := createTestGame()
+	service, _ := newActionTestService(game)
+
+	result, err := service.Resign(game.ID, game.FirstPlayerID)
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.SecondPlayerWon, result.Status)
+	assert.Equal(t, enums.ResignTermination, result.Termination)
+	require.Len(t, result.PendingEvents(), 2)
+	assert.IsType(t, &models.PlayerResigned{}, result.PendingEvents()[0])
+}
+
+func TestGameService_Resign_GameEnded(t *testing.T) {
+	game := createTestGame()
+	game.Status = enums.FirstPlayerWon
+	service, _ := newActionTestService(game)
+
+	_, err := service.Resign(game.ID, game.SecondPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeGameFinished)
+}
+
+func TestGameService_Abort_BeforeBothPlayersMoved(t *testing.T) {
+	game := createTestGame()
+	game.Line[0] = enums.FirstPlayer
+	game.MoveCount = 1
+	service, _ := newActionTestService(game)
+
+	result, err := service.Abort(game.ID, game.SecondPlayerID)
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.Aborted, result.Status)
+	assert.Equal(t, enums.AbortTermination, result.Termination)
+}
+
+func TestGameService_Abort_NotAllowedAfterBothPlayersMoved(t *testing.T) {
+	game := createTestGame()
+	game.Line[0] = enums.FirstPlayer
+	game.Line[1] = enums.SecondPlayer
+	game.MoveCount = 2
+	service, mockGameRepo := newActionTestService(game)
+
+	_, err := service.Abort(game.ID, game.FirstPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeAbortNotAllowed)
+	mockGameRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGameService_DrawOfferAndAccept(t *testing.T) {
+	game := createTestGame()
+	service, _ := newActionTestService(game)
+
+	_, err := service.OfferDraw(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+
+	_, err = service.OfferDraw(game.ID, game.FirstPlayerID)
+	assertErrorCode(t, err, serviceErrors.CodeDrawAlreadyOffered)
+
+	_, err = service.AcceptDraw(game.ID, game.FirstPlayerID)
+	assertErrorCode(t, err, serviceErrors.CodeNoDrawOffer)
+
+	result, err := service.AcceptDraw(game.ID, game.SecondPlayerID)
+	require.NoError(t, err)
+	assert.Equal(t, enums.Draw, result.Status)
+	assert.Equal(t, enums.AgreementTermination, result.Termination)
+	assert.Nil(t, result.DrawOfferedBy)
+}
+
+func TestGameService_DeclineDraw(t *testing.T) {
+	game := createTestGame()
+	service, _ := newActionTestService(game)
+
+	_, err := service.OfferDraw(game.ID, game.SecondPlayerID)
+	require.NoError(t, err)
+
+	result, err := service.DeclineDraw(game.ID, game.FirstPlayerID)
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.InProgress, result.Status)
+	assert.Nil(t, result.DrawOfferedBy)
+}
+
+func TestGameService_MakeMove_ImplicitlyDeclinesDraw(t *testing.T) {
+	game := createTestGame()
+	service, _ := newActionTestService(game)
+
+	_, err := service.OfferDraw(game.ID, game.SecondPlayerID)
+	require.NoError(t, err)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 0})
+
+	require.NoError(t, err)
+	assert.Nil(t, result.Game.DrawOfferedBy)
+}
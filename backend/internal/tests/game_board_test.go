@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+func newBoardTestService() (serviceInterfaces.GameService, *mocks.MockBoardPresetRepository) {
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockBoardRepo := new(mocks.MockBoardPresetRepository)
+
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+	mockGameRepo.On("Create", mock.Anything).Return(nil)
+
+	return services.NewGameService(mockGameRepo, mockPlayerRepo, mockBoardRepo, testPolicy()), mockBoardRepo
+}
+
+func TestGameService_CreateGame_WithCoordinates(t *testing.T) {
+	service, _ := newBoardTestService()
+
+	game, err := service.CreateGame(models.GameSettings{
+		Board: models.BoardLayout{Coordinates: []int{0, 1, 4, 5, 11}},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+
+	require.NoError(t, err)
+	assert.Len(t, game.Line, 5)
+	assert.Equal(t, []int{0, 1, 4, 5, 11}, game.Coordinates)
+}
+
+func TestGameService_CreateGame_WithPreset(t *testing.T) {
+	service, mockBoardRepo := newBoardTestService()
+	mockBoardRepo.On("GetByName", "tournament").
+		Return(&models.BoardPreset{Name: "tournament", Coordinates: []int{0, 2, 3, 7}}, nil)
+
+	game, err := service.CreateGame(models.GameSettings{
+		Board: models.BoardLayout{Preset: "tournament"},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2, 3, 7}, game.Coordinates)
+	created := game.PendingEvents()[0].(*models.GameCreated)
+	assert.Equal(t, "tournament", created.BoardPreset)
+}
+
+func TestGameService_CreateGame_SeededBoardIsReproducible(t *testing.T) {
+	service, _ := newBoardTestService()
+	seed := int64(42)
+	settings := models.GameSettings{LineSize: 12, Board: models.BoardLayout{Seed: &seed}}
+
+	first, err := service.CreateGame(settings, []uuid.UUID{uuid.New(), uuid.New()})
+	require.NoError(t, err)
+	second, err := service.CreateGame(settings, []uuid.UUID{uuid.New(), uuid.New()})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Coordinates, second.Coordinates)
+	require.Len(t, first.Coordinates, 12)
+	for i := 1; i < len(first.Coordinates); i++ {
+		gap := first.Coordinates[i] - first.Coordinates[i-1]
+		assert.True(t, gap >= 1 && gap <= testPolicy().MaxCoordinateGap, "gap %d out of range", gap)
+	}
+}
+
+func TestGameService_CreateGame_RejectsInvalidBoards(t *testing.T) {
+	seed := int64(1)
+	tests := []struct {
+		name  string
+		board models.BoardLayout
+		field string
+	}{
+		{"not increasing", models.BoardLayout{Coordinates: []int{0, 3, 3, 5}}, "board.coordinates"},
+		{"negative", models.BoardLayout{Coordinates: []int{-1, 3, 4}}, "board.coordinates"},
+		{"unknown preset", models.BoardLayout{Preset: "missing"}, "board.preset"},
+		{"several sources", models.BoardLayout{Coordinates: []int{0, 1, 2}, Seed: &seed}, "board"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockBoardRepo := newBoardTestService()
+			mockBoardRepo.On("GetByName", "missing").Return(nil, repositories.ErrBoardPresetNotFound)
+
+			_, err := service.CreateGame(models.GameSettings{Board: tt.board}, []uuid.UUID{uuid.New(), uuid.New()})
+
+			var validation *serviceErrors.ValidationError
+			require.ErrorAs(t, err, &validation)
+			require.Len(t, validation.Fields, 1)
+			assert.Equal(t, tt.field, validation.Fields[0].Field)
+		})
+	}
+}
+
+func TestGameService_IrregularBoard_ScoresRealDistances(t *testing.T) {
+	// по индексам нити равны (2 и 2) и ничья засчитывается второму игроку,
+	// по координатам у первого 0-9 длиной 9, у второго 8-10 длиной 2
+	game := createTestGame()
+	game.Line = make([]enums.PositionState, 4)
+	game.Coordinates = []int{0, 8, 9, 10}
+	playTestMoves(game,
+		testMove{game.FirstPlayerID, 0},
+		testMove{game.SecondPlayerID, 1},
+		testMove{game.FirstPlayerID, 2},
+	)
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.FirstPlayerWon, result.Game.Status)
+}
+
+func TestBoardService_CreatePresetFromSeed(t *testing.T) {
+	mockBoardRepo := new(mocks.MockBoardPresetRepository)
+	mockBoardRepo.On("GetByName", "swiss-r1").Return(nil, repositories.ErrBoardPresetNotFound)
+	mockBoardRepo.On("Create", mock.Anything).Return(nil)
+	service := services.NewBoardService(mockBoardRepo, testPolicy())
+	seed := int64(7)
+
+	preset, err := service.CreatePreset("swiss-r1", models.BoardLayout{Seed: &seed}, 10)
+
+	require.NoError(t, err)
+	generated, err := service.GenerateBoard(seed, 10)
+	require.NoError(t, err)
+	assert.Equal(t, generated, preset.Coordinates)
+	mockBoardRepo.AssertCalled(t, "Create", preset)
+}
+
+func TestBoardService_CreatePreset_NameTaken(t *testing.T) {
+	mockBoardRepo := new(mocks.MockBoardPresetRepository)
+	mockBoardRepo.On("GetByName", "main").Return(&models.BoardPreset{Name: "main"}, nil)
+	service := services.NewBoardService(mockBoardRepo, testPolicy())
+
+	_, err := service.CreatePreset("main", models.BoardLayout{Coordinates: []int{0, 1, 5}}, 0)
+
+	assertErrorCode(t, err, serviceErrors.CodeBoardPresetExists)
+	mockBoardRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
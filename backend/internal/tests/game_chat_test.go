@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+// chatGames - сервис партий, который отдаёт одну партию
+type chatGames struct {
+	serviceInterfaces.GameService
+	game *models.Game
+}
+
+func (g *chatGames) GetGame(gameID, viewerID uuid.UUID) (*serviceInterfaces.GameView, error) {
+	return &serviceInterfaces.GameView{Game: g.game, Line: g.game.Line}, nil
+}
+
+func testChatPolicy() serviceInterfaces.ChatPolicy {
+	return serviceInterfaces.ChatPolicy{MaxMessageLength: 20, MaxMessages: 2, RateWindow: 10 * time.Second}
+}
+
+// newChatTestService возвращает сервис чата, сохранённые сообщения которого попадают в messages
+func newChatTestService(
+	game *models.Game,
+	clock *fakeClock,
+) (serviceInterfaces.ChatService, *mocks.MockChatRepository, *[]models.ChatMessage) {
+	messages := &[]models.ChatMessage{}
+	mockChatRepo := new(mocks.MockChatRepository)
+	mockChatRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		*messages = append(*messages, *args.Get(0).(*models.ChatMessage))
+	}).Return(nil)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+
+	service := services.NewChatService(mockChatRepo, mockPlayerRepo, &chatGames{game: game}, nil,
+		services.NewWordListFilter([]string{"darn"}), testChatPolicy(), clock.Now)
+	return service, mockChatRepo, messages
+}
+
+func TestChatService_Send_ValidatesMessage(t *testing.T) {
+	game := createTestGame()
+	service, _, _ := newChatTestService(game, &fakeClock{now: time.Now()})
+
+	_, err := service.Send(game.ID, game.FirstPlayerID, "lobby", "  ")
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.Fields, 2)
+	assert.Equal(t, "channel", validation.Fields[0].Field)
+	assert.Equal(t, "text", validation.Fields[1].Field)
+
+	_, err = service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "a message that is far too long")
+	assertErrorCode(t, err, serviceErrors.CodeValidationFailed)
+}
+
+func TestChatService_Send_KeepsChannelsApart(t *testing.T) {
+	game := createTestGame()
+	service, _, _ := newChatTestService(game, &fakeClock{now: time.Now()})
+
+	_, err := service.Send(game.ID, uuid.New(), enums.PlayersChannel, "hi")
+	assertErrorCode(t, err, serviceErrors.CodePlayerNotInGame)
+
+	_, err = service.Send(game.ID, game.FirstPlayerID, enums.SpectatorsChannel, "hi")
+	assertErrorCode(t, err, serviceErrors.CodeSpectatorsOnly)
+
+	game.Status = enums.Draw
+	_, err = service.Send(game.ID, game.FirstPlayerID, enums.SpectatorsChannel, "thanks for watching")
+	require.NoError(t, err)
+}
+
+func TestChatService_Send_FiltersWords(t *testing.T) {
+	game := createTestGame()
+	service, _, _ := newChatTestService(game, &fakeClock{now: time.Now()})
+
+	message, err := service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "Darn, darnit!")
+
+	require.NoError(t, err)
+	assert.Equal(t, "****, darnit!", message.Text)
+}
+
+func TestChatService_Send_LimitsRatePerPlayer(t *testing.T) {
+	game := createTestGame()
+	clock := &fakeClock{now: time.Now()}
+	service, _, messages := newChatTestService(game, clock)
+
+	for i := 0; i < 2; i++ {
+		_, err := service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "gl")
+		require.NoError(t, err)
+	}
+	_, err := service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "hf")
+	assertErrorCode(t, err, serviceErrors.CodeChatRateLimited)
+
+	_, err = service.Send(game.ID, game.SecondPlayerID, enums.PlayersChannel, "gl")
+	require.NoError(t, err)
+
+	clock.Advance(10 * time.Second)
+	_, err = service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "hf")
+	require.NoError(t, err)
+	assert.Len(t, *messages, 4)
+}
+
+func TestChatService_ForgetIdleSenders(t *testing.T) {
+	game := createTestGame()
+	clock := &fakeClock{now: time.Now()}
+	service, _, _ := newChatTestService(game, clock)
+
+	_, err := service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "gl")
+	require.NoError(t, err)
+	clock.Advance(5 * time.Second)
+	_, err = service.Send(game.ID, game.SecondPlayerID, enums.PlayersChannel, "hf")
+	require.NoError(t, err)
+
+	assert.Zero(t, service.ForgetIdleSenders())
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, 1, service.ForgetIdleSenders())
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, 1, service.ForgetIdleSenders())
+	assert.Zero(t, service.ForgetIdleSenders())
+}
+
+func TestChatService_Messages_VisibleByChannelAndMute(t *testing.T) {
+	game := createTestGame()
+	spectatorID := uuid.New()
+	service, mockChatRepo, messages := newChatTestService(game, &fakeClock{now: time.Now()})
+
+	_, err := service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "gl")
+	require.NoError(t, err)
+	_, err = service.Send(game.ID, game.SecondPlayerID, enums.PlayersChannel, "you too")
+	require.NoError(t, err)
+	_, err = service.Send(game.ID, spectatorID, enums.SpectatorsChannel, "nice")
+	require.NoError(t, err)
+	mockChatRepo.On("ListByGame", game.ID).Return(*messages, nil)
+	mockChatRepo.On("ListMutes", game.ID).Return([]uuid.UUID{game.SecondPlayerID}, nil)
+
+	texts := func(viewerID uuid.UUID) []string {
+		messages, err := service.Messages(game.ID, viewerID)
+		require.NoError(t, err)
+		var result []string
+		for _, message := range messages {
+			result = append(result, message.Text)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"gl", "you too"}, texts(game.FirstPlayerID))
+	assert.Equal(t, []string{"you too"}, texts(game.SecondPlayerID))
+	assert.Equal(t, []string{"nice"}, texts(uuid.Nil))
+
+	game.Status = enums.FirstPlayerWon
+	assert.Equal(t, []string{"gl", "you too", "nice"}, texts(uuid.Nil))
+}
+
+func TestChatService_Mute_OnlyForPlayers(t *testing.T) {
+	game := createTestGame()
+	mockChatRepo := new(mocks.MockChatRepository)
+	mockChatRepo.On("SetMuted", game.ID, game.FirstPlayerID, true).Return(nil)
+	service := services.NewChatService(mockChatRepo, new(mocks.MockPlayerRepository), &chatGames{game: game}, nil,
+		services.NewWordListFilter(nil), testChatPolicy(), time.Now)
+
+	require.NoError(t, service.Mute(game.ID, game.FirstPlayerID))
+	assertErrorCode(t, service.Mute(game.ID, uuid.New()), serviceErrors.CodePlayerNotInGame)
+	mockChatRepo.AssertExpectations(t)
+}
+
+func TestChatService_Send_WakesGameStreams(t *testing.T) {
+	game := createTestGame()
+	mockChatRepo := new(mocks.MockChatRepository)
+	mockChatRepo.On("Create", mock.Anything).Return(nil)
+	feed := services.NewGameFeed(0)
+	service := services.NewChatService(mockChatRepo, new(mocks.MockPlayerRepository), &chatGames{game: game}, feed,
+		services.NewWordListFilter(nil), testChatPolicy(), time.Now)
+	updates, unsubscribe := feed.Subscribe(game.ID)
+	defer unsubscribe()
+
+	_, err := service.Send(game.ID, game.FirstPlayerID, enums.PlayersChannel, "hi")
+
+	require.NoError(t, err)
+	assert.Len(t, updates, 1)
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+// createAlmostFullGame возвращает партию на 8 позиций, где первому игроку
+// осталось поставить последний гвоздь на позицию 7
+func createAlmostFullGame(variant enums.Variant) *models.Game {
+	game := createTestGame()
+	game.Variant = variant
+	game.Rules = models.LegacyRules(variant)
+	game.Line = make([]enums.PositionState, 8)
+	playTestMoves(game,
+		testMove{game.FirstPlayerID, 0},
+		testMove{game.SecondPlayerID, 1},
+		testMove{game.FirstPlayerID, 2},
+		testMove{game.SecondPlayerID, 4},
+		testMove{game.FirstPlayerID, 3},
+		testMove{game.SecondPlayerID, 5},
+		testMove{game.SecondPlayerID, 6},
+	)
+	game.CurrentPlayerID = game.FirstPlayerID
+	return game
+}
+
+func TestGameService_CircularVariant_ThreadWrapsAround(t *testing.T) {
+	// по прямой нить первого игрока 0-2-3-7 длиной 6, по кольцу 7-0 и 2-3 - всего 2;
+	// у второго игрока 1-4-5-6 в обоих случаях 4
+	game := createAlmostFullGame(enums.CircularVariant)
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 7})
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.SecondPlayerWon, result.Game.Status)
+}
+
+func TestGameService_StandardVariant_ThreadDoesNotWrap(t *testing.T) {
+	game := createAlmostFullGame(enums.StandardVariant)
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 7})
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.FirstPlayerWon, result.Game.Status)
+}
+
+func TestGameService_CreateGame_CircularVariantRequiresLargerLine(t *testing.T) {
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+
+	policy := testPolicy()
+	policy.AllowedVariants = append(policy.AllowedVariants, enums.CircularVariant)
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), policy)
+
+	_, err := service.CreateGame(models.GameSettings{LineSize: 3, Variant: enums.CircularVariant}, []uuid.UUID{uuid.New(), uuid.New()})
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.Fields, 1)
+	assert.Equal(t, "line_size", validation.Fields[0].Field)
+	mockGameRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func createTimedTestGame(clock *fakeClock) *models.Game {
+	game := &models.Game{ID: uuid.New()}
+	firstPlayerID := uuid.New()
+	game.Raise(&models.GameCreated{
+		LineSize:        9,
+		Variant:         enums.StandardVariant,
+		TimeControl:     models.TimeControl{Type: enums.FischerTimeControl, BaseSeconds: 60, IncrementSeconds: 2},
+		FirstPlayerID:   firstPlayerID,
+		SecondPlayerID:  uuid.New(),
+		CurrentPlayerID: firstPlayerID,
+		CreatedAt:       clock.Now(),
+	})
+	game.ClearPendingEvents()
+	return game
+}
+
+func TestGameService_MakeMove_DeductsElapsedTimeAndAddsIncrement(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createTimedTestGame(clock)
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy(), services.WithClock(clock.Now))
+	clock.Advance(10 * time.Second)
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 0})
+
+	require.NoError(t, err)
+	assert.Equal(t, 52*time.Second, result.Game.Clock(game.FirstPlayerID))
+	assert.Equal(t, 60*time.Second, result.Game.Clock(game.SecondPlayerID))
+	require.NotNil(t, result.Game.TurnDeadline)
+	assert.Equal(t, clock.Now().Add(60*time.Second), *result.Game.TurnDeadline)
+}
+
+func TestGameService_MakeMove_AfterFlagFallFinishesGame(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createTimedTestGame(clock)
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy(), services.WithClock(clock.Now))
+	clock.Advance(61 * time.Second)
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 0})
+
+	assertErrorCode(t, err, serviceErrors.CodeTimeExpired)
+	assert.Equal(t, enums.SecondPlayerWon, game.Status)
+	assert.Equal(t, enums.TimeoutTermination, game.Termination)
+	assert.Equal(t, enums.Empty, game.Line[0])
+	mockGameRepo.AssertCalled(t, "Update", game)
+}
+
+func TestGameService_FlagExpiredGames(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createTimedTestGame(clock)
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	clock.Advance(2 * time.Minute)
+	mockGameRepo.On("ListExpired", clock.Now()).Return([]uuid.UUID{game.ID}, nil)
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy(), services.WithClock(clock.Now))
+	flagged, err := service.FlagExpiredGames()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, flagged)
+	assert.Equal(t, enums.SecondPlayerWon, game.Status)
+	assert.Equal(t, int64(0), game.FirstPlayerClockMs)
+	assert.Nil(t, game.TurnDeadline)
+}
+
+func TestGameService_FlagExpiredGames_ContinuesAfterFailure(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createTimedTestGame(clock)
+	brokenID := uuid.New()
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	clock.Advance(2 * time.Minute)
+	mockGameRepo.On("ListExpired", clock.Now()).Return([]uuid.UUID{brokenID, game.ID}, nil)
+	mockGameRepo.On("GetByID", brokenID).Return(nil, errors.New("db down"))
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	service := services.NewGameService(mockGameRepo, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository), testPolicy(), services.WithClock(clock.Now))
+	flagged, err := service.FlagExpiredGames()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), brokenID.String())
+	assert.Equal(t, 1, flagged)
+	assert.Equal(t, enums.SecondPlayerWon, game.Status)
+}
+
+func TestGameService_CreateGame_ValidatesTimeControlParameters(t *testing.T) {
+	firstPlayerID, secondPlayerID := uuid.New(), uuid.New()
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+
+	policy := testPolicy()
+	policy.AllowedTimeControls = append(policy.AllowedTimeControls, enums.FischerTimeControl)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), policy)
+	_, err := service.CreateGame(models.GameSettings{
+		TimeControl: models.TimeControl{Type: enums.FischerTimeControl},
+	}, []uuid.UUID{firstPlayerID, secondPlayerID})
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.Fields, 1)
+	assert.Equal(t, "timeControl.baseSeconds", validation.Fields[0].Field)
+}
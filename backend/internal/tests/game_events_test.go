@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+func TestGameService_MakeMove_RaisesMovePlayedEvent(t *testing.T) {
+	game := createTestGame()
+	move := models.Move{
+		GameID:   game.ID,
+		PlayerID: game.FirstPlayerID,
+		Position: 4,
+	}
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	result, err := service.MakeMove(move)
+
+	require.NoError(t, err)
+	events := result.Game.PendingEvents()
+	require.Len(t, events, 1)
+	played, ok := events[0].(*models.MovePlayed)
+	require.True(t, ok)
+	assert.Equal(t, 4, played.Position)
+	assert.Equal(t, enums.FirstPlayer, played.State)
+	assert.Equal(t, game.SecondPlayerID, played.NextPlayerID)
+	assert.Equal(t, 1, result.Game.Version)
+}
+
+func TestGameService_MakeMove_LastMoveRaisesGameFinished(t *testing.T) {
+	game := createTestGame()
+	for i := 0; i < len(game.Line)-1; i++ {
+		if i%2 == 0 {
+			game.Line[i] = enums.FirstPlayer
+		} else {
+			game.Line[i] = enums.SecondPlayer
+		}
+	}
+	game.MoveCount = len(game.Line) - 1
+
+	move := models.Move{
+		GameID:   game.ID,
+		PlayerID: game.FirstPlayerID,
+		Position: len(game.Line) - 1,
+	}
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	result, err := service.MakeMove(move)
+
+	require.NoError(t, err)
+	events := result.Game.PendingEvents()
+	require.Len(t, events, 2)
+	finished, ok := events[1].(*models.GameFinished)
+	require.True(t, ok)
+	assert.NotEqual(t, enums.InProgress, finished.Status)
+	assert.Equal(t, finished.Status, result.Game.Status)
+}
+
+func TestGame_ReplayRebuildsState(t *testing.T) {
+	original := createTestGame()
+	original.Line = nil
+	original.Raise(&models.GameCreated{
+		LineSize:        3,
+		FirstPlayerID:   original.FirstPlayerID,
+		SecondPlayerID:  original.SecondPlayerID,
+		CurrentPlayerID: original.FirstPlayerID,
+	})
+	original.Raise(&models.MovePlayed{
+		PlayerID:     original.FirstPlayerID,
+		Position:     0,
+		State:        enums.FirstPlayer,
+		NextPlayerID: original.SecondPlayerID,
+	})
+	original.Raise(&models.MovePlayed{
+		PlayerID:     original.SecondPlayerID,
+		Position:     2,
+		State:        enums.SecondPlayer,
+		NextPlayerID: original.FirstPlayerID,
+	})
+
+	replayed := models.Replay(original.ID, original.PendingEvents())
+
+	assert.Equal(t, original.Line, replayed.Line)
+	assert.Equal(t, original.MoveCount, replayed.MoveCount)
+	assert.Equal(t, original.CurrentPlayerID, replayed.CurrentPlayerID)
+	assert.Equal(t, original.Status, replayed.Status)
+	assert.Equal(t, 3, replayed.Version)
+}
@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+const testFogSpectatorDelay = 2 * time.Minute
+
+// createFogTestGame создаёт партию в варианте fog на 9 позиций; событие создания
+// остаётся в PendingEvents, чтобы из них можно было собрать историю партии
+func createFogTestGame(clock *fakeClock) *models.Game {
+	game := &models.Game{ID: uuid.New()}
+	firstPlayerID := uuid.New()
+	game.Raise(&models.GameCreated{
+		LineSize:        9,
+		Variant:         enums.FogVariant,
+		TimeControl:     models.TimeControl{Type: enums.UnlimitedTimeControl},
+		FirstPlayerID:   firstPlayerID,
+		SecondPlayerID:  uuid.New(),
+		CurrentPlayerID: firstPlayerID,
+		CreatedAt:       clock.Now(),
+	})
+	return game
+}
+
+func newFogTestService(game *models.Game, clock *fakeClock) (serviceInterfaces.GameService, *mocks.MockGameRepository) {
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+
+	policy := testPolicy()
+	policy.FogSpectatorDelay = testFogSpectatorDelay
+	service := services.NewGameService(mockGameRepo, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository),
+		policy, services.WithClock(clock.Now))
+	return service, mockGameRepo
+}
+
+// recordEvents превращает события партии в сохранённую историю с заданным временем каждого
+func recordEvents(game *models.Game, occurredAt ...time.Time) []models.RecordedGameEvent {
+	events := make([]models.RecordedGameEvent, len(game.PendingEvents()))
+	for i, event := range game.PendingEvents() {
+		events[i] = models.RecordedGameEvent{Version: i + 1, OccurredAt: occurredAt[i], Event: event}
+	}
+	return events
+}
+
+func TestGameService_Fog_CollisionRevealsNailAndCostsTurn(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createFogTestGame(clock)
+	first, second := game.FirstPlayerID, game.SecondPlayerID
+	service, _ := newFogTestService(game, clock)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: first, Position: 4})
+	require.NoError(t, err)
+	assert.Equal(t, enums.Empty, game.LineSeenBy(second)[4])
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: second, Position: 4})
+
+	require.NoError(t, err)
+	assert.Equal(t, first, result.Game.CurrentPlayerID)
+	assert.Equal(t, enums.FirstPlayer, game.Line[4])
+	assert.Equal(t, 1, game.MoveCount)
+	assert.Equal(t, enums.FirstPlayer, game.LineSeenBy(second)[4])
+
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: first, Position: 0})
+	require.NoError(t, err)
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: second, Position: 4})
+	assertErrorCode(t, err, serviceErrors.CodePositionTaken)
+}
+
+func TestGameService_Fog_PlayerSeesOnlyOwnNails(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createFogTestGame(clock)
+	service, _ := newFogTestService(game, clock)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 1})
+	require.NoError(t, err)
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 2})
+	require.NoError(t, err)
+
+	view, err := service.GetGame(game.ID, game.FirstPlayerID)
+
+	require.NoError(t, err)
+	assert.Equal(t, []enums.PositionState{0, enums.FirstPlayer, 0, 0, 0, 0, 0, 0, 0}, view.Line)
+	assert.Equal(t, enums.SecondPlayer, game.Line[2])
+}
+
+func TestGameService_Fog_SpectatorsSeeDelayedBoard(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	createdAt := clock.Now()
+	game := createFogTestGame(clock)
+	service, mockGameRepo := newFogTestService(game, clock)
+
+	clock.Advance(time.Minute)
+	firstMoveAt := clock.Now()
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 1})
+	require.NoError(t, err)
+	clock.Advance(2 * time.Minute)
+	secondMoveAt := clock.Now()
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 2})
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+
+	mockGameRepo.On("GetEvents", game.ID).Return(recordEvents(game, createdAt, firstMoveAt, secondMoveAt), nil)
+
+	view, err := service.GetGame(game.ID, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, []enums.PositionState{0, enums.FirstPlayer, 0, 0, 0, 0, 0, 0, 0}, view.Line)
+
+	events, err := service.GetGameEvents(game.ID, uuid.Nil)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestGameService_Fog_PlayerHistoryHidesOpponentMovesAfterDelay(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	createdAt := clock.Now()
+	game := createFogTestGame(clock)
+	service, mockGameRepo := newFogTestService(game, clock)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 1})
+	require.NoError(t, err)
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 1})
+	require.NoError(t, err)
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 5})
+	require.NoError(t, err)
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 7})
+	require.NoError(t, err)
+	// задержка для зрителей давно прошла, но игроку ходы соперника всё равно не видны
+	clock.Advance(10 * testFogSpectatorDelay)
+
+	history := recordEvents(game, createdAt, createdAt, createdAt, createdAt, createdAt)
+	mockGameRepo.On("GetEvents", game.ID).Return(history, nil)
+
+	events, err := service.GetGameEvents(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	var types []enums.GameEventType
+	for _, recorded := range events {
+		types = append(types, recorded.Event.EventType())
+		if played, ok := recorded.Event.(*models.MovePlayed); ok {
+			assert.Equal(t, game.FirstPlayerID, played.PlayerID)
+		}
+	}
+	assert.Equal(t, []enums.GameEventType{
+		enums.GameCreatedEvent, enums.MovePlayedEvent, enums.NailRevealedEvent, enums.MovePlayedEvent,
+	}, types)
+
+	spectated, err := service.GetGameEvents(game.ID, uuid.Nil)
+	require.NoError(t, err)
+	assert.Len(t, spectated, 5)
+}
+
+func TestGameService_Fog_FinishedGameIsFullyVisible(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createFogTestGame(clock)
+	service, _ := newFogTestService(game, clock)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 1})
+	require.NoError(t, err)
+	_, err = service.Resign(game.ID, game.SecondPlayerID)
+	require.NoError(t, err)
+
+	view, err := service.GetGame(game.ID, uuid.Nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, game.Line, view.Line)
+}
+
+func TestGameService_Fog_DisablesTakebacks(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createFogTestGame(clock)
+	service, _ := newFogTestService(game, clock)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 1})
+	require.NoError(t, err)
+	_, err = service.RequestTakeback(game.ID, game.FirstPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeTakebacksDisabled)
+}
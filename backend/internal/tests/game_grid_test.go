@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+func newCreateTestService(policy serviceInterfaces.GameSettingsPolicy) serviceInterfaces.GameService {
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+	mockGameRepo.On("Create", mock.Anything).Return(nil)
+
+	return services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), policy)
+}
+
+// createAlmostFullGridGame возвращает партию на поле 3x3, где первому игроку
+// осталось занять клетку (2, 2):
+//
+//	2 1 2
+//	1 2 1
+//	1 2 .
+func createAlmostFullGridGame(metric enums.DistanceMetric) *models.Game {
+	game := createTestGame()
+	game.Variant = enums.GridVariant
+	game.Rules = models.LegacyRules(enums.GridVariant)
+	game.Line = make([]enums.PositionState, 9)
+	game.Width = 3
+	game.Metric = metric
+	playTestMoves(game,
+		testMove{game.FirstPlayerID, 1},
+		testMove{game.SecondPlayerID, 0},
+		testMove{game.FirstPlayerID, 3},
+		testMove{game.SecondPlayerID, 2},
+		testMove{game.FirstPlayerID, 5},
+		testMove{game.SecondPlayerID, 4},
+		testMove{game.FirstPlayerID, 6},
+		testMove{game.SecondPlayerID, 7},
+	)
+	return game
+}
+
+func TestGameService_CreateGame_Grid(t *testing.T) {
+	policy := testPolicy()
+	policy.AllowedVariants = append(policy.AllowedVariants, enums.GridVariant)
+	service := newCreateTestService(policy)
+
+	game, err := service.CreateGame(models.GameSettings{
+		Variant: enums.GridVariant,
+		Grid:    models.Grid{Width: 4, Height: 3},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+
+	require.NoError(t, err)
+	assert.Len(t, game.Line, 12)
+	grid, ok := game.Grid()
+	require.True(t, ok)
+	assert.Equal(t, models.Grid{Width: 4, Height: 3, Metric: enums.ManhattanMetric}, grid)
+}
+
+func TestGameService_CreateGame_RejectsInvalidGrid(t *testing.T) {
+	policy := testPolicy()
+	policy.AllowedVariants = append(policy.AllowedVariants, enums.GridVariant)
+
+	tests := []struct {
+		name     string
+		settings models.GameSettings
+		fields   []string
+	}{
+		{"too narrow", models.GameSettings{Variant: enums.GridVariant, Grid: models.Grid{Width: 1, Height: 5}}, []string{"grid.width"}},
+		{"huge grid", models.GameSettings{Variant: enums.GridVariant, Grid: models.Grid{Width: 1 << 40, Height: 1 << 40}}, []string{"grid.width", "grid.height"}},
+		{"unknown metric", models.GameSettings{Variant: enums.GridVariant, Grid: models.Grid{Width: 3, Height: 3, Metric: "chebyshev"}}, []string{"grid.metric"}},
+		{"size mismatch", models.GameSettings{Variant: enums.GridVariant, LineSize: 10, Grid: models.Grid{Width: 3, Height: 3}}, []string{"line_size"}},
+		{"grid without variant", models.GameSettings{Grid: models.Grid{Width: 3, Height: 3}}, []string{"grid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newCreateTestService(policy)
+
+			_, err := service.CreateGame(tt.settings, []uuid.UUID{uuid.New(), uuid.New()})
+
+			var validation *serviceErrors.ValidationError
+			require.ErrorAs(t, err, &validation)
+			fields := make([]string, 0, len(validation.Fields))
+			for _, f := range validation.Fields {
+				fields = append(fields, f.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestGameService_MakeMove_ByCell(t *testing.T) {
+	game := createAlmostFullGridGame(enums.ManhattanMetric)
+	game.Line[8] = enums.Empty
+	game.Line[4] = enums.Empty
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Cell: &models.Cell{Row: 1, Col: 1}})
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.FirstPlayer, result.Game.Line[4])
+}
+
+func TestGameService_MakeMove_CellOutsideGrid(t *testing.T) {
+	game := createAlmostFullGridGame(enums.ManhattanMetric)
+	service, _ := newActionTestService(game)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Cell: &models.Cell{Row: 3, Col: 0}})
+
+	assertErrorCode(t, err, serviceErrors.CodeInvalidPosition)
+}
+
+func TestGameService_MakeMove_CellOnLineBoard(t *testing.T) {
+	game := createTestGame()
+	service, _ := newActionTestService(game)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Cell: &models.Cell{Row: 0, Col: 1}})
+
+	assertErrorCode(t, err, serviceErrors.CodeInvalidPosition)
+}
+
+func TestGameService_Grid_ScoresByMetric(t *testing.T) {
+	// по Манхэттену нити первого игрока длиннее (4 против 3),
+	// по прямой короче (2 + √2 против 1 + 2√2)
+	tests := []struct {
+		metric enums.DistanceMetric
+		status enums.GameStatus
+	}{
+		{enums.ManhattanMetric, enums.FirstPlayerWon},
+		{enums.EuclideanMetric, enums.SecondPlayerWon},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			game := createAlmostFullGridGame(tt.metric)
+			service, _ := newActionTestService(game)
+
+			result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Cell: &models.Cell{Row: 2, Col: 2}})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, result.Game.Status)
+		})
+	}
+}
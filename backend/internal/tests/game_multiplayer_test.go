@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+func createMultiplayerTestGame(players int) *models.Game {
+	game := createTestGame()
+	game.Seats = []uuid.UUID{game.FirstPlayerID, game.SecondPlayerID}
+	for len(game.Seats) < players {
+		game.Seats = append(game.Seats, uuid.New())
+	}
+	return game
+}
+
+func TestGameService_CreateGame_Multiplayer(t *testing.T) {
+	service := newCreateTestService(testPolicy())
+	players := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+	game, err := service.CreateGame(models.GameSettings{LineSize: 12}, players)
+
+	require.NoError(t, err)
+	assert.Equal(t, players, game.Players())
+	assert.Equal(t, players[0], game.FirstPlayerID)
+	assert.Equal(t, players[1], game.SecondPlayerID)
+	assert.Equal(t, players[0], game.CurrentPlayerID)
+}
+
+func TestGameService_CreateGame_RejectsInvalidSeats(t *testing.T) {
+	repeated := uuid.New()
+	policy := testPolicy()
+	policy.AllowedVariants = append(policy.AllowedVariants, enums.PieVariant)
+
+	tests := []struct {
+		name     string
+		settings models.GameSettings
+		players  []uuid.UUID
+		fields   []string
+	}{
+		{"too many players", models.GameSettings{LineSize: 20}, make([]uuid.UUID, 7), []string{"playerIds"}},
+		{"player seated twice", models.GameSettings{LineSize: 9}, []uuid.UUID{repeated, uuid.New(), repeated}, []string{"playerIds[2]"}},
+		{"pie rule", models.GameSettings{LineSize: 9, Variant: enums.PieVariant}, []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}, []string{"variant"}},
+		{"board too small", models.GameSettings{LineSize: 5}, []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}, []string{"line_size"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newCreateTestService(policy)
+
+			_, err := service.CreateGame(tt.settings, tt.players)
+
+			var validation *serviceErrors.ValidationError
+			require.ErrorAs(t, err, &validation)
+			fields := make([]string, 0, len(validation.Fields))
+			for _, f := range validation.Fields {
+				fields = append(fields, f.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestGameService_Multiplayer_TurnsGoRoundRobin(t *testing.T) {
+	game := createMultiplayerTestGame(3)
+	service, _ := newActionTestService(game)
+
+	for i, playerID := range game.Seats {
+		_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: playerID, Position: i})
+		require.NoError(t, err)
+		assert.Equal(t, enums.SeatState(i), game.Line[i])
+	}
+
+	assert.Equal(t, game.Seats[0], game.CurrentPlayerID)
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.Seats[2], Position: 5})
+	assertErrorCode(t, err, serviceErrors.CodeNotYourTurn)
+}
+
+func TestGameService_Multiplayer_FinishesWithRanking(t *testing.T) {
+	// нити: у первого места 0-5 длиной 5, у второго 1-2 и у третьего 3-4 длиной 1;
+	// при равенстве выше стоит более позднее место
+	game := createMultiplayerTestGame(3)
+	game.Line = make([]enums.PositionState, 6)
+	first, second, third := game.Seats[0], game.Seats[1], game.Seats[2]
+	playTestMoves(game,
+		testMove{first, 0},
+		testMove{second, 1},
+		testMove{third, 3},
+		testMove{first, 5},
+		testMove{second, 2},
+	)
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: third, Position: 4})
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.Finished, result.Game.Status)
+	assert.Equal(t, []float64{5, 1, 1}, result.Game.Scores)
+	assert.Equal(t, []uuid.UUID{first, third, second}, result.Game.Ranking)
+}
+
+func TestGameService_TwoPlayerGame_RecordsScores(t *testing.T) {
+	game := createTestGame()
+	game.Line = make([]enums.PositionState, 4)
+	playTestMoves(game,
+		testMove{game.FirstPlayerID, 0},
+		testMove{game.SecondPlayerID, 1},
+		testMove{game.FirstPlayerID, 3},
+	)
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.FirstPlayerWon, result.Game.Status)
+	assert.Equal(t, []float64{3, 1}, result.Game.Scores)
+	assert.Equal(t, []uuid.UUID{game.FirstPlayerID, game.SecondPlayerID}, result.Game.Ranking)
+}
+
+func TestGameService_Multiplayer_TwoPlayerActionsRejected(t *testing.T) {
+	game := createMultiplayerTestGame(3)
+	service, _ := newActionTestService(game)
+
+	_, err := service.Resign(game.ID, game.Seats[2])
+	assertErrorCode(t, err, serviceErrors.CodeTwoPlayerOnly)
+
+	_, err = service.OfferDraw(game.ID, game.Seats[1])
+	assertErrorCode(t, err, serviceErrors.CodeTwoPlayerOnly)
+}
+
+func TestGameService_Multiplayer_AbortUntilEverySeatMoved(t *testing.T) {
+	game := createMultiplayerTestGame(3)
+	playTestMoves(game,
+		testMove{game.Seats[0], 0},
+		testMove{game.Seats[1], 1},
+	)
+	service, _ := newActionTestService(game)
+
+	result, err := service.Abort(game.ID, game.Seats[2])
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.Aborted, result.Status)
+}
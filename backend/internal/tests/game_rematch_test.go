@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+// createFinishedTestGame создаёт завершённую победой первого игрока партию с историей событий
+func createFinishedTestGame() (*models.Game, []models.RecordedGameEvent) {
+	game := &models.Game{ID: uuid.New()}
+	firstPlayerID := uuid.New()
+	game.Raise(&models.GameCreated{
+		LineSize:        7,
+		Variant:         enums.StandardVariant,
+		TimeControl:     models.TimeControl{Type: enums.UnlimitedTimeControl},
+		Rated:           true,
+		FirstPlayerID:   firstPlayerID,
+		SecondPlayerID:  uuid.New(),
+		CurrentPlayerID: firstPlayerID,
+		CreatedAt:       time.Now().UTC(),
+	})
+	events := recordEvents(game, time.Now())
+	game.Status = enums.FirstPlayerWon
+	return game, events
+}
+
+func newRematchTestService(game *models.Game, events []models.RecordedGameEvent) (serviceInterfaces.GameService, *mocks.MockGameRepository) {
+	service, mockGameRepo := newActionTestService(game)
+	mockGameRepo.On("GetEvents", game.ID).Return(events, nil)
+	mockGameRepo.On("Create", mock.Anything).Return(nil)
+	return service, mockGameRepo
+}
+
+// createdRematch возвращает партию, переданную в gameRepo.Create
+func createdRematch(t *testing.T, mockGameRepo *mocks.MockGameRepository) *models.Game {
+	t.Helper()
+	for _, call := range mockGameRepo.Calls {
+		if call.Method == "Create" {
+			return call.Arguments.Get(0).(*models.Game)
+		}
+	}
+	require.Fail(t, "rematch was not created")
+	return nil
+}
+
+func TestGameService_OfferRematch_RequiresFinishedGame(t *testing.T) {
+	game := createTestGame()
+	service, _ := newActionTestService(game)
+
+	_, err := service.OfferRematch(game.ID, game.FirstPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeGameNotFinished)
+}
+
+func TestGameService_AcceptRematch_CreatesGameWithSwappedColours(t *testing.T) {
+	game, events := createFinishedTestGame()
+	service, mockGameRepo := newRematchTestService(game, events)
+
+	_, err := service.OfferRematch(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	require.NotNil(t, game.RematchOfferedBy)
+	_, err = service.OfferRematch(game.ID, game.FirstPlayerID)
+	assertErrorCode(t, err, serviceErrors.CodeRematchAlreadyOffered)
+
+	result, err := service.AcceptRematch(game.ID, game.SecondPlayerID)
+
+	require.NoError(t, err)
+	rematch := createdRematch(t, mockGameRepo)
+	assert.Nil(t, result.RematchOfferedBy)
+	require.NotNil(t, result.RematchGameID)
+	assert.Equal(t, rematch.ID, *result.RematchGameID)
+	require.NotNil(t, rematch.PreviousGameID)
+	assert.Equal(t, game.ID, *rematch.PreviousGameID)
+	assert.Equal(t, game.SecondPlayerID, rematch.FirstPlayerID)
+	assert.Equal(t, game.FirstPlayerID, rematch.SecondPlayerID)
+	assert.Equal(t, game.SecondPlayerID, rematch.CurrentPlayerID)
+	assert.Len(t, rematch.Line, 7)
+	assert.True(t, rematch.Rated)
+	assert.Equal(t, enums.InProgress, rematch.Status)
+
+	_, err = service.OfferRematch(game.ID, game.SecondPlayerID)
+	assertErrorCode(t, err, serviceErrors.CodeRematchExists)
+}
+
+func TestGameService_OfferRematch_CounterOfferAccepts(t *testing.T) {
+	game, events := createFinishedTestGame()
+	service, mockGameRepo := newRematchTestService(game, events)
+
+	_, err := service.OfferRematch(game.ID, game.SecondPlayerID)
+	require.NoError(t, err)
+	result, err := service.OfferRematch(game.ID, game.FirstPlayerID)
+
+	require.NoError(t, err)
+	require.NotNil(t, result.RematchGameID)
+	accepted := result.PendingEvents()[len(result.PendingEvents())-1].(*models.RematchAccepted)
+	assert.Equal(t, game.FirstPlayerID, accepted.PlayerID)
+	assert.Equal(t, createdRematch(t, mockGameRepo).ID, accepted.GameID)
+}
+
+func TestGameService_DeclineRematch(t *testing.T) {
+	game, events := createFinishedTestGame()
+	service, mockGameRepo := newRematchTestService(game, events)
+
+	_, err := service.AcceptRematch(game.ID, game.SecondPlayerID)
+	assertErrorCode(t, err, serviceErrors.CodeNoRematchOffer)
+
+	_, err = service.OfferRematch(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	_, err = service.DeclineRematch(game.ID, game.FirstPlayerID)
+	assertErrorCode(t, err, serviceErrors.CodeNoRematchOffer)
+
+	result, err := service.DeclineRematch(game.ID, game.SecondPlayerID)
+
+	require.NoError(t, err)
+	assert.Nil(t, result.RematchOfferedBy)
+	assert.Nil(t, result.RematchGameID)
+	mockGameRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestGameService_CreateRematch_ReturnsExistingRematch(t *testing.T) {
+	game, events := createFinishedTestGame()
+	service, mockGameRepo := newRematchTestService(game, events)
+
+	rematch, err := service.CreateRematch(game.ID)
+	require.NoError(t, err)
+	mockGameRepo.On("GetByID", rematch.ID).Return(rematch, nil)
+
+	again, err := service.CreateRematch(game.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, rematch.ID, again.ID)
+	accepted := game.PendingEvents()[len(game.PendingEvents())-1].(*models.RematchAccepted)
+	assert.Equal(t, uuid.Nil, accepted.PlayerID)
+	mockGameRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestGameService_CreateRematch_KeepsHandicapWithItsPlayer(t *testing.T) {
+	game := &models.Game{ID: uuid.New()}
+	firstPlayerID := uuid.New()
+	game.Raise(&models.GameCreated{
+		LineSize:        5,
+		Variant:         enums.HandicapVariant,
+		StartPosition:   []enums.PositionState{enums.FirstPlayer, enums.Empty, enums.Blocked, enums.Empty, enums.SecondPlayer},
+		FirstPlayerID:   firstPlayerID,
+		SecondPlayerID:  uuid.New(),
+		CurrentPlayerID: firstPlayerID,
+		CreatedAt:       time.Now().UTC(),
+	})
+	events := recordEvents(game, time.Now())
+	game.Status = enums.SecondPlayerWon
+	service, mockGameRepo := newRematchTestService(game, events)
+
+	_, err := service.CreateRematch(game.ID)
+
+	require.NoError(t, err)
+	rematch := createdRematch(t, mockGameRepo)
+	assert.Equal(t, game.SecondPlayerID, rematch.FirstPlayerID)
+	// гвоздь первого игрока партии теперь принадлежит ему же, но как второму игроку
+	assert.Equal(t,
+		[]enums.PositionState{enums.SecondPlayer, enums.Empty, enums.Blocked, enums.Empty, enums.FirstPlayer},
+		rematch.Line)
+	created := events[0].Event.(*models.GameCreated)
+	assert.Equal(t, enums.FirstPlayer, created.StartPosition[0])
+}
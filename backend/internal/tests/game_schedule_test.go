@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+func TestGameService_Schedule_PassesTurnWhenQuotaExhausted(t *testing.T) {
+	game := createTestGame()
+	game.Schedule = []int{1, 2}
+	first, second := game.FirstPlayerID, game.SecondPlayerID
+	service, _ := newActionTestService(game)
+
+	steps := []struct {
+		player         uuid.UUID
+		position       int
+		next           uuid.UUID
+		placementsLeft int
+	}{
+		{first, 0, second, 2},
+		{second, 1, second, 1},
+		{second, 2, first, 2},
+		{first, 3, first, 1},
+		{first, 4, second, 2},
+	}
+
+	for _, step := range steps {
+		result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: step.player, Position: step.position})
+		require.NoError(t, err)
+		assert.Equal(t, step.next, result.Game.CurrentPlayerID)
+		assert.Equal(t, step.placementsLeft, result.Game.PlacementsLeft())
+	}
+	assert.Equal(t, 3, game.TurnCount)
+}
+
+func TestGameService_Schedule_MidTurnKeepsClockRunning(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createTimedTestGame(clock)
+	game.Schedule = []int{2}
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+	service := services.NewGameService(mockGameRepo, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository),
+		testPolicy(), services.WithClock(clock.Now))
+
+	clock.Advance(10 * time.Second)
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 0})
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Second, game.Clock(game.FirstPlayerID))
+
+	clock.Advance(5 * time.Second)
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 47*time.Second, game.Clock(game.FirstPlayerID))
+	assert.Equal(t, game.SecondPlayerID, game.CurrentPlayerID)
+}
+
+func TestGameService_CreateGame_Schedule(t *testing.T) {
+	policy := testPolicy()
+	policy.AllowedVariants = append(policy.AllowedVariants, enums.PieVariant)
+
+	t.Run("stored", func(t *testing.T) {
+		game, err := newCreateTestService(policy).CreateGame(models.GameSettings{Schedule: []int{1, 2}},
+			[]uuid.UUID{uuid.New(), uuid.New()})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, game.Schedule)
+		assert.Equal(t, 1, game.PlacementsLeft())
+	})
+
+	t.Run("single nails are not stored", func(t *testing.T) {
+		game, err := newCreateTestService(policy).CreateGame(models.GameSettings{Schedule: []int{1, 1}},
+			[]uuid.UUID{uuid.New(), uuid.New()})
+		require.NoError(t, err)
+		assert.Nil(t, game.Schedule)
+	})
+
+	for name, settings := range map[string]models.GameSettings{
+		"empty turn":    {Schedule: []int{1, 0}},
+		"too many":      {Schedule: []int{6}},
+		"with pie rule": {Schedule: []int{1, 2}, Variant: enums.PieVariant},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newCreateTestService(policy).CreateGame(settings, []uuid.UUID{uuid.New(), uuid.New()})
+
+			var validation *serviceErrors.ValidationError
+			require.ErrorAs(t, err, &validation)
+			require.Len(t, validation.Fields, 1)
+			assert.Equal(t, "schedule", validation.Fields[0].Field)
+		})
+	}
+}
+
+func TestGameService_Schedule_AbortCountsTurns(t *testing.T) {
+	game := createTestGame()
+	game.Schedule = []int{2}
+	service, _ := newActionTestService(game)
+
+	for _, position := range []int{0, 1} {
+		_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: position})
+		require.NoError(t, err)
+	}
+	result, err := service.Abort(game.ID, game.SecondPlayerID)
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.Aborted, result.Status)
+}
+
+func TestGameService_Schedule_DisablesTakebacks(t *testing.T) {
+	game := createTestGame()
+	game.Schedule = []int{1, 2}
+	service, _ := newActionTestService(game)
+
+	_, err := service.RequestTakeback(game.ID, game.FirstPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeTakebacksDisabled)
+}
@@ -1,7 +1,6 @@
 package tests
 
 import (
-	"errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -10,7 +9,10 @@ import (
 
 	"nails_game/internal/models"
 	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
 	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
 	"nails_game/internal/tests/mocks"
 )
 
@@ -27,6 +29,24 @@ func createTestGame() *models.Game {
 	}
 }
 
+func assertErrorCode(t *testing.T, err error, code serviceErrors.Code) {
+	t.Helper()
+	var coded serviceErrors.CodedError
+	require.ErrorAs(t, err, &coded)
+	assert.Equal(t, code, coded.ErrorCode())
+}
+
+func testPolicy() serviceInterfaces.GameSettingsPolicy {
+	return serviceInterfaces.GameSettingsPolicy{
+		MinLineSize:         3,
+		MaxLineSize:         50,
+		DefaultLineSize:     9,
+		AllowedVariants:     []enums.Variant{enums.StandardVariant},
+		AllowedTimeControls: []enums.TimeControlType{enums.UnlimitedTimeControl},
+		MaxCoordinateGap:    5,
+	}
+}
+
 func TestGameService_MakeMove(t *testing.T) {
 	game := createTestGame()
 	move := models.Move{
@@ -41,7 +61,7 @@ func TestGameService_MakeMove(t *testing.T) {
 	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
 	mockGameRepo.On("Update", mock.Anything).Return(nil)
 
-	service := services.NewGameService(mockGameRepo, mockPlayerRepo, 3)
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
 	result, err := service.MakeMove(move)
 
 	require.NoError(t, err)
@@ -71,7 +91,7 @@ func TestGameService_MakeMove_ReturnsCachedResult(t *testing.T) {
 	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
 	mockGameRepo.On("Update", mock.Anything).Return(nil)
 
-	service := services.NewGameService(mockGameRepo, mockPlayerRepo, 3)
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
 	firstResult, err := service.MakeMove(firstMove)
 	require.NoError(t, err)
 
@@ -99,11 +119,10 @@ func TestGameService_MakeMove_NotPlayersTurn(t *testing.T) {
 
 	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
 
-	service := services.NewGameService(mockGameRepo, mockPlayerRepo, 3)
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
 	_, err := service.MakeMove(move)
 
-	assert.Error(t, err)
-	assert.Equal(t, errors.New("not this player's turn"), err)
+	assertErrorCode(t, err, serviceErrors.CodeNotYourTurn)
 }
 
 func TestGameService_MakeMove_PositionOccupied(t *testing.T) {
@@ -121,11 +140,10 @@ func TestGameService_MakeMove_PositionOccupied(t *testing.T) {
 
 	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
 
-	service := services.NewGameService(mockGameRepo, mockPlayerRepo, 3)
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
 	_, err := service.MakeMove(move)
 
-	assert.Error(t, err)
-	assert.Equal(t, errors.New("position is already taken"), err)
+	assertErrorCode(t, err, serviceErrors.CodePositionTaken)
 }
 
 func TestGameService_MakeMove_PlayerNotAssigned(t *testing.T) {
@@ -143,11 +161,10 @@ func TestGameService_MakeMove_PlayerNotAssigned(t *testing.T) {
 
 	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
 
-	service := services.NewGameService(mockGameRepo, mockPlayerRepo, 3)
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
 	_, err := service.MakeMove(move)
 
-	assert.Error(t, err)
-	assert.Equal(t, errors.New("player is not in this game"), err)
+	assertErrorCode(t, err, serviceErrors.CodePlayerNotInGame)
 }
 
 func TestGameService_MakeMove_GameEnded(t *testing.T) {
@@ -165,9 +182,42 @@ func TestGameService_MakeMove_GameEnded(t *testing.T) {
 
 	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
 
-	service := services.NewGameService(mockGameRepo, mockPlayerRepo, 3)
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
 	_, err := service.MakeMove(move)
 
-	assert.Error(t, err)
-	assert.Equal(t, errors.New("game has already ended"), err)
+	assertErrorCode(t, err, serviceErrors.CodeGameFinished)
+}
+
+func TestGameService_MakeMove_InvalidPosition(t *testing.T) {
+	game := createTestGame()
+
+	move := models.Move{
+		GameID:   game.ID,
+		PlayerID: game.FirstPlayerID,
+		Position: len(game.Line),
+	}
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	_, err := service.MakeMove(move)
+
+	assertErrorCode(t, err, serviceErrors.CodeInvalidPosition)
+}
+
+func TestGameService_MakeMove_GameNotFound(t *testing.T) {
+	gameID := uuid.New()
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockGameRepo.On("GetByID", gameID).Return(nil, repositories.ErrGameNotFound)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	_, err := service.MakeMove(models.Move{GameID: gameID, PlayerID: uuid.New()})
+
+	assertErrorCode(t, err, serviceErrors.CodeGameNotFound)
 }
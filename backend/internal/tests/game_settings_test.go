@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+func TestGameService_CreateGame_UsesDefaultLineSize(t *testing.T) {
+	firstPlayerID, secondPlayerID := uuid.New(), uuid.New()
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+	mockGameRepo.On("Create", mock.Anything).Return(nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	game, err := service.CreateGame(models.GameSettings{}, []uuid.UUID{firstPlayerID, secondPlayerID})
+
+	require.NoError(t, err)
+	assert.Len(t, game.Line, testPolicy().DefaultLineSize)
+	assert.Equal(t, enums.StandardVariant, game.Variant)
+	assert.Equal(t, firstPlayerID, game.CurrentPlayerID)
+}
+
+func TestGameService_CreateGame_RejectsInvalidSettings(t *testing.T) {
+	playerID, unknownID := uuid.New(), uuid.New()
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockPlayerRepo.On("GetByID", playerID).Return(&models.Player{}, nil)
+	mockPlayerRepo.On("GetByID", unknownID).Return(nil, repositories.ErrPlayerNotFound)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	_, err := service.CreateGame(models.GameSettings{
+		LineSize: -1,
+		Variant:  "hexagonal",
+	}, []uuid.UUID{unknownID, playerID})
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	fields := make([]string, 0, len(validation.Fields))
+	for _, f := range validation.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.ElementsMatch(t, []string{"line_size", "variant", "firstPlayerId"}, fields)
+	mockGameRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestGameService_CreateGame_RejectsSelfPlay(t *testing.T) {
+	playerID := uuid.New()
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+
+	mockPlayerRepo.On("GetByID", playerID).Return(&models.Player{}, nil)
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	_, err := service.CreateGame(models.GameSettings{LineSize: 10}, []uuid.UUID{playerID, playerID})
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.Fields, 1)
+	assert.Equal(t, "secondPlayerId", validation.Fields[0].Field)
+}
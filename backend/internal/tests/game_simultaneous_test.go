@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+func createSimultaneousTestGame(lineSize int) *models.Game {
+	game := &models.Game{ID: uuid.New()}
+	firstPlayerID := uuid.New()
+	game.Raise(&models.GameCreated{
+		LineSize:        lineSize,
+		Variant:         enums.SimultaneousVariant,
+		TimeControl:     models.TimeControl{Type: enums.UnlimitedTimeControl},
+		FirstPlayerID:   firstPlayerID,
+		SecondPlayerID:  uuid.New(),
+		CurrentPlayerID: firstPlayerID,
+		CreatedAt:       time.Now().UTC(),
+	})
+	game.ClearPendingEvents()
+	return game
+}
+
+func TestGameService_Simultaneous_RevealsRoundWhenEveryoneCommitted(t *testing.T) {
+	game := createSimultaneousTestGame(9)
+	service, _ := newActionTestService(game)
+
+	// второй игрок может выбрать первым: очерёдности внутри раунда нет
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 5})
+	require.NoError(t, err)
+	assert.Equal(t, enums.Empty, game.Line[5])
+	assert.True(t, game.HasCommitted(game.SecondPlayerID))
+
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 6})
+	assertErrorCode(t, err, serviceErrors.CodeAlreadyCommitted)
+
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, enums.FirstPlayer, game.Line[2])
+	assert.Equal(t, enums.SecondPlayer, game.Line[5])
+	assert.Equal(t, 1, game.Round)
+	assert.Equal(t, 2, game.MoveCount)
+	assert.False(t, game.HasCommitted(game.SecondPlayerID))
+}
+
+func TestGameService_Simultaneous_CollisionBlocksPosition(t *testing.T) {
+	game := createSimultaneousTestGame(9)
+	service, _ := newActionTestService(game)
+
+	for _, playerID := range []uuid.UUID{game.FirstPlayerID, game.SecondPlayerID} {
+		_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: playerID, Position: 4})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, enums.Blocked, game.Line[4])
+	assert.Equal(t, 0, game.MoveCount)
+	assert.Equal(t, 1, game.Round)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 4})
+	assertErrorCode(t, err, serviceErrors.CodePositionBlocked)
+}
+
+func TestGameService_Simultaneous_FinishesWhenLastPositionCollides(t *testing.T) {
+	game := createSimultaneousTestGame(3)
+	service, _ := newActionTestService(game)
+
+	rounds := [][2]int{{0, 2}, {1, 1}}
+	for _, round := range rounds {
+		_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: round[0]})
+		require.NoError(t, err)
+		_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: round[1]})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []enums.PositionState{enums.FirstPlayer, enums.Blocked, enums.SecondPlayer}, game.Line)
+	assert.NotEqual(t, enums.InProgress, game.Status)
+	assert.Equal(t, enums.NormalTermination, game.Termination)
+}
+
+func TestGameService_Simultaneous_HidesSealedCommits(t *testing.T) {
+	game := createSimultaneousTestGame(9)
+	service, mockGameRepo := newActionTestService(game)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 3})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	mockGameRepo.On("GetEvents", game.ID).Return([]models.RecordedGameEvent{
+		{Version: 1, OccurredAt: now, Event: &models.GameCreated{Variant: enums.SimultaneousVariant}},
+		{Version: 2, OccurredAt: now, Event: game.PendingEvents()[0]},
+	}, nil)
+
+	events, err := service.GetGameEvents(game.ID, uuid.Nil)
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.IsType(t, &models.GameCreated{}, events[0].Event)
+}
+
+func TestGameService_CreateGame_SimultaneousNeedsUnlimitedTime(t *testing.T) {
+	policy := testPolicy()
+	policy.AllowedVariants = append(policy.AllowedVariants, enums.SimultaneousVariant)
+	policy.AllowedTimeControls = append(policy.AllowedTimeControls, enums.FischerTimeControl)
+
+	_, err := newCreateTestService(policy).CreateGame(models.GameSettings{
+		Variant:     enums.SimultaneousVariant,
+		TimeControl: models.TimeControl{Type: enums.FischerTimeControl, BaseSeconds: 60},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.Fields, 1)
+	assert.Equal(t, "timeControl.type", validation.Fields[0].Field)
+}
@@ -0,0 +1,278 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/controllers"
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+func createPrivateTestGame() *models.Game {
+	game := &models.Game{ID: uuid.New()}
+	firstPlayerID := uuid.New()
+	game.Raise(&models.GameCreated{
+		LineSize:        9,
+		Variant:         enums.StandardVariant,
+		TimeControl:     models.TimeControl{Type: enums.UnlimitedTimeControl},
+		Private:         true,
+		FirstPlayerID:   firstPlayerID,
+		SecondPlayerID:  uuid.New(),
+		CurrentPlayerID: firstPlayerID,
+		CreatedAt:       time.Now().UTC(),
+	})
+	return game
+}
+
+func TestGameService_CreateGame_PrivateNeedsTokens(t *testing.T) {
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+	mockGameRepo.On("Create", mock.Anything).Return(nil)
+	players := []uuid.UUID{uuid.New(), uuid.New()}
+
+	service := services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	_, err := service.CreateGame(models.GameSettings{Private: true}, players)
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	assert.Equal(t, "private", validation.Fields[0].Field)
+
+	policy := testPolicy()
+	policy.AllowPrivateGames = true
+	service = services.NewGameService(mockGameRepo, mockPlayerRepo, new(mocks.MockBoardPresetRepository), policy)
+	game, err := service.CreateGame(models.GameSettings{Private: true}, players)
+
+	require.NoError(t, err)
+	assert.True(t, game.Private)
+}
+
+func TestGameService_PrivateGame_OnlyPlayersCanView(t *testing.T) {
+	game := createPrivateTestGame()
+	events := recordEvents(game, time.Now())
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("GetEvents", game.ID).Return(events, nil)
+	service := services.NewGameService(mockGameRepo, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository), testPolicy())
+
+	_, err := service.GetGame(game.ID, uuid.Nil)
+	assertErrorCode(t, err, serviceErrors.CodeAuthenticationRequired)
+	_, err = service.GetGameEvents(game.ID, uuid.Nil)
+	assertErrorCode(t, err, serviceErrors.CodeAuthenticationRequired)
+
+	stranger := uuid.New()
+	_, err = service.GetGame(game.ID, stranger)
+	assertErrorCode(t, err, serviceErrors.CodePlayerNotInGame)
+	_, err = service.GetGameEvents(game.ID, stranger)
+	assertErrorCode(t, err, serviceErrors.CodePlayerNotInGame)
+
+	view, err := service.GetGame(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	assert.True(t, view.Game.Private)
+	history, err := service.GetGameEvents(game.ID, game.SecondPlayerID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	// турниры и серии читают итог закрытой партии без зрителя
+	loaded, err := service.LoadGame(game.ID)
+	require.NoError(t, err)
+	assert.Equal(t, game.ID, loaded.ID)
+}
+
+func TestGameService_ListLiveGames_PassesFilter(t *testing.T) {
+	rated := true
+	tournamentID := uuid.New()
+	filter := models.LiveGameFilter{Variant: enums.PieVariant, Rated: &rated, TournamentID: &tournamentID}
+	live := []models.Game{*createTestGame()}
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockGameRepo.On("ListLive", filter).Return(live, nil)
+	service := services.NewGameService(mockGameRepo, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository), testPolicy())
+
+	games, err := service.ListLiveGames(filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, live, games)
+	mockGameRepo.AssertExpectations(t)
+}
+
+func TestSpectatorRegistry_CountsWatchers(t *testing.T) {
+	registry := services.NewSpectatorRegistry()
+	gameID := uuid.New()
+
+	leaveFirst := registry.Watch(gameID)
+	leaveSecond := registry.Watch(gameID)
+	assert.Equal(t, 2, registry.Count(gameID))
+	assert.Zero(t, registry.Count(uuid.New()))
+
+	leaveFirst()
+	leaveFirst()
+	assert.Equal(t, 1, registry.Count(gameID))
+	leaveSecond()
+	assert.Zero(t, registry.Count(gameID))
+}
+
+func TestGameFeed_WakesSubscribersOfTheGame(t *testing.T) {
+	feed := services.NewGameFeed(20 * time.Millisecond)
+	game := createTestGame()
+	updates, unsubscribe := feed.Subscribe(game.ID)
+	other, unsubscribeOther := feed.Subscribe(uuid.New())
+	defer unsubscribeOther()
+
+	feed.Publish(game, nil)
+	feed.Notify(game.ID)
+	// сигналы не копятся: подписчик всё равно загрузит всё новое разом
+	assert.Len(t, updates, 1)
+	<-updates
+	assert.Empty(t, other)
+
+	unsubscribe()
+	unsubscribe()
+	feed.Notify(game.ID)
+	assert.Empty(t, updates)
+}
+
+func TestGameFeed_WakesFogSpectatorsAfterDelay(t *testing.T) {
+	feed := services.NewGameFeed(20 * time.Millisecond)
+	game := createFogTestGame(&fakeClock{now: time.Now()})
+	updates, unsubscribe := feed.Subscribe(game.ID)
+	defer unsubscribe()
+
+	feed.Publish(game, nil)
+	<-updates
+
+	select {
+	case <-updates:
+	case <-time.After(time.Second):
+		t.Fatal("fog spectators were not woken after the delay")
+	}
+}
+
+// movesGames - сервис партий, который только запоминает ходы
+type movesGames struct {
+	serviceInterfaces.GameService
+	moves []models.Move
+}
+
+func (g *movesGames) MakeMove(move models.Move) (*serviceInterfaces.CachedMoveResult, error) {
+	g.moves = append(g.moves, move)
+	game := createTestGame()
+	return &serviceInterfaces.CachedMoveResult{Game: game}, nil
+}
+
+func TestGameController_MakeMove_RequiresOwnToken(t *testing.T) {
+	tokens := services.NewTokenService("secret", time.Hour, time.Now)
+	games := &movesGames{}
+	controller := controllers.NewGameController(games, nil, tokens, services.NewSpectatorRegistry(), services.NewGameFeed(0))
+
+	e := echo.New()
+	e.HTTPErrorHandler = controllers.NewErrorHandler(logrus.New())
+	e.Use(controllers.NewAuthMiddleware(tokens))
+	e.POST("/api/game/:gameId/move", controller.MakeMove)
+
+	playerID := uuid.New()
+	move := func(body, token string) (int, dtos.ErrorResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/api/game/"+uuid.NewString()+"/move", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var resp dtos.ErrorResponse
+		if rec.Code != http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec.Code, resp
+	}
+
+	status, resp := move(`{"playerId":"`+playerID.String()+`","position":1}`, "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, string(serviceErrors.CodeAuthenticationRequired), resp.Code)
+
+	status, resp = move(`{"playerId":"`+playerID.String()+`","position":1}`, tokens.Issue(uuid.New(), uuid.Nil))
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, string(serviceErrors.CodePlayerMismatch), resp.Code)
+	assert.Empty(t, games.moves)
+
+	status, _ = move(`{"position":1}`, tokens.Issue(playerID, uuid.Nil))
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, games.moves, 1)
+	assert.Equal(t, playerID, games.moves[0].PlayerID)
+
+	// токен, выданный при создании другой партии, здесь не действует
+	status, resp = move(`{"position":1}`, tokens.Issue(playerID, uuid.New()))
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, string(serviceErrors.CodeInvalidToken), resp.Code)
+	assert.Len(t, games.moves, 1)
+}
+
+// createdGames - сервис партий, который только создаёт партии
+type createdGames struct {
+	serviceInterfaces.GameService
+	created int
+}
+
+func (g *createdGames) CreateGame(_ models.GameSettings, playerIDs []uuid.UUID) (*models.Game, error) {
+	g.created++
+	game := createTestGame()
+	game.FirstPlayerID, game.SecondPlayerID = playerIDs[0], playerIDs[1]
+	return game, nil
+}
+
+func TestGameController_CreateGame_OnlyByItsPlayers(t *testing.T) {
+	tokens := services.NewTokenService("secret", time.Hour, time.Now)
+	games := &createdGames{}
+	controller := controllers.NewGameController(games, nil, tokens, services.NewSpectatorRegistry(), services.NewGameFeed(0))
+
+	e := echo.New()
+	e.HTTPErrorHandler = controllers.NewErrorHandler(logrus.New())
+	e.Use(controllers.NewAuthMiddleware(tokens))
+	e.POST("/api/game", controller.CreateGame)
+
+	firstID, secondID := uuid.New(), uuid.New()
+	body := `{"firstPlayerId":"` + firstID.String() + `","secondPlayerId":"` + secondID.String() + `"}`
+
+	status, resp := callAPI(t, e, http.MethodPost, "/api/game", body, "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, string(serviceErrors.CodeAuthenticationRequired), resp.Code)
+
+	status, resp = callAPI(t, e, http.MethodPost, "/api/game", body, tokens.Issue(uuid.New(), uuid.Nil))
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, string(serviceErrors.CodePlayerMismatch), resp.Code)
+	assert.Zero(t, games.created)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/game", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+tokens.Issue(secondID, uuid.Nil))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var created dtos.CreateGameResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	// в ответе только токен создателя, и только для этой партии
+	playerID, err := tokens.Verify(created.PlayerToken, created.GameID)
+	require.NoError(t, err)
+	assert.Equal(t, secondID, playerID)
+	_, err = tokens.Verify(created.PlayerToken, uuid.New())
+	assert.Error(t, err)
+}
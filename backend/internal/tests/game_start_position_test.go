@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+// createHandicapTestGame создаёт партию с расстановкой 1 x . . 2:
+// у каждого игрока по гвоздю, вторая позиция закрыта
+func createHandicapTestGame(t *testing.T) *models.Game {
+	t.Helper()
+
+	game, err := newCreateTestService(testPolicy()).CreateGame(models.GameSettings{
+		StartPosition: []enums.PositionState{enums.FirstPlayer, enums.Blocked, enums.Empty, enums.Empty, enums.SecondPlayer},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+	require.NoError(t, err)
+	return game
+}
+
+func TestGameService_CreateGame_StartPosition(t *testing.T) {
+	game := createHandicapTestGame(t)
+
+	assert.Equal(t, []enums.PositionState{enums.FirstPlayer, enums.Blocked, enums.Empty, enums.Empty, enums.SecondPlayer}, game.Line)
+	assert.Equal(t, 0, game.MoveCount)
+	assert.Equal(t, game.FirstPlayerID, game.CurrentPlayerID)
+}
+
+func TestGameService_CreateGame_EmptyStartPositionIsNotStored(t *testing.T) {
+	game, err := newCreateTestService(testPolicy()).CreateGame(models.GameSettings{
+		StartPosition: make([]enums.PositionState, 4),
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+
+	require.NoError(t, err)
+	assert.Len(t, game.Line, 4)
+	created := game.PendingEvents()[0].(*models.GameCreated)
+	assert.Nil(t, created.StartPosition)
+}
+
+func TestGameService_CreateGame_RejectsInvalidStartPosition(t *testing.T) {
+	tests := map[string]struct {
+		settings models.GameSettings
+		field    string
+	}{
+		"length mismatch": {
+			settings: models.GameSettings{LineSize: 9, StartPosition: make([]enums.PositionState, 5)},
+			field:    "startPosition",
+		},
+		"unknown seat": {
+			settings: models.GameSettings{StartPosition: []enums.PositionState{0, enums.SeatState(2), 0, 0}},
+			field:    "startPosition[1]",
+		},
+		"no empty positions": {
+			settings: models.GameSettings{StartPosition: []enums.PositionState{1, 2, enums.Blocked}},
+			field:    "startPosition",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newCreateTestService(testPolicy()).CreateGame(tt.settings, []uuid.UUID{uuid.New(), uuid.New()})
+
+			var validation *serviceErrors.ValidationError
+			require.ErrorAs(t, err, &validation)
+			require.Len(t, validation.Fields, 1)
+			assert.Equal(t, tt.field, validation.Fields[0].Field)
+		})
+	}
+}
+
+func TestGameService_MakeMove_RejectsBlockedPosition(t *testing.T) {
+	game := createHandicapTestGame(t)
+	service, mockGameRepo := newActionTestService(game)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 1})
+
+	assertErrorCode(t, err, serviceErrors.CodePositionBlocked)
+	mockGameRepo.AssertNotCalled(t, "Update", game)
+}
+
+func TestGameService_MakeMove_BlockedPositionsAreSkippedInScoring(t *testing.T) {
+	game := createHandicapTestGame(t)
+	service, _ := newActionTestService(game)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 2})
+	require.NoError(t, err)
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 3})
+	require.NoError(t, err)
+
+	// нить первого игрока 0-2 проходит над закрытой позицией, у второго - 3-4
+	assert.Equal(t, enums.FirstPlayerWon, result.Game.Status)
+	assert.Equal(t, []float64{2, 1}, result.Game.Scores)
+}
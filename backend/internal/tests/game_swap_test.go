@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+func createPieTestGame() *models.Game {
+	game := createTestGame()
+	game.Variant = enums.PieVariant
+	game.Rules = models.LegacyRules(enums.PieVariant)
+	playTestMoves(game, testMove{game.FirstPlayerID, 0})
+	return game
+}
+
+func swapMove(game *models.Game, playerID uuid.UUID) models.Move {
+	return models.Move{GameID: game.ID, PlayerID: playerID, Type: enums.SwapMove}
+}
+
+func TestGameService_SwapSides(t *testing.T) {
+	game := createPieTestGame()
+	originalFirst, originalSecond := game.FirstPlayerID, game.SecondPlayerID
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(swapMove(game, originalSecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, originalSecond, result.Game.FirstPlayerID)
+	assert.Equal(t, originalFirst, result.Game.SecondPlayerID)
+	assert.Equal(t, originalFirst, result.Game.CurrentPlayerID)
+	assert.Equal(t, enums.FirstPlayer, result.Game.Line[0])
+	assert.Equal(t, 2, result.Game.MoveCount)
+	assert.IsType(t, &models.SidesSwapped{}, result.Game.PendingEvents()[0])
+}
+
+func TestGameService_SwapSides_PlayerContinuesWithOpponentsSide(t *testing.T) {
+	game := createPieTestGame()
+	originalFirst := game.FirstPlayerID
+	service, _ := newActionTestService(game)
+
+	_, err := service.MakeMove(swapMove(game, game.SecondPlayerID))
+	require.NoError(t, err)
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: originalFirst, Position: 4})
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.SecondPlayer, result.Game.Line[4])
+}
+
+func TestGameService_SwapSides_NotAllowedInStandardVariant(t *testing.T) {
+	game := createPieTestGame()
+	game.Variant = enums.StandardVariant
+	game.Rules = models.LegacyRules(enums.StandardVariant)
+	service, mockGameRepo := newActionTestService(game)
+
+	_, err := service.MakeMove(swapMove(game, game.SecondPlayerID))
+
+	assertErrorCode(t, err, serviceErrors.CodeSwapNotAllowed)
+	mockGameRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGameService_SwapSides_OnlyAfterFirstNail(t *testing.T) {
+	game := createPieTestGame()
+	playTestMoves(game,
+		testMove{game.SecondPlayerID, 4},
+		testMove{game.FirstPlayerID, 5},
+	)
+	service, _ := newActionTestService(game)
+
+	_, err := service.MakeMove(swapMove(game, game.SecondPlayerID))
+
+	assertErrorCode(t, err, serviceErrors.CodeSwapNotAllowed)
+}
+
+func TestGameService_MakeMove_UnknownMoveType(t *testing.T) {
+	game := createPieTestGame()
+	service, _ := newActionTestService(game)
+
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Type: "pass"})
+
+	assertErrorCode(t, err, serviceErrors.CodeValidationFailed)
+}
+
+func TestGameService_SwapSides_SwapsClocks(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	game := createTimedTestGame(clock)
+	game.Variant = enums.PieVariant
+	game.Rules = models.LegacyRules(enums.PieVariant)
+	originalFirst, originalSecond := game.FirstPlayerID, game.SecondPlayerID
+
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+	service := services.NewGameService(mockGameRepo, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository), testPolicy(),
+		services.WithClock(clock.Now))
+
+	clock.Advance(10 * time.Second)
+	_, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: originalFirst, Position: 0})
+	require.NoError(t, err)
+	clock.Advance(5 * time.Second)
+	result, err := service.MakeMove(swapMove(game, originalSecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, 52*time.Second, result.Game.Clock(originalFirst))
+	assert.Equal(t, 57*time.Second, result.Game.Clock(originalSecond))
+}
@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+)
+
+type testMove struct {
+	player   uuid.UUID
+	position int
+}
+
+// playTestMoves расставляет гвозди и возвращает соответствующую историю ходов
+func playTestMoves(game *models.Game, moves ...testMove) []models.RecordedGameEvent {
+	events := []models.RecordedGameEvent{{Version: 1, Event: &models.GameCreated{}}}
+	for i, move := range moves {
+		seat := game.Seat(move.player)
+		state := enums.SeatState(seat)
+		game.Line[move.position] = state
+		game.MoveCount++
+		game.CurrentPlayerID = game.Players()[(seat+1)%len(game.Players())]
+		events = append(events, models.RecordedGameEvent{
+			Version: i + 2,
+			Event:   &models.MovePlayed{PlayerID: move.player, Position: move.position, State: state},
+		})
+	}
+	return events
+}
+
+func TestGameService_TakebackRequestAndAccept(t *testing.T) {
+	game := createTestGame()
+	events := playTestMoves(game,
+		testMove{game.FirstPlayerID, 0},
+		testMove{game.SecondPlayerID, 4},
+	)
+	service, mockGameRepo := newActionTestService(game)
+	mockGameRepo.On("GetEvents", game.ID).Return(events, nil)
+
+	_, err := service.RequestTakeback(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	require.NotNil(t, game.TakebackRequestedBy)
+
+	result, err := service.AcceptTakeback(game.ID, game.SecondPlayerID)
+
+	require.NoError(t, err)
+	assert.Equal(t, enums.Empty, result.Line[0])
+	assert.Equal(t, enums.Empty, result.Line[4])
+	assert.Equal(t, 0, result.MoveCount)
+	assert.Equal(t, game.FirstPlayerID, result.CurrentPlayerID)
+	assert.Nil(t, result.TakebackRequestedBy)
+	taken := result.PendingEvents()[len(result.PendingEvents())-1].(*models.MovesTakenBack)
+	assert.Equal(t, []int{4, 0}, taken.Positions)
+}
+
+func TestGameService_RequestTakeback_RejectedInFinishedGame(t *testing.T) {
+	game := createTestGame()
+	game.Line = make([]enums.PositionState, 3)
+	events := playTestMoves(game,
+		testMove{game.FirstPlayerID, 0},
+		testMove{game.SecondPlayerID, 1},
+		testMove{game.FirstPlayerID, 2},
+	)
+	game.Status = enums.FirstPlayerWon
+	game.Termination = enums.NormalTermination
+	service, mockGameRepo := newActionTestService(game)
+	mockGameRepo.On("GetEvents", game.ID).Return(events, nil)
+
+	_, err := service.RequestTakeback(game.ID, game.FirstPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeGameFinished)
+	assert.Nil(t, game.TakebackRequestedBy)
+	assert.Equal(t, enums.FirstPlayerWon, game.Status)
+	mockGameRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGameService_RequestTakeback_DisabledInRatedGames(t *testing.T) {
+	game := createTestGame()
+	game.Rated = true
+	service, mockGameRepo := newActionTestService(game)
+
+	_, err := service.RequestTakeback(game.ID, game.FirstPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeTakebacksDisabled)
+	mockGameRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGameService_RequestTakeback_DisabledInTimedGames(t *testing.T) {
+	game := createTestGame()
+	game.TimeControl = models.TimeControl{Type: enums.FischerTimeControl, BaseSeconds: 300, IncrementSeconds: 2}
+	service, mockGameRepo := newActionTestService(game)
+
+	_, err := service.RequestTakeback(game.ID, game.FirstPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeTakebacksDisabled)
+	mockGameRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGameService_RequestTakeback_NoMove(t *testing.T) {
+	game := createTestGame()
+	events := playTestMoves(game, testMove{game.FirstPlayerID, 0})
+	service, mockGameRepo := newActionTestService(game)
+	mockGameRepo.On("GetEvents", game.ID).Return(events, nil)
+
+	_, err := service.RequestTakeback(game.ID, game.SecondPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeNoMoveToTakeBack)
+}
+
+func TestGameService_AcceptTakeback_WithoutRequest(t *testing.T) {
+	game := createTestGame()
+	service, _ := newActionTestService(game)
+
+	_, err := service.AcceptTakeback(game.ID, game.SecondPlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeNoTakebackRequest)
+}
+
+func TestGameService_MoveDeclinesTakebackRequest(t *testing.T) {
+	game := createTestGame()
+	events := playTestMoves(game, testMove{game.FirstPlayerID, 0})
+	service, mockGameRepo := newActionTestService(game)
+	mockGameRepo.On("GetEvents", game.ID).Return(events, nil)
+
+	_, err := service.RequestTakeback(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	_, err = service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.SecondPlayerID, Position: 4})
+
+	require.NoError(t, err)
+	assert.Nil(t, game.TakebackRequestedBy)
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+)
+
+func variantsTestPolicy() serviceInterfaces.GameSettingsPolicy {
+	policy := testPolicy()
+	policy.AllowedVariants = []enums.Variant{enums.StandardVariant, enums.GridVariant, enums.HandicapVariant, enums.MisereVariant}
+	return policy
+}
+
+func TestGameService_ListVariants_OnlyAllowed(t *testing.T) {
+	variants := newCreateTestService(variantsTestPolicy()).ListVariants()
+
+	names := make([]enums.Variant, 0, len(variants))
+	for _, v := range variants {
+		names = append(names, v.Name)
+	}
+	assert.Equal(t, []enums.Variant{enums.StandardVariant, enums.GridVariant, enums.HandicapVariant, enums.MisereVariant}, names)
+
+	handicap := variants[2]
+	assert.Equal(t, enums.LineTopology, handicap.Rules.Topology)
+	var required []string
+	for _, p := range handicap.Parameters {
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+	assert.Equal(t, []string{"startPosition"}, required)
+}
+
+func TestGameService_CreateGame_AppliesVariantParameters(t *testing.T) {
+	game, err := newCreateTestService(variantsTestPolicy()).CreateGame(models.GameSettings{
+		Variant:    enums.GridVariant,
+		Parameters: map[string]any{"width": float64(3), "height": float64(2), "metric": "euclidean"},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+
+	require.NoError(t, err)
+	assert.Len(t, game.Line, 6)
+	assert.Equal(t, 3, game.Width)
+	assert.Equal(t, enums.EuclideanMetric, game.Metric)
+	assert.Equal(t, enums.GridTopology, game.Rules.Topology)
+}
+
+func TestGameService_CreateGame_RejectsParametersOutsideSchema(t *testing.T) {
+	tests := map[string]struct {
+		settings models.GameSettings
+		field    string
+	}{
+		"unsupported parameter": {
+			settings: models.GameSettings{Parameters: map[string]any{"width": float64(3)}},
+			field:    "parameters.width",
+		},
+		"wrong type": {
+			settings: models.GameSettings{Parameters: map[string]any{"lineSize": "ten"}},
+			field:    "parameters.lineSize",
+		},
+		"unknown scoring rule": {
+			settings: models.GameSettings{Parameters: map[string]any{"scoring": "most_nails"}},
+			field:    "parameters.scoring",
+		},
+		"missing required parameter": {
+			settings: models.GameSettings{Variant: enums.HandicapVariant},
+			field:    "parameters.startPosition",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newCreateTestService(variantsTestPolicy()).CreateGame(tt.settings, []uuid.UUID{uuid.New(), uuid.New()})
+
+			var validation *serviceErrors.ValidationError
+			require.ErrorAs(t, err, &validation)
+			require.Len(t, validation.Fields, 1)
+			assert.Equal(t, tt.field, validation.Fields[0].Field)
+		})
+	}
+}
+
+func TestGameService_MisereVariant_ShortestThreadWins(t *testing.T) {
+	game, err := newCreateTestService(variantsTestPolicy()).CreateGame(models.GameSettings{
+		Variant:    enums.MisereVariant,
+		Parameters: map[string]any{"startPosition": []any{float64(1), float64(1), float64(0), float64(2), float64(2)}},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+	require.NoError(t, err)
+	service, _ := newActionTestService(game)
+
+	result, err := service.MakeMove(models.Move{GameID: game.ID, PlayerID: game.FirstPlayerID, Position: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2, 1}, result.Game.Scores)
+	assert.Equal(t, enums.SecondPlayerWon, result.Game.Status)
+}
+
+func TestGame_RulesArePersistedWithTheGame(t *testing.T) {
+	game, err := newCreateTestService(variantsTestPolicy()).CreateGame(models.GameSettings{
+		Variant:    enums.StandardVariant,
+		Parameters: map[string]any{"scoring": "shortest_thread"},
+	}, []uuid.UUID{uuid.New(), uuid.New()})
+	require.NoError(t, err)
+
+	replayed := models.Replay(game.ID, game.PendingEvents())
+
+	assert.Equal(t, enums.ShortestThreadWins, replayed.Rules.Scoring)
+}
+
+func TestGame_LegacyGamesKeepTheirVariantRules(t *testing.T) {
+	firstPlayerID := uuid.New()
+	game := models.Replay(uuid.New(), []models.GameEvent{&models.GameCreated{
+		LineSize:        9,
+		Variant:         enums.CircularVariant,
+		TimeControl:     models.TimeControl{Type: enums.UnlimitedTimeControl},
+		FirstPlayerID:   firstPlayerID,
+		SecondPlayerID:  uuid.New(),
+		CurrentPlayerID: firstPlayerID,
+	}})
+
+	assert.Equal(t, models.LegacyRules(enums.CircularVariant), game.Rules)
+	assert.Equal(t, enums.RingTopology, game.Rules.Topology)
+}
@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/repositories/migrations"
+)
+
+func TestMigrations_EmbeddedAreOrderedAndComplete(t *testing.T) {
+	list, err := migrations.Load()
+
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for i, m := range list {
+		assert.Equal(t, i+1, m.Version)
+		assert.NotEmpty(t, m.Name)
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+}
+
+func TestMigrations_MissingDownScript(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0001_init.up.sql": {Data: []byte("CREATE TABLE a (id int);")},
+	}
+
+	_, err := migrations.LoadFromFS(fsys, "sql")
+
+	assert.Error(t, err)
+}
+
+func TestMigrations_VersionGap(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0001_init.up.sql":   {Data: []byte("CREATE TABLE a (id int);")},
+		"sql/0001_init.down.sql": {Data: []byte("DROP TABLE a;")},
+		"sql/0003_next.up.sql":   {Data: []byte("CREATE TABLE b (id int);")},
+		"sql/0003_next.down.sql": {Data: []byte("DROP TABLE b;")},
+	}
+
+	_, err := migrations.LoadFromFS(fsys, "sql")
+
+	assert.Error(t, err)
+}
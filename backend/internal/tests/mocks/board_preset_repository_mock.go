@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"nails_game/internal/models"
+)
+
+type MockBoardPresetRepository struct {
+	mock.Mock
+}
+
+func (m *MockBoardPresetRepository) Create(preset *models.BoardPreset) error {
+	return m.Called(preset).Error(0)
+}
+
+func (m *MockBoardPresetRepository) GetByName(name string) (*models.BoardPreset, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BoardPreset), args.Error(1)
+}
+
+func (m *MockBoardPresetRepository) List() ([]models.BoardPreset, error) {
+	args := m.Called()
+	return args.Get(0).([]models.BoardPreset), args.Error(1)
+}
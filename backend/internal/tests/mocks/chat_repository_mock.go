@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"nails_game/internal/models"
+)
+
+type MockChatRepository struct {
+	mock.Mock
+}
+
+func (m *MockChatRepository) Create(message *models.ChatMessage) error {
+	return m.Called(message).Error(0)
+}
+
+func (m *MockChatRepository) ListByGame(gameID uuid.UUID) ([]models.ChatMessage, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChatMessage), args.Error(1)
+}
+
+func (m *MockChatRepository) ListMutes(gameID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatRepository) SetMuted(gameID, playerID uuid.UUID, muted bool) error {
+	return m.Called(gameID, playerID, muted).Error(0)
+}
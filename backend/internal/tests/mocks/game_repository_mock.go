@@ -1,9 +1,12 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
 )
 
 type MockGameRepository struct {
@@ -18,12 +21,52 @@ func (m *MockGameRepository) GetByID(id uuid.UUID) (*models.Game, error) {
 	return args.Get(0).(*models.Game), args.Error(1)
 }
 
-func (m *MockGameRepository) Update(game *models.Game) error {
+func (m *MockGameRepository) Update(game *models.Game, _ ...models.WebhookDelivery) error {
 	args := m.Called(game)
 	return args.Error(0)
 }
 
-func (m *MockGameRepository) Create(game *models.Game) error {
+func (m *MockGameRepository) Create(game *models.Game, _ ...models.WebhookDelivery) error {
 	args := m.Called(game)
 	return args.Error(0)
 }
+
+func (m *MockGameRepository) GetEvents(id uuid.UUID) ([]models.RecordedGameEvent, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RecordedGameEvent), args.Error(1)
+}
+
+func (m *MockGameRepository) Rebuild(id uuid.UUID) (*models.Game, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) ListExpired(now time.Time) ([]uuid.UUID, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockGameRepository) ListInProgress(timeControl enums.TimeControlType) ([]models.Game, error) {
+	args := m.Called(timeControl)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) ListLive(filter models.LiveGameFilter) ([]models.Game, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Game), args.Error(1)
+}
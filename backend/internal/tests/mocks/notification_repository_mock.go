@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"nails_game/internal/models"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Add(notification *models.Notification) error {
+	return m.Called(notification).Error(0)
+}
+
+func (m *MockNotificationRepository) ListPending() ([]models.Notification, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkSent(ids []uuid.UUID, sentAt time.Time) error {
+	return m.Called(ids, sentAt).Error(0)
+}
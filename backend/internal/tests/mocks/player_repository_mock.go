@@ -16,6 +16,17 @@ func (m *MockPlayerRepository) Create(player *models.Player) error {
 
 func (m *MockPlayerRepository) GetByID(id uuid.UUID) (*models.Player, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) GetByEmail(email string) (*models.Player, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*models.Player), args.Error(1)
 }
 
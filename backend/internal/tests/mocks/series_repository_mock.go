@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"nails_game/internal/models"
+)
+
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) Create(series *models.Series) error {
+	return m.Called(series).Error(0)
+}
+
+func (m *MockSeriesRepository) GetByID(id uuid.UUID) (*models.Series, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) List() ([]models.Series, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) ListActive() ([]uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockSeriesRepository) Update(series *models.Series) error {
+	return m.Called(series).Error(0)
+}
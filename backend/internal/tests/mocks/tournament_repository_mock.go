@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+type MockTournamentRepository struct {
+	mock.Mock
+}
+
+func (m *MockTournamentRepository) Create(tournament *models.Tournament) error {
+	return m.Called(tournament).Error(0)
+}
+
+func (m *MockTournamentRepository) GetByID(id uuid.UUID) (*models.Tournament, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tournament), args.Error(1)
+}
+
+func (m *MockTournamentRepository) List() ([]models.Tournament, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Tournament), args.Error(1)
+}
+
+func (m *MockTournamentRepository) ListActive(formats []enums.TournamentFormat) ([]uuid.UUID, error) {
+	args := m.Called(formats)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockTournamentRepository) Update(tournament *models.Tournament) error {
+	return m.Called(tournament).Error(0)
+}
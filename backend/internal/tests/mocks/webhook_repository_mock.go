@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(webhook *models.Webhook) error {
+	return m.Called(webhook).Error(0)
+}
+
+func (m *MockWebhookRepository) GetByID(id uuid.UUID) (*models.Webhook, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(id uuid.UUID) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockWebhookRepository) ListForGame(game *models.Game) ([]models.Webhook, error) {
+	args := m.Called(game)
+	return args.Get(0).([]models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetDelivery(id uuid.UUID) (*models.WebhookDelivery, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	args := m.Called(now, leaseUntil, limit)
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(webhookID uuid.UUID, status enums.DeliveryStatus) ([]models.WebhookDelivery, error) {
+	args := m.Called(webhookID, status)
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return m.Called(delivery).Error(0)
+}
@@ -0,0 +1,283 @@
+package tests
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+// memoryNotifications - хранилище уведомлений в памяти, не пропускающее повторы
+type memoryNotifications struct {
+	notifications []models.Notification
+}
+
+func (r *memoryNotifications) Add(notification *models.Notification) error {
+	for _, existing := range r.notifications {
+		if existing.GameID == notification.GameID && existing.PlayerID == notification.PlayerID &&
+			existing.Kind == notification.Kind && existing.TurnStartedAt.Equal(notification.TurnStartedAt) {
+			return nil
+		}
+	}
+	r.notifications = append(r.notifications, *notification)
+	return nil
+}
+
+func (r *memoryNotifications) ListPending() ([]models.Notification, error) {
+	var pending []models.Notification
+	for _, notification := range r.notifications {
+		if notification.SentAt == nil {
+			pending = append(pending, notification)
+		}
+	}
+	return pending, nil
+}
+
+func (r *memoryNotifications) MarkSent(ids []uuid.UUID, sentAt time.Time) error {
+	for i := range r.notifications {
+		for _, id := range ids {
+			if r.notifications[i].ID == id {
+				r.notifications[i].SentAt = &sentAt
+			}
+		}
+	}
+	return nil
+}
+
+// recordingNotifier запоминает письма вместо отправки
+type recordingNotifier struct {
+	messages []serviceInterfaces.Message
+}
+
+func (n *recordingNotifier) Send(message serviceInterfaces.Message) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+// createCorrespondenceGame создаёт заочную партию, в которой ходит первый игрок
+func createCorrespondenceGame(clock *fakeClock, first, second uuid.UUID) models.Game {
+	game := models.Game{ID: uuid.New()}
+	game.Raise(&models.GameCreated{
+		LineSize:        9,
+		Variant:         enums.StandardVariant,
+		TimeControl:     models.TimeControl{Type: enums.CorrespondenceTimeControl, DaysPerMove: 3},
+		FirstPlayerID:   first,
+		SecondPlayerID:  second,
+		CurrentPlayerID: first,
+		CreatedAt:       clock.Now(),
+	})
+	return game
+}
+
+type notificationTest struct {
+	service    serviceInterfaces.NotificationService
+	gameRepo   *mocks.MockGameRepository
+	repo       *memoryNotifications
+	notifier   *recordingNotifier
+	first      *models.Player
+	second     *models.Player
+	clock      *fakeClock
+	playerRepo *mocks.MockPlayerRepository
+}
+
+func newNotificationTest(firstMode, secondMode enums.NotificationMode) *notificationTest {
+	test := &notificationTest{
+		gameRepo:   new(mocks.MockGameRepository),
+		repo:       &memoryNotifications{},
+		notifier:   &recordingNotifier{},
+		clock:      &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)},
+		playerRepo: new(mocks.MockPlayerRepository),
+	}
+	test.first = &models.Player{ID: uuid.New(), Email: "first@example.com",
+		Notifications: models.NotificationPreferences{Mode: firstMode}}
+	test.second = &models.Player{ID: uuid.New(), Email: "second@example.com",
+		Notifications: models.NotificationPreferences{Mode: secondMode}}
+	test.playerRepo.On("GetByID", test.first.ID).Return(test.first, nil)
+	test.playerRepo.On("GetByID", test.second.ID).Return(test.second, nil)
+
+	policy := serviceInterfaces.NotificationPolicy{ReminderBefore: 12 * time.Hour, DigestInterval: 24 * time.Hour}
+	test.service = services.NewNotificationService(test.repo, test.gameRepo, test.playerRepo, test.notifier, policy, test.clock.Now)
+	return test
+}
+
+func (test *notificationTest) notify(t *testing.T, games ...models.Game) int {
+	t.Helper()
+	test.gameRepo.On("ListInProgress", enums.CorrespondenceTimeControl).Return(games, nil).Once()
+	sent, err := test.service.NotifyPlayers()
+	require.NoError(t, err)
+	return sent
+}
+
+func TestNotificationService_NotifiesPlayerToMoveOnceAndReminds(t *testing.T) {
+	test := newNotificationTest(enums.ImmediateNotifications, enums.ImmediateNotifications)
+	game := createCorrespondenceGame(test.clock, test.first.ID, test.second.ID)
+
+	require.Equal(t, 1, test.notify(t, game))
+	message := test.notifier.messages[0]
+	assert.Equal(t, test.first.Email, message.To)
+	assert.Equal(t, "Your move in game "+game.ID.String(), message.Subject)
+	assert.Contains(t, message.Body, "2026-01-04 12:00 UTC")
+
+	test.clock.Advance(time.Hour)
+	assert.Zero(t, test.notify(t, game))
+
+	test.clock.Advance(2*24*time.Hour + 11*time.Hour)
+	require.Equal(t, 1, test.notify(t, game))
+	assert.Equal(t, "Time is running out in game "+game.ID.String(), test.notifier.messages[1].Subject)
+	assert.Zero(t, test.notify(t, game))
+}
+
+func TestNotificationService_DigestCollectsTurns(t *testing.T) {
+	test := newNotificationTest(enums.DigestNotifications, enums.ImmediateNotifications)
+	games := []models.Game{
+		createCorrespondenceGame(test.clock, test.first.ID, test.second.ID),
+		createCorrespondenceGame(test.clock, test.first.ID, test.second.ID),
+	}
+
+	assert.Zero(t, test.notify(t, games...))
+	test.clock.Advance(23 * time.Hour)
+	assert.Zero(t, test.notify(t, games...))
+	test.clock.Advance(time.Hour)
+
+	require.Equal(t, 1, test.notify(t, games...))
+	message := test.notifier.messages[0]
+	assert.Equal(t, "Your move in 2 games", message.Subject)
+	assert.Contains(t, message.Body, games[0].ID.String())
+	assert.Contains(t, message.Body, games[1].ID.String())
+}
+
+func TestNotificationService_DropsStaleAndMutedNotifications(t *testing.T) {
+	test := newNotificationTest(enums.DigestNotifications, enums.NoNotifications)
+	game := createCorrespondenceGame(test.clock, test.first.ID, test.second.ID)
+	assert.Zero(t, test.notify(t, game))
+
+	test.clock.Advance(time.Hour)
+	game.Raise(&models.MovePlayed{
+		PlayerID:     test.first.ID,
+		Position:     0,
+		State:        enums.FirstPlayer,
+		NextPlayerID: test.second.ID,
+		ClockMs:      (72 * time.Hour).Milliseconds(),
+		PlayedAt:     test.clock.Now(),
+	})
+	require.Equal(t, test.second.ID, game.CurrentPlayerID)
+	test.clock.Advance(24 * time.Hour)
+
+	assert.Zero(t, test.notify(t, game))
+	assert.Empty(t, test.notifier.messages)
+	pending, err := test.repo.ListPending()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	test := newNotificationTest("", enums.ImmediateNotifications)
+	test.playerRepo.On("Update", mock.Anything).Return(nil)
+
+	preferences, err := test.service.Preferences(test.first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, enums.ImmediateNotifications, preferences.Mode)
+
+	_, err = test.service.UpdatePreferences(test.first.ID, models.NotificationPreferences{Mode: "weekly"})
+	assertErrorCode(t, err, serviceErrors.CodeValidationFailed)
+
+	preferences, err = test.service.UpdatePreferences(test.first.ID,
+		models.NotificationPreferences{Mode: enums.DigestNotifications, NoReminders: true})
+	require.NoError(t, err)
+	assert.Equal(t, enums.DigestNotifications, preferences.Mode)
+	assert.True(t, test.first.Notifications.NoReminders)
+	test.playerRepo.AssertCalled(t, "Update", test.first)
+}
+
+// startFakeSMTPServer принимает письма по SMTP на локальном порту и передаёт их текст в канал
+func startFakeSMTPServer(t *testing.T) (string, string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+
+		reply("220 localhost ESMTP")
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					messages <- data.String()
+					reply("250 OK")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case command == "DATA":
+				inData = true
+				reply("354 End data with <CR><LF>.<CR><LF>")
+			case command == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return host, port, messages
+}
+
+func TestSMTPNotifier_SendsThroughServer(t *testing.T) {
+	host, port, messages := startFakeSMTPServer(t)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	notifier := services.NewSMTPNotifier(host, port, "", "", "nails@example.com", clock.Now)
+
+	err := notifier.Send(serviceInterfaces.Message{
+		To:      "player@example.com",
+		Subject: "Your move in game 42",
+		Body:    "It is your move.\nGood luck.",
+	})
+
+	require.NoError(t, err)
+	select {
+	case message := <-messages:
+		assert.Contains(t, message, "From: nails@example.com\r\n")
+		assert.Contains(t, message, "To: player@example.com\r\n")
+		assert.Contains(t, message, "Subject: Your move in game 42\r\n")
+		assert.Contains(t, message, "Date: Thu, 01 Jan 2026 12:00:00 +0000\r\n")
+		assert.True(t, strings.HasSuffix(message, "\r\n\r\nIt is your move.\r\nGood luck.\r\n"))
+	case <-time.After(time.Second):
+		require.Fail(t, "no message reached the SMTP server")
+	}
+}
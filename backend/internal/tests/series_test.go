@@ -0,0 +1,247 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/controllers"
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+// seriesGames дополняет подменённый сервис партий реваншами со сменой цвета
+type seriesGames struct {
+	*tournamentGames
+}
+
+func (g *seriesGames) CheckSettings(models.GameSettings) error {
+	return nil
+}
+
+func (g *seriesGames) CreateRematch(gameID uuid.UUID) (*models.Game, error) {
+	previous := g.games[gameID]
+	return g.CreateGame(models.GameSettings{}, []uuid.UUID{previous.SecondPlayerID, previous.FirstPlayerID})
+}
+
+func newSeriesTestService(clock *fakeClock) (serviceInterfaces.SeriesService, *mocks.MockSeriesRepository, *seriesGames) {
+	mockSeriesRepo := new(mocks.MockSeriesRepository)
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+	mockSeriesRepo.On("Create", mock.Anything).Return(nil)
+	mockSeriesRepo.On("Update", mock.Anything).Return(nil)
+
+	games := &seriesGames{&tournamentGames{games: map[uuid.UUID]*models.Game{}}}
+	return services.NewSeriesService(mockSeriesRepo, mockPlayerRepo, games, clock.Now), mockSeriesRepo, games
+}
+
+// advanceSeries прогоняет планировщик серий по единственной серии
+func advanceSeries(t *testing.T, service serviceInterfaces.SeriesService, mockSeriesRepo *mocks.MockSeriesRepository, series *models.Series) {
+	t.Helper()
+	mockSeriesRepo.On("ListActive").Return([]uuid.UUID{series.ID}, nil).Once()
+	mockSeriesRepo.On("GetByID", series.ID).Return(series, nil).Once()
+	_, err := service.AdvanceSeries()
+	require.NoError(t, err)
+}
+
+func TestSeriesService_CreateSeries_Validation(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	service, _, _ := newSeriesTestService(clock)
+	playerID := uuid.New()
+	past := clock.now.Add(-time.Minute)
+
+	_, err := service.CreateSeries(models.SeriesSettings{
+		FirstPlayerID:  playerID,
+		SecondPlayerID: playerID,
+		BestOf:         16,
+		StartsAt:       &past,
+	})
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	var fields []string
+	for _, field := range validation.Fields {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(t, []string{"bestOf", "startsAt", "secondPlayerId"}, fields)
+}
+
+func TestSeriesService_BestOfThree_EndsWhenMajorityWon(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	service, mockSeriesRepo, games := newSeriesTestService(clock)
+	first, second := uuid.New(), uuid.New()
+
+	series, err := service.CreateSeries(models.SeriesSettings{FirstPlayerID: first, SecondPlayerID: second, BestOf: 3})
+	require.NoError(t, err)
+	assert.Equal(t, enums.RunningSeries, series.Status)
+	require.Len(t, series.Games, 1)
+
+	finish(games.created[0], first)
+	advanceSeries(t, service, mockSeriesRepo, series)
+
+	require.Len(t, series.Games, 2)
+	assert.Equal(t, enums.FirstPlayerWin, series.Games[0].Result)
+	assert.Equal(t, second, series.Games[1].FirstPlayerID)
+	assert.Equal(t, enums.RunningSeries, series.Status)
+
+	finish(games.created[1], first)
+	advanceSeries(t, service, mockSeriesRepo, series)
+
+	assert.Equal(t, enums.FinishedSeries, series.Status)
+	assert.Len(t, series.Games, 2)
+	assert.Equal(t, 2.0, series.Score(first))
+	assert.Zero(t, series.Score(second))
+	require.NotNil(t, series.WinnerID)
+	assert.Equal(t, first, *series.WinnerID)
+}
+
+func TestSeriesService_AbortedGameIsReplayed(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	service, mockSeriesRepo, games := newSeriesTestService(clock)
+	first, second := uuid.New(), uuid.New()
+
+	series, err := service.CreateSeries(models.SeriesSettings{FirstPlayerID: first, SecondPlayerID: second, BestOf: 2})
+	require.NoError(t, err)
+
+	games.created[0].Status = enums.Aborted
+	advanceSeries(t, service, mockSeriesRepo, series)
+	finish(games.created[1], uuid.Nil)
+	advanceSeries(t, service, mockSeriesRepo, series)
+	assert.Equal(t, enums.RunningSeries, series.Status)
+	finish(games.created[2], uuid.Nil)
+	advanceSeries(t, service, mockSeriesRepo, series)
+
+	require.Len(t, series.Games, 3)
+	assert.Equal(t, enums.AbortedResult, series.Games[0].Result)
+	assert.Equal(t, 2, series.Played())
+	assert.Equal(t, enums.FinishedSeries, series.Status)
+	assert.Equal(t, 1.0, series.Score(first))
+	assert.Nil(t, series.WinnerID)
+}
+
+func TestSeriesService_EndsAfterReplaysExhausted(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	service, mockSeriesRepo, games := newSeriesTestService(clock)
+	first, second := uuid.New(), uuid.New()
+
+	series, err := service.CreateSeries(models.SeriesSettings{FirstPlayerID: first, SecondPlayerID: second, BestOf: 3})
+	require.NoError(t, err)
+	finish(games.created[0], second)
+	advanceSeries(t, service, mockSeriesRepo, series)
+
+	// исходная партия и три переигровки прерваны
+	for i := 1; i <= 4; i++ {
+		require.Len(t, games.created, i+1)
+		games.created[i].Status = enums.Aborted
+		advanceSeries(t, service, mockSeriesRepo, series)
+	}
+
+	assert.Len(t, games.created, 5)
+	assert.Equal(t, enums.FinishedSeries, series.Status)
+	require.NotNil(t, series.WinnerID)
+	assert.Equal(t, second, *series.WinnerID)
+}
+
+func TestSeriesService_ScheduledSeriesStartsOnTime(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	service, mockSeriesRepo, games := newSeriesTestService(clock)
+	startsAt := clock.now.Add(time.Hour)
+
+	series, err := service.CreateSeries(models.SeriesSettings{
+		FirstPlayerID:  uuid.New(),
+		SecondPlayerID: uuid.New(),
+		BestOf:         5,
+		StartsAt:       &startsAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, enums.ScheduledSeries, series.Status)
+	assert.Empty(t, games.created)
+
+	advanceSeries(t, service, mockSeriesRepo, series)
+	assert.Empty(t, games.created)
+
+	clock.Advance(time.Hour)
+	advanceSeries(t, service, mockSeriesRepo, series)
+
+	assert.Equal(t, enums.RunningSeries, series.Status)
+	require.Len(t, series.Games, 1)
+	assert.Equal(t, games.created[0].ID, series.Games[0].GameID)
+}
+
+func TestSeriesService_AdvanceSeries_ContinuesAfterFailure(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	service, mockSeriesRepo, games := newSeriesTestService(clock)
+	startsAt := clock.now.Add(time.Hour)
+
+	series, err := service.CreateSeries(models.SeriesSettings{
+		FirstPlayerID:  uuid.New(),
+		SecondPlayerID: uuid.New(),
+		BestOf:         3,
+		StartsAt:       &startsAt,
+	})
+	require.NoError(t, err)
+	brokenID := uuid.New()
+	mockSeriesRepo.On("ListActive").Return([]uuid.UUID{brokenID, series.ID}, nil).Once()
+	mockSeriesRepo.On("GetByID", brokenID).Return(nil, errors.New("db down")).Once()
+	mockSeriesRepo.On("GetByID", series.ID).Return(series, nil).Once()
+
+	clock.Advance(time.Hour)
+	advanced, err := service.AdvanceSeries()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), brokenID.String())
+	assert.Equal(t, 1, advanced)
+	assert.Equal(t, enums.RunningSeries, series.Status)
+	assert.Len(t, games.created, 1)
+}
+
+// createdSeries - сервис серий, который только запоминает созданные серии
+type createdSeries struct {
+	serviceInterfaces.SeriesService
+	settings []models.SeriesSettings
+}
+
+func (s *createdSeries) CreateSeries(settings models.SeriesSettings) (*models.Series, error) {
+	s.settings = append(s.settings, settings)
+	return &models.Series{ID: uuid.New(), FirstPlayerID: settings.FirstPlayerID, SecondPlayerID: settings.SecondPlayerID}, nil
+}
+
+func TestSeriesController_CreateSeries_OnlyByItsPlayers(t *testing.T) {
+	tokens := services.NewTokenService("secret", time.Hour, time.Now)
+	series := &createdSeries{}
+	controller := controllers.NewSeriesController(series, tokens)
+
+	e := echo.New()
+	e.HTTPErrorHandler = controllers.NewErrorHandler(logrus.New())
+	e.Use(controllers.NewAuthMiddleware(tokens))
+	e.POST("/api/series", controller.CreateSeries)
+
+	firstID, secondID := uuid.New(), uuid.New()
+	body := `{"firstPlayerId":"` + firstID.String() + `","secondPlayerId":"` + secondID.String() + `","bestOf":3}`
+
+	status, resp := callAPI(t, e, http.MethodPost, "/api/series", body, "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, string(serviceErrors.CodeAuthenticationRequired), resp.Code)
+
+	status, resp = callAPI(t, e, http.MethodPost, "/api/series", body, tokens.Issue(uuid.New(), uuid.Nil))
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, string(serviceErrors.CodePlayerMismatch), resp.Code)
+	assert.Empty(t, series.settings)
+
+	status, _ = callAPI(t, e, http.MethodPost, "/api/series", body, tokens.Issue(secondID, uuid.Nil))
+	assert.Equal(t, http.StatusCreated, status)
+	require.Len(t, series.settings, 1)
+	assert.Equal(t, firstID, series.settings[0].FirstPlayerID)
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"nails_game/internal/models"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+func TestTokenService_VerifiesIssuedToken(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tokens := services.NewTokenService("secret", time.Hour, clock.Now)
+	playerID := uuid.New()
+
+	verified, err := tokens.Verify(tokens.Issue(playerID, uuid.Nil), uuid.New())
+
+	require.NoError(t, err)
+	assert.Equal(t, playerID, verified)
+}
+
+func TestTokenService_GameTokenOnlyWorksInItsGame(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tokens := services.NewTokenService("secret", time.Hour, clock.Now)
+	playerID, gameID := uuid.New(), uuid.New()
+	token := tokens.Issue(playerID, gameID)
+
+	verified, err := tokens.Verify(token, gameID)
+	require.NoError(t, err)
+	assert.Equal(t, playerID, verified)
+
+	_, err = tokens.Verify(token, uuid.New())
+	assertErrorCode(t, err, serviceErrors.CodeInvalidToken)
+	_, err = tokens.Verify(token, uuid.Nil)
+	assertErrorCode(t, err, serviceErrors.CodeInvalidToken)
+}
+
+func TestTokenService_RejectsInvalidTokens(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tokens := services.NewTokenService("secret", time.Hour, clock.Now)
+	token := tokens.Issue(uuid.New(), uuid.Nil)
+	tampered := "A" + token[1:]
+	if token[0] == 'A' {
+		tampered = "B" + token[1:]
+	}
+
+	cases := map[string]func() string{
+		"malformed": func() string { return "not-a-token" },
+		"tampered":  func() string { return tampered },
+		"foreign": func() string {
+			return services.NewTokenService("other", time.Hour, clock.Now).Issue(uuid.New(), uuid.Nil)
+		},
+		"expired": func() string {
+			clock.Advance(time.Hour)
+			return token
+		},
+	}
+
+	for name, tokenFor := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tokens.Verify(tokenFor(), uuid.Nil)
+			assertErrorCode(t, err, serviceErrors.CodeInvalidToken)
+		})
+	}
+}
+
+func TestAuthService_LoginChecksPassword(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tokens := services.NewTokenService("secret", time.Hour, clock.Now)
+	hash, err := bcrypt.GenerateFromPassword([]byte("first-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	player := &models.Player{ID: uuid.New(), Email: "first@example.com", PasswordHash: string(hash)}
+	playerRepo := new(mocks.MockPlayerRepository)
+	playerRepo.On("GetByEmail", player.Email).Return(player, nil)
+	playerRepo.On("GetByEmail", "nobody@example.com").Return(nil, repositories.ErrPlayerNotFound)
+	auth := services.NewAuthService(playerRepo, tokens)
+
+	_, _, err = auth.Login(player.Email, "wrong-password")
+	assertErrorCode(t, err, serviceErrors.CodeInvalidCredentials)
+	_, _, err = auth.Login("nobody@example.com", "first-password")
+	assertErrorCode(t, err, serviceErrors.CodeInvalidCredentials)
+
+	loggedIn, token, err := auth.Login(player.Email, "first-password")
+	require.NoError(t, err)
+	assert.Equal(t, player.ID, loggedIn.ID)
+	// токен входа действует в любой партии
+	verified, err := tokens.Verify(token, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, player.ID, verified)
+}
@@ -0,0 +1,217 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	"nails_game/internal/tests/mocks"
+)
+
+func createArenaTestTournament(players int) *models.Tournament {
+	tournament := createTestTournament(enums.ArenaFormat, 0, players)
+	tournament.Duration = time.Hour
+	return tournament
+}
+
+// arenaGame добавляет сыгранную партию арены, завершённую в момент at
+func arenaGame(tournament *models.Tournament, first, second uuid.UUID, result enums.PairingResult, at time.Time) {
+	tournament.Pairings = append(tournament.Pairings, models.TournamentPairing{
+		ID:             uuid.New(),
+		FirstPlayerID:  first,
+		SecondPlayerID: &second,
+		Result:         result,
+		FinishedAt:     &at,
+	})
+}
+
+func TestTournamentService_CreateArena_NeedsDurationAndClock(t *testing.T) {
+	organizerID := uuid.New()
+
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", organizerID).Return(&models.Player{}, nil)
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+
+	gameService := services.NewGameService(new(mocks.MockGameRepository), mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	service := services.NewTournamentService(mockTournamentRepo, mockPlayerRepo, gameService, time.Now)
+
+	_, err := service.CreateTournament(models.TournamentSettings{
+		Name:   "Friday arena",
+		Format: enums.ArenaFormat,
+	}, organizerID)
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	fields := make([]string, 0, len(validation.Fields))
+	for _, f := range validation.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.ElementsMatch(t, []string{"durationMinutes", "game.timeControl.type"}, fields)
+	mockTournamentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTournamentService_Arena_PairsWaitingPlayers(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	tournament := createArenaTestTournament(5)
+	service, games := newTournamentTestService(tournament, clock)
+
+	_, err := service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	require.NotNil(t, tournament.EndsAt)
+	assert.True(t, clock.now.Add(time.Hour).Equal(*tournament.EndsAt))
+	require.Len(t, games.created, 2)
+
+	// пока все играют, новых пар нет
+	clock.Advance(time.Minute)
+	advanced, err := service.AdvanceArenas()
+	require.NoError(t, err)
+	assert.Zero(t, advanced)
+
+	first := games.created[0]
+	finish(first, first.FirstPlayerID)
+	clock.Advance(time.Minute)
+	_, err = service.AdvanceArenas()
+	require.NoError(t, err)
+
+	require.Len(t, games.created, 3)
+	next := games.created[2]
+	assert.NotEqual(t,
+		map[uuid.UUID]bool{first.FirstPlayerID: true, first.SecondPlayerID: true},
+		map[uuid.UUID]bool{next.FirstPlayerID: true, next.SecondPlayerID: true},
+		"arena paired a rematch")
+	assert.Len(t, waitingIn(tournament), 1)
+}
+
+func TestTournamentService_Arena_StreakDoublesPoints(t *testing.T) {
+	tournament := createArenaTestTournament(3)
+	a, b, c := tournament.Players[0].PlayerID, tournament.Players[1].PlayerID, tournament.Players[2].PlayerID
+	start := time.Now()
+	arenaGame(tournament, a, b, enums.FirstPlayerWin, start)
+	arenaGame(tournament, c, a, enums.SecondPlayerWin, start.Add(time.Minute))
+	arenaGame(tournament, a, b, enums.FirstPlayerWin, start.Add(2*time.Minute))
+	arenaGame(tournament, c, a, enums.DrawResult, start.Add(3*time.Minute))
+	arenaGame(tournament, b, c, enums.SecondPlayerWin, start.Add(4*time.Minute))
+	arenaGame(tournament, b, c, enums.AbortedResult, start.Add(5*time.Minute))
+	service, _ := newTournamentTestService(tournament, &fakeClock{now: start})
+
+	table, err := service.Standings(tournament.ID)
+	require.NoError(t, err)
+
+	// a: 2 + 2 + 4 за третью победу подряд + 2 за ничью на серии
+	assert.Equal(t, a, table[0].PlayerID)
+	assert.Equal(t, 10.0, table[0].Points)
+	assert.False(t, table[0].OnFire)
+	assert.Equal(t, c, table[1].PlayerID)
+	assert.Equal(t, 3.0, table[1].Points)
+	assert.Equal(t, 3, table[1].Losses+table[1].Draws+table[1].Wins)
+	assert.Equal(t, 0.0, table[2].Points)
+}
+
+func TestTournamentService_Arena_EndsAtFixedTime(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	tournament := createArenaTestTournament(4)
+	service, games := newTournamentTestService(tournament, clock)
+
+	_, err := service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	require.Len(t, games.created, 2)
+	finished, unfinished := games.created[0], games.created[1]
+	finish(finished, finished.SecondPlayerID)
+
+	clock.Advance(time.Hour)
+	_, err = service.AdvanceArenas()
+	require.NoError(t, err)
+
+	assert.Equal(t, enums.FinishedTournament, tournament.Status)
+	assert.Len(t, games.created, 2)
+	finish(unfinished, unfinished.FirstPlayerID)
+
+	table, err := service.Standings(tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, finished.SecondPlayerID, table[0].PlayerID)
+	assert.Equal(t, 2.0, table[0].Points)
+	assert.Equal(t, 0.0, table[1].Points)
+}
+
+func TestTournamentService_Arena_LeaveAndReturn(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	tournament := createArenaTestTournament(2)
+	service, games := newTournamentTestService(tournament, clock)
+	playerID := tournament.Players[1].PlayerID
+
+	_, err := service.Leave(tournament.ID, playerID)
+	require.NoError(t, err)
+	_, err = service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	assert.Empty(t, games.created)
+
+	_, err = service.Register(tournament.ID, playerID)
+	require.NoError(t, err)
+	assert.False(t, tournament.Players[1].Paused)
+	_, err = service.AdvanceArenas()
+	require.NoError(t, err)
+	assert.Len(t, games.created, 1)
+
+	_, err = service.Leave(tournament.ID, uuid.New())
+	assertErrorCode(t, err, serviceErrors.CodeNotRegistered)
+}
+
+func TestTournamentService_Leave_OnlyForArena(t *testing.T) {
+	tournament := createTestTournament(enums.SwissFormat, 3, 2)
+	service, _ := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	_, err := service.Leave(tournament.ID, tournament.Players[0].PlayerID)
+
+	assertErrorCode(t, err, serviceErrors.CodeArenaOnly)
+}
+
+// waitingIn возвращает игроков арены без текущей партии
+func waitingIn(tournament *models.Tournament) []uuid.UUID {
+	playing := make(map[uuid.UUID]bool)
+	for _, pairing := range tournament.Pairings {
+		if pairing.Result == enums.PendingResult {
+			playing[pairing.FirstPlayerID] = true
+			playing[*pairing.SecondPlayerID] = true
+		}
+	}
+	var waiting []uuid.UUID
+	for _, p := range tournament.Players {
+		if !playing[p.PlayerID] {
+			waiting = append(waiting, p.PlayerID)
+		}
+	}
+	return waiting
+}
+
+func TestTournamentService_AdvanceArenas_ContinuesAfterFailure(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tournament := createArenaTestTournament(2)
+	startsAt := clock.Now()
+	tournament.StartsAt = &startsAt
+	brokenID := uuid.New()
+
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+	mockTournamentRepo.On("ListActive", mock.Anything).Return([]uuid.UUID{brokenID, tournament.ID}, nil)
+	mockTournamentRepo.On("GetByID", brokenID).Return(nil, errors.New("db down"))
+	mockTournamentRepo.On("GetByID", tournament.ID).Return(tournament, nil)
+	mockTournamentRepo.On("Update", tournament).Return(nil)
+	games := &tournamentGames{games: make(map[uuid.UUID]*models.Game)}
+	service := services.NewTournamentService(mockTournamentRepo, new(mocks.MockPlayerRepository), games, clock.Now)
+
+	advanced, err := service.AdvanceArenas()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), brokenID.String())
+	assert.Equal(t, 1, advanced)
+	assert.Equal(t, enums.RunningTournament, tournament.Status)
+	assert.Len(t, games.created, 1)
+}
@@ -0,0 +1,592 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/controllers"
+	"nails_game/internal/models"
+	"nails_game/internal/models/dtos"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+// tournamentGames подменяет сервис партий: запоминает созданные партии,
+// а тест завершает их через finish
+type tournamentGames struct {
+	serviceInterfaces.GameService
+	games   map[uuid.UUID]*models.Game
+	created []*models.Game
+	// limit - сколько партий можно создать, прежде чем создание начнёт падать; 0 - без ограничения
+	limit int
+}
+
+func (g *tournamentGames) CreateGame(_ models.GameSettings, playerIDs []uuid.UUID) (*models.Game, error) {
+	if g.limit > 0 && len(g.created) >= g.limit {
+		return nil, errors.New("db down")
+	}
+	game := &models.Game{
+		ID:             uuid.New(),
+		Status:         enums.InProgress,
+		FirstPlayerID:  playerIDs[0],
+		SecondPlayerID: playerIDs[1],
+	}
+	g.games[game.ID] = game
+	g.created = append(g.created, game)
+	return game, nil
+}
+
+func (g *tournamentGames) LoadGame(gameID uuid.UUID) (*models.Game, error) {
+	return g.games[gameID], nil
+}
+
+// inProgress возвращает ещё не завершённые партии в порядке создания
+func (g *tournamentGames) inProgress() []*models.Game {
+	var games []*models.Game
+	for _, game := range g.created {
+		if game.Status == enums.InProgress {
+			games = append(games, game)
+		}
+	}
+	return games
+}
+
+// finish завершает партию победой winner или ничьей, если winner - uuid.Nil
+func finish(game *models.Game, winner uuid.UUID) {
+	switch winner {
+	case uuid.Nil:
+		game.Status = enums.Draw
+	case game.FirstPlayerID:
+		game.Status = enums.FirstPlayerWon
+	default:
+		game.Status = enums.SecondPlayerWon
+	}
+}
+
+func createTestTournament(format enums.TournamentFormat, rounds, players int) *models.Tournament {
+	tournament := &models.Tournament{
+		ID:          uuid.New(),
+		Name:        "Weekly",
+		Format:      format,
+		Status:      enums.RegistrationTournament,
+		OrganizerID: uuid.New(),
+		Rounds:      rounds,
+	}
+	for i := 0; i < players; i++ {
+		tournament.Players = append(tournament.Players, models.TournamentPlayer{
+			TournamentID: tournament.ID,
+			PlayerID:     uuid.New(),
+			Seed:         i + 1,
+		})
+	}
+	return tournament
+}
+
+func newTournamentTestService(
+	tournament *models.Tournament,
+	clock *fakeClock,
+) (serviceInterfaces.TournamentService, *tournamentGames) {
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+	mockTournamentRepo.On("GetByID", tournament.ID).Return(tournament, nil)
+	mockTournamentRepo.On("ListActive", mock.Anything).Return([]uuid.UUID{tournament.ID}, nil)
+	mockTournamentRepo.On("Update", tournament).Return(nil)
+
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+
+	games := &tournamentGames{games: make(map[uuid.UUID]*models.Game)}
+	return services.NewTournamentService(mockTournamentRepo, mockPlayerRepo, games, clock.Now), games
+}
+
+// playTournament доигрывает турнир: в каждой партии побеждает игрок с лучшим номером
+func playTournament(t *testing.T, service serviceInterfaces.TournamentService, tournament *models.Tournament, games *tournamentGames) {
+	t.Helper()
+	seeds := make(map[uuid.UUID]int)
+	for _, p := range tournament.Players {
+		seeds[p.PlayerID] = p.Seed
+	}
+
+	for i := 0; tournament.Status != enums.FinishedTournament; i++ {
+		require.Less(t, i, 100, "tournament did not finish")
+		for _, game := range games.inProgress() {
+			winner := game.FirstPlayerID
+			if seeds[game.SecondPlayerID] < seeds[winner] {
+				winner = game.SecondPlayerID
+			}
+			finish(game, winner)
+		}
+		_, err := service.AdvanceTournaments()
+		require.NoError(t, err)
+	}
+}
+
+func TestTournamentService_CreateTournament_ValidatesSettings(t *testing.T) {
+	organizerID := uuid.New()
+
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", organizerID).Return(&models.Player{}, nil)
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+
+	gameService := services.NewGameService(new(mocks.MockGameRepository), mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	service := services.NewTournamentService(mockTournamentRepo, mockPlayerRepo, gameService, time.Now)
+
+	_, err := service.CreateTournament(models.TournamentSettings{
+		Name:   "Weekly",
+		Format: enums.SwissFormat,
+		Game:   models.GameSettings{LineSize: 1},
+	}, organizerID)
+
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	fields := make([]string, 0, len(validation.Fields))
+	for _, f := range validation.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.ElementsMatch(t, []string{"rounds", "game.line_size"}, fields)
+	mockTournamentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTournamentService_CreateTournament_StoresDefaults(t *testing.T) {
+	organizerID := uuid.New()
+
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", organizerID).Return(&models.Player{}, nil)
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+	mockTournamentRepo.On("Create", mock.Anything).Return(nil)
+
+	gameService := services.NewGameService(new(mocks.MockGameRepository), mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy())
+	service := services.NewTournamentService(mockTournamentRepo, mockPlayerRepo, gameService, time.Now)
+
+	tournament, err := service.CreateTournament(models.TournamentSettings{
+		Name:   " Weekly ",
+		Format: enums.RoundRobinFormat,
+	}, organizerID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly", tournament.Name)
+	assert.Equal(t, enums.RegistrationTournament, tournament.Status)
+	assert.Equal(t, enums.StandardVariant, tournament.GameSettings.Variant)
+	assert.Equal(t, enums.UnlimitedTimeControl, tournament.GameSettings.TimeControl.Type)
+}
+
+func TestTournamentService_Register(t *testing.T) {
+	tournament := createTestTournament(enums.RoundRobinFormat, 0, 1)
+	service, _ := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	playerID := uuid.New()
+	_, err := service.Register(tournament.ID, playerID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, tournament.Players[1].Seed)
+
+	_, err = service.Register(tournament.ID, playerID)
+	assertErrorCode(t, err, serviceErrors.CodeAlreadyRegistered)
+
+	_, err = service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	_, err = service.Register(tournament.ID, uuid.New())
+	assertErrorCode(t, err, serviceErrors.CodeRegistrationClosed)
+}
+
+func TestTournamentService_Register_UnknownTournament(t *testing.T) {
+	tournamentID := uuid.New()
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+	mockTournamentRepo.On("GetByID", tournamentID).Return(nil, repositories.ErrTournamentNotFound)
+
+	service := services.NewTournamentService(mockTournamentRepo, new(mocks.MockPlayerRepository), nil, time.Now)
+	_, err := service.Register(tournamentID, uuid.New())
+
+	assertErrorCode(t, err, serviceErrors.CodeTournamentNotFound)
+}
+
+func TestTournamentService_Start_Checks(t *testing.T) {
+	tournament := createTestTournament(enums.RoundRobinFormat, 0, 1)
+	service, games := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	_, err := service.Start(tournament.ID, tournament.Players[0].PlayerID)
+	assertErrorCode(t, err, serviceErrors.CodeNotOrganizer)
+
+	_, err = service.Start(tournament.ID, tournament.OrganizerID)
+	assertErrorCode(t, err, serviceErrors.CodeNotEnoughPlayers)
+	assert.Empty(t, games.created)
+}
+
+func TestTournamentService_RoundRobin_EveryoneMeetsOnce(t *testing.T) {
+	tournament := createTestTournament(enums.RoundRobinFormat, 0, 5)
+	service, games := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	_, err := service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, tournament.Rounds)
+	playTournament(t, service, tournament, games)
+
+	met := make(map[[2]uuid.UUID]int)
+	byes := make(map[uuid.UUID]int)
+	for _, pairing := range tournament.Pairings {
+		if pairing.IsBye() {
+			byes[pairing.FirstPlayerID]++
+			continue
+		}
+		a, b := pairing.FirstPlayerID, *pairing.SecondPlayerID
+		if a.String() > b.String() {
+			a, b = b, a
+		}
+		met[[2]uuid.UUID{a, b}]++
+	}
+	assert.Len(t, met, 10)
+	for _, count := range met {
+		assert.Equal(t, 1, count)
+	}
+	assert.Len(t, byes, 5)
+	assert.Len(t, games.created, 10)
+
+	table, err := service.Standings(tournament.ID)
+	require.NoError(t, err)
+	for i, standing := range table {
+		assert.Equal(t, i+1, standing.Seed)
+		assert.Equal(t, float64(5-i), standing.Points)
+	}
+}
+
+func TestTournamentService_Swiss_AvoidsRematchesAndRepeatedByes(t *testing.T) {
+	tournament := createTestTournament(enums.SwissFormat, 4, 7)
+	service, games := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	_, err := service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	playTournament(t, service, tournament, games)
+
+	assert.Equal(t, 4, tournament.CurrentRound)
+	met := make(map[[2]uuid.UUID]bool)
+	byes := make(map[uuid.UUID]bool)
+	for _, pairing := range tournament.Pairings {
+		if pairing.IsBye() {
+			assert.False(t, byes[pairing.FirstPlayerID], "player received a second bye")
+			byes[pairing.FirstPlayerID] = true
+			continue
+		}
+		key := [2]uuid.UUID{pairing.FirstPlayerID, *pairing.SecondPlayerID}
+		assert.False(t, met[key], "players met twice")
+		met[key] = true
+		met[[2]uuid.UUID{key[1], key[0]}] = true
+	}
+	assert.Len(t, byes, 4)
+
+	table, err := service.Standings(tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tournament.Players[0].PlayerID, table[0].PlayerID)
+	assert.Equal(t, 4.0, table[0].Points)
+}
+
+func TestTournamentService_Swiss_BoundsPairingSearch(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	tournament := createTestTournament(enums.SwissFormat, 30, 40)
+	tournament.Status = enums.RunningTournament
+	tournament.CurrentRound = 1
+	// каждый из 21 игрока уже встречался с каждым из 19 остальных: без повторов пары
+	// не свести, а полный перебор вариантов занял бы годы
+	finishedAt := clock.Now()
+	for _, a := range tournament.Players[:21] {
+		for _, b := range tournament.Players[21:] {
+			second := b.PlayerID
+			tournament.Pairings = append(tournament.Pairings, models.TournamentPairing{
+				ID:             uuid.New(),
+				TournamentID:   tournament.ID,
+				Round:          1,
+				FirstPlayerID:  a.PlayerID,
+				SecondPlayerID: &second,
+				Result:         enums.DrawResult,
+				FinishedAt:     &finishedAt,
+			})
+		}
+	}
+	service, games := newTournamentTestService(tournament, clock)
+
+	_, err := service.AdvanceTournaments()
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, tournament.CurrentRound)
+	assert.Len(t, games.created, 20)
+}
+
+func TestTournamentService_SingleElimination_ReplaysDraws(t *testing.T) {
+	tournament := createTestTournament(enums.SingleEliminationFormat, 0, 4)
+	service, games := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	_, err := service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	require.Len(t, games.created, 2)
+	// лучший номер играет с худшим
+	top := tournament.RoundPairings(1)[0]
+	assert.ElementsMatch(t,
+		[]uuid.UUID{tournament.Players[0].PlayerID, tournament.Players[3].PlayerID},
+		[]uuid.UUID{top.FirstPlayerID, *top.SecondPlayerID})
+
+	drawn := games.created[0]
+	finish(drawn, uuid.Nil)
+	_, err = service.AdvanceTournaments()
+	require.NoError(t, err)
+
+	require.Len(t, games.created, 3)
+	replay := games.created[2]
+	assert.Equal(t, drawn.SecondPlayerID, replay.FirstPlayerID)
+	assert.Equal(t, drawn.FirstPlayerID, replay.SecondPlayerID)
+	assert.Equal(t, 1, top.Replays)
+	assert.Equal(t, 1, tournament.CurrentRound)
+
+	playTournament(t, service, tournament, games)
+	assert.Equal(t, 2, tournament.CurrentRound)
+
+	table, err := service.Standings(tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tournament.Players[0].PlayerID, table[0].PlayerID)
+	assert.False(t, table[0].Eliminated)
+	for _, standing := range table[1:] {
+		assert.True(t, standing.Eliminated)
+	}
+}
+
+func TestTournamentService_SingleElimination_HigherSeedAdvancesAfterReplays(t *testing.T) {
+	tournament := createTestTournament(enums.SingleEliminationFormat, 0, 2)
+	service, games := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	_, err := service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+	pairing := tournament.RoundPairings(1)[0]
+
+	for i := 0; i < 10; i++ {
+		game := games.inProgress()
+		if len(game) == 0 {
+			break
+		}
+		// первая партия прервана, остальные заканчиваются ничьей
+		if i == 0 {
+			game[0].Status = enums.Aborted
+		} else {
+			finish(game[0], uuid.Nil)
+		}
+		_, err = service.AdvanceTournaments()
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, games.created, 4)
+	assert.Equal(t, 3, pairing.Replays)
+	assert.Equal(t, enums.FinishedTournament, tournament.Status)
+	assert.Equal(t, 1.0, pairing.Score(tournament.Players[0].PlayerID))
+}
+
+func TestTournamentService_DoubleElimination_NeedsTwoLosses(t *testing.T) {
+	tournament := createTestTournament(enums.DoubleEliminationFormat, 0, 2)
+	service, games := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+	first, second := tournament.Players[0].PlayerID, tournament.Players[1].PlayerID
+
+	_, err := service.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+
+	// второй номер выигрывает первую партию, первый - две следующие
+	for _, winner := range []uuid.UUID{second, first, first} {
+		require.Equal(t, enums.RunningTournament, tournament.Status)
+		inProgress := games.inProgress()
+		require.Len(t, inProgress, 1)
+		finish(inProgress[0], winner)
+		_, err = service.AdvanceTournaments()
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, enums.FinishedTournament, tournament.Status)
+	table, err := service.Standings(tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first, table[0].PlayerID)
+	assert.Equal(t, 1, table[0].Losses)
+	assert.Equal(t, 2, table[1].Losses)
+}
+
+func TestTournamentService_AdvanceTournaments_StartsOnSchedule(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	tournament := createTestTournament(enums.RoundRobinFormat, 0, 2)
+	startsAt := clock.now.Add(time.Hour)
+	tournament.StartsAt = &startsAt
+	service, games := newTournamentTestService(tournament, clock)
+
+	advanced, err := service.AdvanceTournaments()
+	require.NoError(t, err)
+	assert.Zero(t, advanced)
+
+	clock.Advance(time.Hour)
+	advanced, err = service.AdvanceTournaments()
+	require.NoError(t, err)
+	assert.Equal(t, 1, advanced)
+	assert.Equal(t, enums.RunningTournament, tournament.Status)
+	assert.Len(t, games.created, 1)
+}
+
+func TestTournamentService_Standings_TieBreaks(t *testing.T) {
+	tournament := createTestTournament(enums.SwissFormat, 2, 4)
+	a, b, c, d := tournament.Players[0].PlayerID, tournament.Players[1].PlayerID,
+		tournament.Players[2].PlayerID, tournament.Players[3].PlayerID
+	pair := func(round int, first, second uuid.UUID, result enums.PairingResult) models.TournamentPairing {
+		return models.TournamentPairing{Round: round, FirstPlayerID: first, SecondPlayerID: &second, Result: result}
+	}
+	// b и c набирают по пол-очка, но b играл с сильнейшим соперником
+	tournament.Pairings = []models.TournamentPairing{
+		pair(1, a, d, enums.FirstPlayerWin),
+		pair(1, b, c, enums.DrawResult),
+		pair(2, a, b, enums.FirstPlayerWin),
+		pair(2, c, d, enums.SecondPlayerWin),
+	}
+	service, _ := newTournamentTestService(tournament, &fakeClock{now: time.Now()})
+
+	table, err := service.Standings(tournament.ID)
+	require.NoError(t, err)
+
+	order := make([]uuid.UUID, 0, len(table))
+	for _, standing := range table {
+		order = append(order, standing.PlayerID)
+	}
+	assert.Equal(t, []uuid.UUID{a, d, b, c}, order)
+	assert.Equal(t, 2.0, table[0].Points)
+	assert.Equal(t, 2.5, table[2].Buchholz)
+	assert.Equal(t, 1.5, table[3].Buchholz)
+	assert.Equal(t, 0.25, table[3].SonnebornBerger)
+}
+
+// registrations - сервис турниров, который только запоминает действия игроков
+type registrations struct {
+	serviceInterfaces.TournamentService
+	players    []uuid.UUID
+	organizers []uuid.UUID
+}
+
+func (r *registrations) Register(tournamentID, playerID uuid.UUID) (*models.Tournament, error) {
+	r.players = append(r.players, playerID)
+	return &models.Tournament{ID: tournamentID}, nil
+}
+
+func (r *registrations) CreateTournament(_ models.TournamentSettings, organizerID uuid.UUID) (*models.Tournament, error) {
+	r.organizers = append(r.organizers, organizerID)
+	return &models.Tournament{ID: uuid.New(), OrganizerID: organizerID}, nil
+}
+
+// callAPI выполняет запрос к серверу с токеном игрока и возвращает код ответа и ошибку
+func callAPI(t *testing.T, e *echo.Echo, method, path, body, token string) (int, dtos.ErrorResponse) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var resp dtos.ErrorResponse
+	if rec.Code >= http.StatusBadRequest {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec.Code, resp
+}
+
+func TestTournamentController_ActsAsTokenOwner(t *testing.T) {
+	tokens := services.NewTokenService("secret", time.Hour, time.Now)
+	tournaments := &registrations{}
+	controller := controllers.NewTournamentController(tournaments, tokens)
+
+	e := echo.New()
+	e.HTTPErrorHandler = controllers.NewErrorHandler(logrus.New())
+	e.Use(controllers.NewAuthMiddleware(tokens))
+	e.POST("/api/tournaments", controller.CreateTournament)
+	e.POST("/api/tournaments/:tournamentId/register", controller.Register)
+
+	playerID, otherID := uuid.New(), uuid.New()
+	registerPath := "/api/tournaments/" + uuid.NewString() + "/register"
+
+	status, resp := callAPI(t, e, http.MethodPost, registerPath, `{"playerId":"`+otherID.String()+`"}`, "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, string(serviceErrors.CodeAuthenticationRequired), resp.Code)
+
+	status, resp = callAPI(t, e, http.MethodPost, registerPath, `{"playerId":"`+otherID.String()+`"}`, tokens.Issue(playerID, uuid.Nil))
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, string(serviceErrors.CodePlayerMismatch), resp.Code)
+
+	status, _ = callAPI(t, e, http.MethodPost, registerPath, `{}`, tokens.Issue(playerID, uuid.Nil))
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []uuid.UUID{playerID}, tournaments.players)
+
+	status, resp = callAPI(t, e, http.MethodPost, "/api/tournaments", `{"organizerId":"`+otherID.String()+`"}`, tokens.Issue(playerID, uuid.Nil))
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, string(serviceErrors.CodePlayerMismatch), resp.Code)
+	status, _ = callAPI(t, e, http.MethodPost, "/api/tournaments", `{"name":"Weekly"}`, tokens.Issue(playerID, uuid.Nil))
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, []uuid.UUID{playerID}, tournaments.organizers)
+}
+
+func TestTournamentService_AdvanceTournaments_CreatesOnlyMissingGames(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tournament := createTestTournament(enums.RoundRobinFormat, 0, 4)
+	startsAt := clock.Now()
+	tournament.StartsAt = &startsAt
+
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+	mockTournamentRepo.On("ListActive", mock.Anything).Return([]uuid.UUID{tournament.ID}, nil)
+	mockTournamentRepo.On("GetByID", tournament.ID).Return(tournament, nil)
+	mockTournamentRepo.On("Update", tournament).Return(nil)
+	games := &tournamentGames{games: make(map[uuid.UUID]*models.Game), limit: 1}
+	service := services.NewTournamentService(mockTournamentRepo, new(mocks.MockPlayerRepository), games, clock.Now)
+
+	_, err := service.AdvanceTournaments()
+
+	require.Error(t, err)
+	// тур сохранён вместе с уже созданной партией
+	mockTournamentRepo.AssertCalled(t, "Update", tournament)
+	pairings := tournament.RoundPairings(1)
+	require.Len(t, pairings, 2)
+	require.NotNil(t, pairings[0].GameID)
+	assert.Nil(t, pairings[1].GameID)
+
+	games.limit = 0
+	_, err = service.AdvanceTournaments()
+
+	require.NoError(t, err)
+	require.Len(t, games.created, 2)
+	assert.Equal(t, games.created[0].ID, *pairings[0].GameID)
+	assert.Equal(t, games.created[1].ID, *pairings[1].GameID)
+	assert.Len(t, tournament.Pairings, 2)
+}
+
+func TestTournamentService_AdvanceTournaments_ContinuesAfterFailure(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tournament := createTestTournament(enums.RoundRobinFormat, 0, 2)
+	startsAt := clock.Now()
+	tournament.StartsAt = &startsAt
+	brokenID := uuid.New()
+
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+	mockTournamentRepo.On("ListActive", mock.Anything).Return([]uuid.UUID{brokenID, tournament.ID}, nil)
+	mockTournamentRepo.On("GetByID", brokenID).Return(nil, errors.New("db down"))
+	mockTournamentRepo.On("GetByID", tournament.ID).Return(tournament, nil)
+	mockTournamentRepo.On("Update", tournament).Return(nil)
+	games := &tournamentGames{games: make(map[uuid.UUID]*models.Game)}
+	service := services.NewTournamentService(mockTournamentRepo, new(mocks.MockPlayerRepository), games, clock.Now)
+
+	advanced, err := service.AdvanceTournaments()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), brokenID.String())
+	assert.Equal(t, 1, advanced)
+	assert.Equal(t, enums.RunningTournament, tournament.Status)
+	assert.Len(t, games.created, 1)
+}
@@ -0,0 +1,567 @@
+package tests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"nails_game/internal/models"
+	"nails_game/internal/models/enums"
+	repositories "nails_game/internal/repositories/interfaces"
+	serviceErrors "nails_game/internal/services/errors"
+	services "nails_game/internal/services/implemenatation"
+	serviceInterfaces "nails_game/internal/services/interfaces"
+	"nails_game/internal/tests/mocks"
+)
+
+// memoryWebhooks - хранилище вебхуков в памяти; ListForGame отбирает вебхуки как база
+type memoryWebhooks struct {
+	mu         sync.Mutex
+	webhooks   []models.Webhook
+	deliveries []models.WebhookDelivery
+}
+
+func (r *memoryWebhooks) Create(webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks = append(r.webhooks, *webhook)
+	return nil
+}
+
+func (r *memoryWebhooks) GetByID(id uuid.UUID) (*models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.webhooks {
+		if r.webhooks[i].ID == id {
+			webhook := r.webhooks[i]
+			return &webhook, nil
+		}
+	}
+	return nil, repositories.ErrWebhookNotFound
+}
+
+func (r *memoryWebhooks) Delete(id uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func (r *memoryWebhooks) ListForGame(game *models.Game) ([]models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var webhooks []models.Webhook
+	for _, webhook := range r.webhooks {
+		switch webhook.Scope {
+		case enums.GlobalWebhook:
+		case enums.PlayerWebhook:
+			if webhook.OwnerID == nil || !game.HasPlayer(*webhook.OwnerID) {
+				continue
+			}
+		case enums.TournamentWebhook:
+			if game.TournamentID == nil || *webhook.TournamentID != *game.TournamentID {
+				continue
+			}
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func (r *memoryWebhooks) AddDeliveries(deliveries []models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, deliveries...)
+	return nil
+}
+
+func (r *memoryWebhooks) GetDelivery(id uuid.UUID) (*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == id {
+			delivery := r.deliveries[i]
+			return &delivery, nil
+		}
+	}
+	return nil, repositories.ErrDeliveryNotFound
+}
+
+func (r *memoryWebhooks) ClaimDue(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []models.WebhookDelivery
+	for j := range r.deliveries {
+		if len(due) == limit {
+			break
+		}
+		if r.deliveries[j].Status != enums.PendingDelivery || r.deliveries[j].NextAttemptAt.After(now) {
+			continue
+		}
+		r.deliveries[j].NextAttemptAt = leaseUntil
+		delivery := r.deliveries[j]
+		for i := range r.webhooks {
+			if r.webhooks[i].ID == delivery.WebhookID {
+				webhook := r.webhooks[i]
+				delivery.Webhook = &webhook
+			}
+		}
+		due = append(due, delivery)
+	}
+	return due, nil
+}
+
+func (r *memoryWebhooks) ListDeliveries(webhookID uuid.UUID, status enums.DeliveryStatus) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []models.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == webhookID && delivery.Status == status {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *memoryWebhooks) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == delivery.ID {
+			r.deliveries[i] = *delivery
+			r.deliveries[i].Webhook = nil
+		}
+	}
+	return nil
+}
+
+func testWebhookPolicy() serviceInterfaces.WebhookPolicy {
+	return serviceInterfaces.WebhookPolicy{
+		MaxAttempts: 3,
+		RetryBase:   10 * time.Second,
+		RetryMax:    15 * time.Second,
+		Timeout:     time.Second,
+		// тестовые серверы слушают 127.0.0.1
+		AllowPrivateTargets: true,
+	}
+}
+
+func newWebhookTestService(repo *memoryWebhooks, clock *fakeClock) serviceInterfaces.WebhookService {
+	return services.NewWebhookService(repo, new(mocks.MockTournamentRepository), testWebhookPolicy(), clock.Now)
+}
+
+// recordDeliveries готовит доставки событий партии и записывает их, как репозиторий партий
+func recordDeliveries(t *testing.T, service serviceInterfaces.WebhookService, repo *memoryWebhooks, game *models.Game) {
+	t.Helper()
+	deliveries, err := service.Outbox(game, game.PendingEvents())
+	require.NoError(t, err)
+	require.NoError(t, repo.AddDeliveries(deliveries))
+}
+
+func deliveryPayloads(t *testing.T, deliveries []models.WebhookDelivery) []map[string]interface{} {
+	payloads := make([]map[string]interface{}, len(deliveries))
+	for i, delivery := range deliveries {
+		require.NoError(t, json.Unmarshal(delivery.Payload, &payloads[i]))
+	}
+	return payloads
+}
+
+func TestWebhookService_Publish_RecordsSubscribedEvents(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	game := createPrivateTestGame()
+	game.Private = false
+	strangerID := uuid.New()
+	repo := &memoryWebhooks{webhooks: []models.Webhook{
+		{ID: uuid.New(), Scope: enums.GlobalWebhook},
+		{ID: uuid.New(), Scope: enums.PlayerWebhook, OwnerID: &game.SecondPlayerID, Events: []enums.WebhookEvent{enums.GameFinishedWebhook}},
+		{ID: uuid.New(), Scope: enums.PlayerWebhook, OwnerID: &strangerID},
+	}}
+	service := newWebhookTestService(repo, clock)
+
+	game.Raise(&models.MovePlayed{PlayerID: game.FirstPlayerID, Position: 3, State: enums.FirstPlayer, NextPlayerID: game.SecondPlayerID})
+	game.Raise(&models.DrawOffered{PlayerID: game.SecondPlayerID})
+	game.Raise(&models.PlayerResigned{PlayerID: game.SecondPlayerID})
+	game.Raise(&models.GameFinished{Status: enums.FirstPlayerWon, Termination: enums.ResignTermination})
+	recordDeliveries(t, service, repo, game)
+
+	require.Len(t, repo.deliveries, 4)
+	var events []enums.WebhookEvent
+	for _, delivery := range repo.deliveries {
+		events = append(events, delivery.Event)
+		assert.Equal(t, enums.PendingDelivery, delivery.Status)
+		assert.Equal(t, clock.Now(), delivery.NextAttemptAt)
+	}
+	assert.Equal(t, []enums.WebhookEvent{
+		enums.GameCreatedWebhook, enums.MovePlayedWebhook, enums.GameFinishedWebhook, enums.GameFinishedWebhook,
+	}, events)
+	assert.Equal(t, repo.webhooks[1].ID, repo.deliveries[3].WebhookID)
+
+	payloads := deliveryPayloads(t, repo.deliveries)
+	assert.Equal(t, game.ID.String(), payloads[1]["gameId"])
+	assert.Equal(t, float64(3), payloads[1]["data"].(map[string]interface{})["position"])
+	finished := payloads[2]["data"].(map[string]interface{})
+	assert.Equal(t, "FIRST_PLAYER_WON", finished["status"])
+	assert.Equal(t, game.FirstPlayerID.String(), finished["winnerId"])
+	// одно событие доходит до всех вебхуков с одним идентификатором
+	assert.Equal(t, payloads[2]["id"], payloads[3]["id"])
+}
+
+func TestWebhookService_Publish_HidesFogAndPrivateGames(t *testing.T) {
+	clock := &fakeClock{now: time.Now().UTC()}
+	fog := createFogTestGame(clock)
+	tournamentID := uuid.New()
+	fog.TournamentID = &tournamentID
+	private := createPrivateTestGame()
+	repo := &memoryWebhooks{webhooks: []models.Webhook{
+		{ID: uuid.New(), Scope: enums.GlobalWebhook},
+		{ID: uuid.New(), Scope: enums.TournamentWebhook, TournamentID: fog.TournamentID},
+		{ID: uuid.New(), Scope: enums.PlayerWebhook, OwnerID: &fog.FirstPlayerID},
+		{ID: uuid.New(), Scope: enums.PlayerWebhook, OwnerID: &private.FirstPlayerID},
+	}}
+	service := newWebhookTestService(repo, clock)
+
+	fog.ClearPendingEvents()
+	fog.Raise(&models.MovePlayed{PlayerID: fog.FirstPlayerID, Position: 4, State: enums.FirstPlayer, NextPlayerID: fog.SecondPlayerID})
+	recordDeliveries(t, service, repo, fog)
+
+	require.Len(t, repo.deliveries, 3)
+	for _, payload := range deliveryPayloads(t, repo.deliveries) {
+		assert.NotContains(t, payload["data"], "position")
+	}
+
+	repo.deliveries = nil
+	recordDeliveries(t, service, repo, private)
+
+	require.Len(t, repo.deliveries, 1)
+	assert.Equal(t, repo.webhooks[3].ID, repo.deliveries[0].WebhookID)
+}
+
+func TestWebhookService_DeliverWebhooks_SignsPayload(t *testing.T) {
+	var received []*http.Request
+	var bodies [][]byte
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now().UTC()}
+	webhook := models.Webhook{ID: uuid.New(), URL: server.URL, Scope: enums.GlobalWebhook, Secret: "webhook-secret"}
+	repo := &memoryWebhooks{webhooks: []models.Webhook{webhook}}
+	service := newWebhookTestService(repo, clock)
+	game := createPrivateTestGame()
+	game.Private = false
+	recordDeliveries(t, service, repo, game)
+
+	delivered, err := service.DeliverWebhooks()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	require.Len(t, received, 1)
+	assert.Equal(t, "game.created", received[0].Header.Get("X-Nails-Event"))
+	assert.Equal(t, repo.deliveries[0].ID.String(), received[0].Header.Get("X-Nails-Delivery"))
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write(bodies[0])
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received[0].Header.Get("X-Nails-Signature"))
+
+	assert.Equal(t, enums.DeliveredStatus, repo.deliveries[0].Status)
+	assert.Equal(t, 1, repo.deliveries[0].Attempts)
+	require.NotNil(t, repo.deliveries[0].DeliveredAt)
+
+	delivered, err = service.DeliverWebhooks()
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Len(t, received, 1)
+}
+
+func TestWebhookService_DeliverWebhooks_BacksOffAndDeadLetters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now().UTC()}
+	webhook := models.Webhook{ID: uuid.New(), URL: server.URL, Scope: enums.GlobalWebhook, Secret: "webhook-secret"}
+	repo := &memoryWebhooks{webhooks: []models.Webhook{webhook}}
+	service := newWebhookTestService(repo, clock)
+	game := createPrivateTestGame()
+	game.Private = false
+	recordDeliveries(t, service, repo, game)
+	actor := serviceInterfaces.WebhookActor{Admin: true}
+
+	delivered, err := service.DeliverWebhooks()
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Equal(t, enums.PendingDelivery, repo.deliveries[0].Status)
+	assert.Equal(t, clock.Now().Add(10*time.Second), repo.deliveries[0].NextAttemptAt)
+	assert.Contains(t, repo.deliveries[0].LastError, "503")
+
+	// до следующей попытки доставка не отправляется
+	clock.Advance(5 * time.Second)
+	_, err = service.DeliverWebhooks()
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.deliveries[0].Attempts)
+
+	clock.Advance(5 * time.Second)
+	_, err = service.DeliverWebhooks()
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.deliveries[0].Attempts)
+	// вторая пауза удвоилась бы до 20s, но ограничена RetryMax
+	assert.Equal(t, clock.Now().Add(15*time.Second), repo.deliveries[0].NextAttemptAt)
+
+	deliveryID := repo.deliveries[0].ID
+	_, err = service.RetryDelivery(actor, webhook.ID, deliveryID)
+	assertErrorCode(t, err, serviceErrors.CodeDeliveryNotDead)
+
+	clock.Advance(15 * time.Second)
+	_, err = service.DeliverWebhooks()
+	require.NoError(t, err)
+	dead, err := service.Deliveries(actor, webhook.ID, enums.DeadDelivery)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].Attempts)
+
+	retried, err := service.RetryDelivery(actor, webhook.ID, deliveryID)
+	require.NoError(t, err)
+	assert.Equal(t, enums.PendingDelivery, retried.Status)
+	assert.Zero(t, retried.Attempts)
+	assert.Equal(t, clock.Now(), retried.NextAttemptAt)
+
+	_, err = service.RetryDelivery(actor, webhook.ID, uuid.New())
+	assertErrorCode(t, err, serviceErrors.CodeDeliveryNotFound)
+}
+
+func TestWebhookService_Register_ChecksScope(t *testing.T) {
+	clock := &fakeClock{now: time.Now().UTC()}
+	tournament := &models.Tournament{ID: uuid.New(), OrganizerID: uuid.New()}
+	tournamentRepo := new(mocks.MockTournamentRepository)
+	tournamentRepo.On("GetByID", tournament.ID).Return(tournament, nil)
+	repo := &memoryWebhooks{}
+	service := services.NewWebhookService(repo, tournamentRepo, testWebhookPolicy(), clock.Now)
+	player := serviceInterfaces.WebhookActor{PlayerID: uuid.New()}
+
+	_, err := service.Register(player, models.WebhookSettings{
+		URL:    "ftp://example.com",
+		Scope:  enums.PlayerWebhook,
+		Events: []enums.WebhookEvent{"rating.changed"},
+	})
+	var validation *serviceErrors.ValidationError
+	require.ErrorAs(t, err, &validation)
+	assert.Equal(t, "url", validation.Fields[0].Field)
+	assert.Equal(t, "events", validation.Fields[1].Field)
+
+	_, err = service.Register(player, models.WebhookSettings{URL: "https://example.com/hook", Scope: enums.GlobalWebhook})
+	assertErrorCode(t, err, serviceErrors.CodeAdminKeyRequired)
+
+	tournamentSettings := models.WebhookSettings{URL: "https://example.com/hook", Scope: enums.TournamentWebhook, TournamentID: &tournament.ID}
+	_, err = service.Register(player, tournamentSettings)
+	assertErrorCode(t, err, serviceErrors.CodeNotOrganizer)
+	webhook, err := service.Register(serviceInterfaces.WebhookActor{PlayerID: tournament.OrganizerID}, tournamentSettings)
+	require.NoError(t, err)
+	assert.Equal(t, &tournament.ID, webhook.TournamentID)
+
+	webhook, err = service.Register(player, models.WebhookSettings{URL: "https://example.com/hook", Scope: enums.PlayerWebhook})
+	require.NoError(t, err)
+	assert.Equal(t, &player.PlayerID, webhook.OwnerID)
+	assert.Len(t, webhook.Secret, 64)
+
+	_, err = service.Deliveries(serviceInterfaces.WebhookActor{PlayerID: uuid.New()}, webhook.ID, enums.DeadDelivery)
+	assertErrorCode(t, err, serviceErrors.CodeNotWebhookOwner)
+	_, err = service.Deliveries(player, uuid.New(), enums.DeadDelivery)
+	assertErrorCode(t, err, serviceErrors.CodeWebhookNotFound)
+}
+
+// recordingPublisher запоминает опубликованные события
+type recordingPublisher struct {
+	events [][]models.GameEvent
+}
+
+func (p *recordingPublisher) Publish(game *models.Game, events []models.GameEvent) {
+	p.events = append(p.events, events)
+}
+
+func TestGameService_PublishesEventsAfterSave(t *testing.T) {
+	game := createTestGame()
+	publisher := &recordingPublisher{}
+	mockGameRepo := new(mocks.MockGameRepository)
+	mockGameRepo.On("GetByID", game.ID).Return(game, nil)
+	mockGameRepo.On("Update", mock.Anything).Return(errors.New("db down")).Once()
+	mockGameRepo.On("Update", mock.Anything).Return(nil)
+	service := services.NewGameService(mockGameRepo, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository),
+		testPolicy(), services.WithEventPublisher(publisher))
+
+	_, err := service.Resign(game.ID, game.FirstPlayerID)
+	require.Error(t, err)
+	assert.Empty(t, publisher.events)
+
+	game.Status = enums.InProgress
+	game.ClearPendingEvents()
+	_, err = service.Resign(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	require.Len(t, publisher.events, 1)
+	assert.IsType(t, &models.PlayerResigned{}, publisher.events[0][0])
+	assert.IsType(t, &models.GameFinished{}, publisher.events[0][1])
+}
+
+// outboxGames - репозиторий партий, который, как база, записывает доставки вебхуков
+// только вместе с успешно сохранённой партией
+type outboxGames struct {
+	mocks.MockGameRepository
+	webhooks *memoryWebhooks
+	err      error
+}
+
+func (g *outboxGames) Create(game *models.Game, deliveries ...models.WebhookDelivery) error {
+	return g.save(game, deliveries)
+}
+
+func (g *outboxGames) Update(game *models.Game, deliveries ...models.WebhookDelivery) error {
+	return g.save(game, deliveries)
+}
+
+func (g *outboxGames) save(game *models.Game, deliveries []models.WebhookDelivery) error {
+	if g.err != nil {
+		return g.err
+	}
+	game.ClearPendingEvents()
+	return g.webhooks.AddDeliveries(deliveries)
+}
+
+func TestGameService_RecordsWebhookDeliveriesWithEvents(t *testing.T) {
+	game := createTestGame()
+	repo := &memoryWebhooks{webhooks: []models.Webhook{{ID: uuid.New(), Scope: enums.GlobalWebhook}}}
+	games := &outboxGames{webhooks: repo, err: errors.New("db down")}
+	games.On("GetByID", game.ID).Return(game, nil)
+	service := services.NewGameService(games, new(mocks.MockPlayerRepository), new(mocks.MockBoardPresetRepository),
+		testPolicy(), services.WithEventOutbox(newWebhookTestService(repo, &fakeClock{now: time.Now()})))
+
+	_, err := service.Resign(game.ID, game.FirstPlayerID)
+	require.Error(t, err)
+	assert.Empty(t, repo.deliveries)
+
+	games.err = nil
+	game.Status = enums.InProgress
+	game.ClearPendingEvents()
+	_, err = service.Resign(game.ID, game.FirstPlayerID)
+	require.NoError(t, err)
+	require.Len(t, repo.deliveries, 1)
+	assert.Equal(t, enums.GameFinishedWebhook, repo.deliveries[0].Event)
+}
+
+func TestWebhookService_TournamentWebhookGetsGameCreated(t *testing.T) {
+	clock := &fakeClock{now: time.Now().UTC()}
+	tournament := createTestTournament(enums.RoundRobinFormat, 0, 2)
+	repo := &memoryWebhooks{webhooks: []models.Webhook{
+		{ID: uuid.New(), Scope: enums.TournamentWebhook, TournamentID: &tournament.ID},
+	}}
+	webhooks := newWebhookTestService(repo, clock)
+
+	mockPlayerRepo := new(mocks.MockPlayerRepository)
+	mockPlayerRepo.On("GetByID", mock.Anything).Return(&models.Player{}, nil)
+	gameService := services.NewGameService(&outboxGames{webhooks: repo}, mockPlayerRepo, new(mocks.MockBoardPresetRepository), testPolicy(),
+		services.WithEventOutbox(webhooks))
+	mockTournamentRepo := new(mocks.MockTournamentRepository)
+	mockTournamentRepo.On("GetByID", tournament.ID).Return(tournament, nil)
+	mockTournamentRepo.On("Update", tournament).Return(nil)
+	tournamentService := services.NewTournamentService(mockTournamentRepo, mockPlayerRepo, gameService, clock.Now)
+
+	_, err := tournamentService.Start(tournament.ID, tournament.OrganizerID)
+	require.NoError(t, err)
+
+	require.Len(t, repo.deliveries, 1)
+	assert.Equal(t, enums.GameCreatedWebhook, repo.deliveries[0].Event)
+	assert.Equal(t, tournament.RoundPairings(1)[0].GameID.String(), deliveryPayloads(t, repo.deliveries)[0]["gameId"])
+}
+
+func TestWebhookService_DeliverWebhooks_ClaimsDeliveries(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now().UTC()}
+	webhook := models.Webhook{ID: uuid.New(), URL: server.URL, Scope: enums.GlobalWebhook, Secret: "webhook-secret"}
+	repo := &memoryWebhooks{webhooks: []models.Webhook{webhook}}
+	require.NoError(t, repo.AddDeliveries([]models.WebhookDelivery{{
+		ID: uuid.New(), WebhookID: webhook.ID, Event: enums.GameCreatedWebhook,
+		Status: enums.PendingDelivery, NextAttemptAt: clock.Now(),
+	}}))
+	// два экземпляра сервера с общей базой
+	first, second := newWebhookTestService(repo, clock), newWebhookTestService(repo, clock)
+
+	done := make(chan int)
+	go func() {
+		delivered, _ := first.DeliverWebhooks()
+		done <- delivered
+	}()
+	<-received
+
+	delivered, err := second.DeliverWebhooks()
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	close(release)
+	assert.Equal(t, 1, <-done)
+}
+
+func TestWebhookService_RejectsPrivateTargets(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now().UTC()}
+	policy := testWebhookPolicy()
+	policy.AllowPrivateTargets = false
+	// вебхук, зарегистрированный раньше, например когда имя указывало на публичный адрес
+	webhook := models.Webhook{ID: uuid.New(), URL: server.URL, Scope: enums.GlobalWebhook, Secret: "webhook-secret"}
+	repo := &memoryWebhooks{webhooks: []models.Webhook{webhook}}
+	service := services.NewWebhookService(repo, new(mocks.MockTournamentRepository), policy, clock.Now)
+	player := serviceInterfaces.WebhookActor{PlayerID: uuid.New()}
+
+	for _, target := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"https://10.1.2.3/hook",
+		"http://192.168.0.10/hook",
+		"http://0.0.0.0/hook",
+		"http://224.0.0.1/hook",
+		"http://localhost:8080/hook",
+	} {
+		_, err := service.Register(player, models.WebhookSettings{URL: target, Scope: enums.PlayerWebhook})
+		var validation *serviceErrors.ValidationError
+		require.ErrorAs(t, err, &validation, target)
+		assert.Equal(t, "url", validation.Fields[0].Field, target)
+	}
+	_, err := service.Register(player, models.WebhookSettings{URL: "https://93.184.216.34/hook", Scope: enums.PlayerWebhook})
+	require.NoError(t, err)
+
+	game := createPrivateTestGame()
+	game.Private = false
+	recordDeliveries(t, service, repo, game)
+	delivered, err := service.DeliverWebhooks()
+
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Zero(t, calls)
+	assert.Contains(t, repo.deliveries[0].LastError, "not a public address")
+}